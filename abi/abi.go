@@ -0,0 +1,96 @@
+// Interface-based facade for exchanging UUIDs across plugin boundaries
+//
+// A host program and its plugins may be built against different copies or
+// versions of this module, in which case their uuid25.Uuid25 types are
+// distinct and values of one cannot be asserted to the other. This package
+// avoids the problem by exchanging UUIDs through the ID interface, whose method
+// set consists only of predeclared types, so any implementation built from any
+// copy of this package satisfies it on both sides of the boundary.
+//
+// A plugin API should accept and return ID rather than uuid25.Uuid25:
+//
+//	// shared between host and plugin
+//	type Handler interface {
+//		Handle(id abi.ID) (abi.ID, error)
+//	}
+//
+// When the boundary is an RPC connection, as with hashicorp/go-plugin, send the
+// string or byte representation returned by the ID methods instead of the
+// interface value and restore it on the other side with Parse() or
+// FromBytes().
+package abi
+
+import (
+	"errors"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// A UUID value that can cross plugin boundaries.
+//
+// Implementations must return consistent results: UUIDString() returns the
+// 25-digit Uuid25 format of the value whose 16-byte representation UUIDBytes()
+// returns.
+type ID interface {
+	// Returns the 25-digit Uuid25 representation.
+	UUIDString() string
+
+	// Returns the 16-byte binary representation.
+	UUIDBytes() [16]byte
+}
+
+// The unexported implementation of ID.
+type id uuid25.Uuid25
+
+func (x id) UUIDString() string {
+	return uuid25.Uuid25(x).String()
+}
+
+func (x id) UUIDBytes() [16]byte {
+	return uuid25.Uuid25(x).ToBytes()
+}
+
+// Returns the Uuid25 format, so that an ID prints nicely with fmt.
+func (x id) String() string {
+	return x.UUIDString()
+}
+
+// Wraps a Uuid25 value as an ID.
+//
+// This function panics if the value is not constructed properly.
+func Wrap(uuid25 uuid25.Uuid25) ID {
+	return id(uuid25.String())
+}
+
+// Creates an ID from a UUID string representation in any format accepted by
+// uuid25.Parse().
+func Parse(s string) (ID, error) {
+	uuid25, err := uuid25.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return id(uuid25), nil
+}
+
+// Creates an ID from a 16-byte UUID binary representation.
+func FromBytes(b [16]byte) ID {
+	return id(uuid25.FromBytes(b[:]))
+}
+
+// Converts an ID, which may come from another copy of this package, into a
+// Uuid25 value of this copy.
+//
+// The value is reconstructed from UUIDBytes() and checked against UUIDString()
+// to detect a faulty implementation. This function returns an error if `x` is
+// nil or inconsistent.
+func Unwrap(x ID) (uuid25.Uuid25, error) {
+	if x == nil {
+		return "", errors.New("nil ID")
+	}
+	b := x.UUIDBytes()
+	uuid25 := uuid25.FromBytes(b[:])
+	if x.UUIDString() != uuid25.String() {
+		return "", errors.New("inconsistent ID representations")
+	}
+	return uuid25, nil
+}
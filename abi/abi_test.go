@@ -0,0 +1,48 @@
+package abi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// An ID implementation standing in for one built from another copy of this
+// package.
+type foreignID struct {
+	s string
+	b [16]byte
+}
+
+func (x foreignID) UUIDString() string  { return x.s }
+func (x foreignID) UUIDBytes() [16]byte { return x.b }
+
+// Tests conversions through the facade.
+func TestFacade(t *testing.T) {
+	u := uuid25.Uuid25("3ud3gtvgolimgu9lah6aie99o")
+	b := u.ToBytes()
+
+	x := Wrap(u)
+	if x.UUIDString() != u.String() || x.UUIDBytes() != b || fmt.Sprint(x) != u.String() {
+		t.Fail()
+	}
+	if y, err := Parse("40eb9860-cf3e-45e2-a90e-b82236ac806c"); err != nil || y != x {
+		t.Fail()
+	}
+	if _, err := Parse("invalid"); err == nil {
+		t.Fail()
+	}
+	if FromBytes(b) != x {
+		t.Fail()
+	}
+
+	if y, err := Unwrap(foreignID{u.String(), b}); y != u || err != nil {
+		t.Fail()
+	}
+	if _, err := Unwrap(foreignID{"0000000000000000000000000", b}); err == nil {
+		t.Fail()
+	}
+	if _, err := Unwrap(nil); err == nil {
+		t.Fail()
+	}
+}
@@ -0,0 +1,61 @@
+package uuid25
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// Tests the binary encoding of Annotated.
+func TestAnnotatedBinary(t *testing.T) {
+	a := Annotated{
+		ID:        "dpoadk8izg9y4tte7vy1xt94o",
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 6, time.FixedZone("JST", 9*3600)),
+		Actor:     "alice",
+	}
+	data, err := a.MarshalBinary()
+	if err != nil || len(data) != 28+5 {
+		t.Fatal(err)
+	}
+	var b Annotated
+	if b.UnmarshalBinary(data) != nil || b.ID != a.ID || !b.CreatedAt.Equal(a.CreatedAt) || b.Actor != "alice" {
+		t.Fail()
+	}
+	if b.CreatedAt.Location() != time.UTC {
+		t.Fail()
+	}
+
+	zero := Annotated{ID: a.ID}
+	data, _ = zero.MarshalBinary()
+	if b.UnmarshalBinary(data) != nil || !b.CreatedAt.Equal(time.Time{}) || b.Actor != "" {
+		t.Fail()
+	}
+
+	if _, err := (Annotated{}).MarshalBinary(); err == nil {
+		t.Fail()
+	}
+	if b.UnmarshalBinary(data[:27]) == nil {
+		t.Fail()
+	}
+	data[24] = 0xff
+	if b.UnmarshalBinary(data) == nil {
+		t.Fail()
+	}
+}
+
+// Tests the JSON encoding of Annotated.
+func TestAnnotatedJSON(t *testing.T) {
+	a := Annotated{
+		ID:        "dpoadk8izg9y4tte7vy1xt94o",
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	data, err := json.Marshal(a)
+	if err != nil || string(data) != `{"id":"dpoadk8izg9y4tte7vy1xt94o","created_at":"2024-01-02T03:04:05Z"}` {
+		t.Errorf("got %s", data)
+	}
+	var b Annotated
+	if json.Unmarshal([]byte(`{"id":"e7a1d63b-7117-4423-8988-afcf12161878","created_at":"2024-01-02T03:04:05Z","actor":"bob"}`), &b) != nil ||
+		b.ID != a.ID || !b.CreatedAt.Equal(a.CreatedAt) || b.Actor != "bob" {
+		t.Fail()
+	}
+}
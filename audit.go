@@ -0,0 +1,63 @@
+package uuid25
+
+import (
+	"errors"
+	"strings"
+)
+
+// An error returned by RoundTripCheck() when a string is a valid UUID but not
+// in the canonical (lowercase) form of its format.
+var ErrNotCanonical = errors.New("UUID string not in canonical form")
+
+// An error returned by RoundTripCheck() when a conversion does not restore the
+// original value.
+var ErrRoundTrip = errors.New("UUID conversion round trip mismatch")
+
+// Verifies that a stored UUID string is canonical and convertible.
+//
+// This function parses `s`, checks that `s` is byte-for-byte identical to the
+// canonical representation of the format it is written in, and then converts
+// the value into every supported format and the 16-byte representation and back,
+// comparing the results with the original value. It returns a parse error if
+// `s` is invalid, ErrNotCanonical if `s` is valid but, for example, contains
+// uppercase letters, and ErrRoundTrip if any conversion is lossy. The function
+// is intended for data-quality jobs validating stored identifiers.
+func RoundTripCheck(s string) error {
+	uuid25, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	formats := []struct {
+		format func() string
+		parse  func(string) (Uuid25, error)
+	}{
+		{uuid25.String, ParseUuid25},
+		{uuid25.ToHex, ParseHex},
+		{uuid25.ToHyphenated, ParseHyphenated},
+		{uuid25.ToBraced, ParseBraced},
+		{uuid25.ToUrn, ParseUrn},
+	}
+	canonical := false
+	for _, e := range formats {
+		formatted := e.format()
+		if formatted == s {
+			canonical = true
+		}
+		if restored, err := e.parse(formatted); err != nil || restored != uuid25 {
+			return ErrRoundTrip
+		}
+		if restored, err := Parse(strings.ToUpper(formatted)); err != nil || restored != uuid25 {
+			return ErrRoundTrip
+		}
+	}
+	b := uuid25.ToBytes()
+	if FromBytes(b[:]) != uuid25 {
+		return ErrRoundTrip
+	}
+
+	if !canonical {
+		return ErrNotCanonical
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package uuid25
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Tests the round trip check of stored representations.
+func TestRoundTripCheck(t *testing.T) {
+	for _, e := range testCases {
+		for _, s := range []string{e.uuid25, e.hex, e.hyphenated, e.braced, e.urn} {
+			if err := RoundTripCheck(s); err != nil {
+				t.Errorf("unexpected error for %s: %v", s, err)
+			}
+			if upper := strings.ToUpper(s); upper != s {
+				if err := RoundTripCheck(upper); err != ErrNotCanonical {
+					t.Errorf("unexpected error for %s: %v", upper, err)
+				}
+			}
+		}
+	}
+	if err := RoundTripCheck("40eb9860-cf3e-45e2-a90e-b82236ac806"); !errors.Is(err, ErrParse) {
+		t.Fail()
+	}
+}
@@ -0,0 +1,175 @@
+// Conversion of digit value arrays between arbitrary bases
+//
+// This package provides the base conversion engine behind the Uuid25 encoding.
+// A number is represented as a big-endian array of digit values, each of which
+// is a byte smaller than the base, and Convert() rewrites such an array in one
+// base into another in any base from 2 to 256. DecodeDigits() and
+// EncodeDigits() translate between digit values and the case-insensitive digit
+// characters `0-9a-z` for bases up to 36.
+//
+// For example, the following converts a hexadecimal string into a Base36 string
+// of a fixed length:
+//
+//	src := make([]byte, len(hex))
+//	if err := baseconv.DecodeDigits(hex, src, 16); err != nil {
+//		return err
+//	}
+//	dst := make([]byte, 25)
+//	if err := baseconv.Convert(src, dst, 16, 36); err != nil {
+//		return err
+//	}
+//	s, err := baseconv.EncodeDigits(dst, 36)
+package baseconv
+
+import (
+	"errors"
+	"math"
+)
+
+// An error returned when a base is out of the supported range.
+var ErrInvalidBase = errors.New("invalid base")
+
+// An error returned when a digit value or character is not valid in the base.
+var ErrInvalidDigit = errors.New("invalid digit")
+
+// An error returned when the destination is too short to hold the result.
+var ErrOverflow = errors.New("destination too short")
+
+// An error returned when the source and destination lengths differ where they
+// must be equal.
+var ErrLengthMismatch = errors.New("mismatched source and destination lengths")
+
+// Converts a digit value array in `srcBase` to that in `dstBase`.
+//
+// Both bases must be within 2 to 256. This function fills the whole `dst` with
+// the result, padding it with leading zeros, and returns ErrOverflow if the
+// result does not fit in `dst`. The content of `dst` is unspecified if an error
+// is returned.
+func Convert(src []byte, dst []byte, srcBase int, dstBase int) error {
+	if srcBase < 2 || srcBase > 256 || dstBase < 2 || dstBase > 256 {
+		return ErrInvalidBase
+	}
+	sb, db := uint(srcBase), uint(dstBase)
+
+	// determine the number of `src` digits to read for each outer loop
+	wordLen := 1
+	wordBase := sb
+	for wordBase <= math.MaxUint/(sb*db) {
+		wordLen += 1
+		wordBase *= sb
+	}
+
+	for i := range dst {
+		dst[i] = 0
+	}
+
+	if len(src) == 0 {
+		return nil
+	} else if len(dst) == 0 {
+		return ErrOverflow
+	}
+
+	dstUsed := len(dst) - 1 // storage to memorize range of `dst` filled
+
+	// read `wordLen` digits from `src` for each outer loop
+	wordHead := len(src) % wordLen
+	if wordHead > 0 {
+		wordHead -= wordLen
+	}
+	for ; wordHead < len(src); wordHead += wordLen {
+		var carry uint = 0
+		i := wordHead
+		if i < 0 {
+			i = 0
+		}
+		for ; i < wordHead+wordLen; i += 1 {
+			e := uint(src[i])
+			if e >= sb {
+				return ErrInvalidDigit
+			}
+			carry = carry*sb + e
+		}
+
+		// fill in `dst` from right to left, while carrying up prior result to left
+		for i := len(dst) - 1; i >= 0; i -= 1 {
+			carry += uint(dst[i]) * wordBase
+			dst[i] = byte(carry % db)
+			carry /= db
+
+			// break inner loop when `carry` and remaining `dst` digits are all zero
+			if carry == 0 && i <= dstUsed {
+				dstUsed = i
+				break
+			}
+		}
+		if carry > 0 {
+			return ErrOverflow
+		}
+	}
+	return nil
+}
+
+// The digit characters for bases up to 36.
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// An O(1) map from ASCII code points to Base36 digit values.
+var decodeMap = [256]byte{
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x01, 0x02, 0x03,
+	0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16,
+	0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20, 0x21, 0x22, 0x23,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+	0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d,
+	0x1e, 0x1f, 0x20, 0x21, 0x22, 0x23, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+}
+
+// Converts from a string of case-insensitive digit characters to an array of
+// digit values.
+//
+// The base must be within 2 to 36, and `dst` must have the same length as
+// `src`.
+func DecodeDigits(src string, dst []byte, base int) error {
+	if base < 2 || base > 36 {
+		return ErrInvalidBase
+	} else if len(src) != len(dst) {
+		return ErrLengthMismatch
+	}
+	for i := 0; i < len(src); i += 1 {
+		dst[i] = decodeMap[src[i]]
+		if int(dst[i]) >= base {
+			return ErrInvalidDigit
+		}
+	}
+	return nil
+}
+
+// Converts from an array of digit values to a string of lowercase digit
+// characters.
+//
+// The base must be within 2 to 36.
+func EncodeDigits(src []byte, base int) (string, error) {
+	if base < 2 || base > 36 {
+		return "", ErrInvalidBase
+	}
+	buffer := make([]byte, len(src))
+	for i, e := range src {
+		if int(e) >= base {
+			return "", ErrInvalidDigit
+		}
+		buffer[i] = digits[e]
+	}
+	return string(buffer), nil
+}
@@ -0,0 +1,95 @@
+package baseconv
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// Tests conversions between random pairs of bases against math/big.
+func TestConvert(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i += 1 {
+		srcBase, dstBase := 2+r.Intn(255), 2+r.Intn(255)
+		src := make([]byte, r.Intn(40))
+		for j := range src {
+			src[j] = byte(r.Intn(srcBase))
+		}
+
+		x := new(big.Int)
+		for _, e := range src {
+			x.Mul(x, big.NewInt(int64(srcBase)))
+			x.Add(x, big.NewInt(int64(e)))
+		}
+		var expected []byte
+		for y := new(big.Int).Set(x); y.Sign() > 0; {
+			m := new(big.Int)
+			y.DivMod(y, big.NewInt(int64(dstBase)), m)
+			expected = append([]byte{byte(m.Int64())}, expected...)
+		}
+
+		dst := make([]byte, len(expected)+r.Intn(3))
+		if err := Convert(src, dst, srcBase, dstBase); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(dst[len(dst)-len(expected):], expected) ||
+			!bytes.Equal(dst[:len(dst)-len(expected)], make([]byte, len(dst)-len(expected))) {
+			t.Fatalf("unexpected result: %v (%d to %d)", src, srcBase, dstBase)
+		}
+
+		if len(expected) > 0 {
+			if err := Convert(src, dst[:len(expected)-1], srcBase, dstBase); err != ErrOverflow {
+				t.Fatal("Convert must report a too short destination")
+			}
+		}
+	}
+}
+
+// Tests error returns instead of panics.
+func TestErrors(t *testing.T) {
+	dst := make([]byte, 4)
+	if Convert([]byte{1}, dst, 1, 10) != ErrInvalidBase || Convert([]byte{1}, dst, 10, 257) != ErrInvalidBase {
+		t.Fail()
+	}
+	if Convert([]byte{1, 10}, dst, 10, 16) != ErrInvalidDigit {
+		t.Fail()
+	}
+	if DecodeDigits("12", dst, 10) != ErrLengthMismatch {
+		t.Fail()
+	}
+	if DecodeDigits("12z4", dst, 36) != nil || DecodeDigits("12z4", dst, 35) != ErrInvalidDigit {
+		t.Fail()
+	}
+	if DecodeDigits("1234", dst, 37) != ErrInvalidBase {
+		t.Fail()
+	}
+	if _, err := EncodeDigits([]byte{1, 36}, 36); err != ErrInvalidDigit {
+		t.Fail()
+	}
+	if _, err := EncodeDigits([]byte{1}, 1); err != ErrInvalidBase {
+		t.Fail()
+	}
+}
+
+// Tests the round trip through digit characters.
+func TestDigits(t *testing.T) {
+	const s = "3ud3gtvgolimgu9lah6aie99o"
+	values := make([]byte, len(s))
+	if DecodeDigits("3UD3GTVGOLIMGU9LAH6AIE99O", values, 36) != nil {
+		t.Fatal("DecodeDigits must be case-insensitive")
+	}
+	hex := make([]byte, 32)
+	if Convert(values, hex, 36, 16) != nil {
+		t.Fail()
+	}
+	if h, err := EncodeDigits(hex, 16); h != "40eb9860cf3e45e2a90eb82236ac806c" || err != nil {
+		t.Fail()
+	}
+	if Convert(hex, values, 16, 36) != nil {
+		t.Fail()
+	}
+	if e, err := EncodeDigits(values, 36); e != s || err != nil {
+		t.Fail()
+	}
+}
@@ -0,0 +1,175 @@
+// Long-running UUID format conversion with progress reporting
+//
+// This package rewrites line-oriented streams of UUID strings, such as table
+// exports and ID lists, into another format while periodically reporting the
+// number of converted lines, the throughput, and the estimated time remaining,
+// so that migration tools can surface status to operators.
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// A snapshot of the state of a conversion.
+type Progress struct {
+	// The number of lines processed so far, including invalid lines.
+	Count int64
+
+	// The number of invalid lines skipped so far.
+	Invalid int64
+
+	// The expected total number of lines, or 0 if unknown.
+	Total int64
+
+	// The time elapsed since the start of the conversion.
+	Elapsed time.Duration
+
+	// The average number of lines processed per second.
+	Rate float64
+
+	// The estimated time remaining, or -1 if Total is unknown.
+	ETA time.Duration
+
+	// Whether the conversion has finished.
+	Done bool
+}
+
+// A configuration of a conversion.
+type Converter struct {
+	// The format of the output. The default is FormatUuid25.
+	Format uuid25.Format
+
+	// The expected total number of lines used to estimate the time remaining.
+	// Zero means unknown.
+	Total int64
+
+	// The minimum interval between progress reports. The default is 1 second.
+	Interval time.Duration
+
+	// The function that receives progress reports. It is called from the
+	// converting goroutine at most once per Interval and once upon completion.
+	OnProgress func(Progress)
+
+	// Whether to copy invalid lines to the output unchanged and continue,
+	// instead of aborting the conversion.
+	SkipInvalid bool
+
+	// The clock used to measure elapsed time. The default is time.Now.
+	Now func() time.Time
+}
+
+// Converts each line of `r` and writes the results to `w`, one per line.
+//
+// Blank lines are copied as is and surrounding whitespace is trimmed. Unless
+// SkipInvalid is set, the conversion stops at the first invalid line with an
+// error that reports its line number. The conversion also stops when `ctx` is
+// canceled. In any case, this method returns the final progress, which is
+// reported to OnProgress as well.
+func (c *Converter) Convert(ctx context.Context, r io.Reader, w io.Writer) (Progress, error) {
+	format := c.Format
+	if format == uuid25.FormatInvalid {
+		format = uuid25.FormatUuid25
+	} else if format < uuid25.FormatUuid25 || format > uuid25.FormatUrn {
+		return Progress{}, errors.New("invalid output format")
+	}
+	interval := c.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	now := c.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	start := now()
+	lastReport := start
+	p := Progress{Total: c.Total, ETA: -1}
+	update := func(t time.Time) {
+		p.Elapsed = t.Sub(start)
+		if p.Elapsed > 0 {
+			p.Rate = float64(p.Count) / p.Elapsed.Seconds()
+		}
+		if p.Total > 0 && p.Rate > 0 {
+			remaining := p.Total - p.Count
+			if remaining < 0 {
+				remaining = 0
+			}
+			p.ETA = time.Duration(float64(remaining) / p.Rate * float64(time.Second))
+		}
+	}
+	finish := func(err error) (Progress, error) {
+		update(now())
+		p.Done = true
+		if c.OnProgress != nil {
+			c.OnProgress(p)
+		}
+		return p, err
+	}
+
+	scanner := bufio.NewScanner(r)
+	writer := bufio.NewWriter(w)
+	buffer := make([]byte, 0, uuid25.MaxInputLen+1)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return finish(err)
+		}
+		line := bytes.TrimSpace(scanner.Bytes())
+		p.Count += 1
+
+		buffer = buffer[:0]
+		if len(line) == 0 {
+			// keep blank lines to preserve line correspondence
+		} else if x, err := uuid25.ParseNoAlloc(string(line)); err == nil {
+			buffer = uuid25.FromBytes(x[:]).AppendFormat(buffer, format)
+		} else if c.SkipInvalid {
+			p.Invalid += 1
+			buffer = append(buffer, line...)
+		} else {
+			writer.Flush()
+			return finish(fmt.Errorf("line %d: %w", p.Count, err))
+		}
+		if _, err := writer.Write(append(buffer, '\n')); err != nil {
+			return finish(err)
+		}
+
+		if c.OnProgress != nil && p.Count%1024 == 0 {
+			if t := now(); t.Sub(lastReport) >= interval {
+				lastReport = t
+				update(t)
+				c.OnProgress(p)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		writer.Flush()
+		return finish(err)
+	}
+	return finish(writer.Flush())
+}
+
+// Returns a function that sends progress reports to `ch` without blocking,
+// dropping reports while the receiver is busy. The final report is always
+// delivered, so `ch` should be buffered or drained concurrently.
+//
+// The returned function can be set to Converter.OnProgress to consume progress
+// reports over a channel.
+func SendTo(ch chan<- Progress) func(Progress) {
+	return func(p Progress) {
+		if p.Done {
+			ch <- p
+			return
+		}
+		select {
+		case ch <- p:
+		default:
+		}
+	}
+}
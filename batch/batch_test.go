@@ -0,0 +1,105 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Returns a clock that advances by `step` on each call.
+func fakeClock(step time.Duration) func() time.Time {
+	t := time.Unix(0, 0)
+	return func() time.Time {
+		t = t.Add(step)
+		return t
+	}
+}
+
+// Tests conversion and final progress.
+func TestConvert(t *testing.T) {
+	input := " dpoadk8izg9y4tte7vy1xt94o\n\nE7A1D63B711744238988AFCF12161878\nfoo\n"
+	var out bytes.Buffer
+	c := &Converter{Format: uuid25.FormatHyphenated, SkipInvalid: true, Total: 4}
+	p, err := c.Convert(context.Background(), strings.NewReader(input), &out)
+	if err != nil || !p.Done || p.Count != 4 || p.Invalid != 1 {
+		t.Errorf("unexpected progress: %+v %v", p, err)
+	}
+	expected := "e7a1d63b-7117-4423-8988-afcf12161878\n\ne7a1d63b-7117-4423-8988-afcf12161878\nfoo\n"
+	if out.String() != expected {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+
+	out.Reset()
+	c = &Converter{}
+	p, err = c.Convert(context.Background(), strings.NewReader(input), &out)
+	if err == nil || !errors.Is(err, uuid25.ErrParse) || !strings.HasPrefix(err.Error(), "line 4:") ||
+		out.String() != "dpoadk8izg9y4tte7vy1xt94o\n\ndpoadk8izg9y4tte7vy1xt94o\n" || p.Count != 4 {
+		t.Errorf("unexpected result: %q %v", out.String(), err)
+	}
+
+	c = &Converter{Format: uuid25.Format(99)}
+	if _, err := c.Convert(context.Background(), strings.NewReader(input), &out); err == nil {
+		t.Fail()
+	}
+}
+
+// Tests periodic progress reports and estimates.
+func TestProgress(t *testing.T) {
+	const n = 10000
+	var sb strings.Builder
+	for i := 0; i < n; i += 1 {
+		fmt.Fprintf(&sb, "%032x\n", i)
+	}
+
+	ch := make(chan Progress, 100)
+	c := &Converter{
+		Total:      2 * n,
+		Interval:   time.Second,
+		OnProgress: SendTo(ch),
+		Now:        fakeClock(time.Second),
+	}
+	final, err := c.Convert(context.Background(), strings.NewReader(sb.String()), &bytes.Buffer{})
+	close(ch)
+	if err != nil || final.Count != n {
+		t.Fatal(err)
+	}
+
+	var reports []Progress
+	for p := range ch {
+		reports = append(reports, p)
+	}
+	if len(reports) < 2 || reports[len(reports)-1] != final {
+		t.Fatalf("unexpected reports: %v", reports)
+	}
+	for _, p := range reports[:len(reports)-1] {
+		if p.Done || p.Count == 0 || p.Count%1024 != 0 || p.Rate <= 0 || p.ETA <= 0 {
+			t.Errorf("unexpected report: %+v", p)
+		}
+	}
+	// half of the total is done, so the remaining time equals the elapsed time
+	if d := final.ETA - final.Elapsed; d < -time.Millisecond || d > time.Millisecond {
+		t.Errorf("unexpected ETA: %+v", final)
+	}
+
+	c = &Converter{}
+	if p, _ := c.Convert(context.Background(), strings.NewReader(sb.String()), &bytes.Buffer{}); p.ETA != -1 {
+		t.Fail()
+	}
+}
+
+// Tests cancellation.
+func TestConvertCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := &Converter{}
+	p, err := c.Convert(ctx, strings.NewReader("dpoadk8izg9y4tte7vy1xt94o\n"), &bytes.Buffer{})
+	if err != context.Canceled || p.Count != 0 || !p.Done {
+		t.Fail()
+	}
+}
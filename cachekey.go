@@ -0,0 +1,92 @@
+package uuid25
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// An error returned when a string is not a cache key built by CacheKey.
+var ErrInvalidCacheKey = errors.New("invalid cache key")
+
+// Returns a versioned cache key prefix of the form `<name>:v<version>`, such as
+// `user:v2`.
+//
+// Bumping the version when the cached representation changes makes the keys
+// of the previous representation unreachable without flushing the cache.
+func CachePrefix(name string, version int) string {
+	return name + ":v" + strconv.Itoa(version)
+}
+
+// Returns a cache key of the form `<prefix>:<uuid25>`.
+//
+// The ID is always in the 25-digit Uuid25 format, so the key is exactly
+// CacheKeyLen(prefix) bytes long, which helps to stay within key length
+// limits such as the 250 bytes of memcached. This function panics if `u` is
+// not constructed properly.
+func CacheKey(prefix string, u Uuid25) string {
+	if len(u) != 25 {
+		panic("receiver not constructed properly")
+	}
+	return prefix + ":" + string(u)
+}
+
+// Appends a cache key built by CacheKey to `dst` and returns the extended
+// buffer.
+//
+// This function panics if `u` is not constructed properly.
+func AppendCacheKey(dst []byte, prefix string, u Uuid25) []byte {
+	if len(u) != 25 {
+		panic("receiver not constructed properly")
+	}
+	dst = append(dst, prefix...)
+	dst = append(dst, ':')
+	return append(dst, u...)
+}
+
+// Returns the length of the cache keys built with a prefix.
+func CacheKeyLen(prefix string) int {
+	return len(prefix) + 26
+}
+
+// Splits a cache key built by CacheKey into the prefix and the ID.
+//
+// The ID part is accepted in the Uuid25 format only, regardless of case. This
+// function returns ErrInvalidCacheKey if the key is not of the form
+// `<prefix>:<uuid25>`.
+func ParseCacheKey(key string) (prefix string, u Uuid25, err error) {
+	i := len(key) - 26
+	if i < 0 || key[i] != ':' {
+		return "", "", ErrInvalidCacheKey
+	}
+	u, err = ParseUuid25(key[i+1:])
+	if err != nil {
+		return "", "", ErrInvalidCacheKey
+	}
+	return key[:i], u, nil
+}
+
+// Parses a cache key built by CacheKey with the specified prefix and returns
+// the ID.
+//
+// This function returns ErrInvalidCacheKey if the key has a different prefix,
+// so that keys of other entities or of previous versions are rejected.
+func ParseCacheKeyWithPrefix(key string, prefix string) (Uuid25, error) {
+	rest, ok := cutPrefix(key, prefix)
+	if !ok || len(rest) != 26 || rest[0] != ':' {
+		return "", ErrInvalidCacheKey
+	}
+	u, err := ParseUuid25(rest[1:])
+	if err != nil {
+		return "", ErrInvalidCacheKey
+	}
+	return u, nil
+}
+
+// Returns `s` without `prefix` and whether `s` begins with `prefix`.
+func cutPrefix(s string, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
@@ -0,0 +1,42 @@
+package uuid25
+
+import "testing"
+
+// Tests building and parsing cache keys.
+func TestCacheKey(t *testing.T) {
+	prefix := CachePrefix("user", 2)
+	if prefix != "user:v2" || CacheKeyLen(prefix) != 33 {
+		t.Fail()
+	}
+	for _, e := range testCases {
+		u := Uuid25(e.uuid25)
+		key := CacheKey(prefix, u)
+		if key != "user:v2:"+e.uuid25 || len(key) != CacheKeyLen(prefix) {
+			t.Fail()
+		}
+		if string(AppendCacheKey([]byte("x"), prefix, u)) != "x"+key {
+			t.Fail()
+		}
+		if p, x, err := ParseCacheKey(key); p != prefix || x != u || err != nil {
+			t.Fail()
+		}
+		if x, err := ParseCacheKeyWithPrefix(key, prefix); x != u || err != nil {
+			t.Fail()
+		}
+		if _, err := ParseCacheKeyWithPrefix(key, "user:v1"); err != ErrInvalidCacheKey {
+			t.Fail()
+		}
+		if _, err := ParseCacheKeyWithPrefix(key, "user"); err != ErrInvalidCacheKey {
+			t.Fail()
+		}
+	}
+
+	if p, x, err := ParseCacheKey(":3UD3GTVGOLIMGU9LAH6AIE99O"); p != "" || x != "3ud3gtvgolimgu9lah6aie99o" || err != nil {
+		t.Fail()
+	}
+	for _, e := range []string{"", "3ud3gtvgolimgu9lah6aie99o", "user-3ud3gtvgolimgu9lah6aie99o", "user:40eb9860cf3e45e2a90eb82236ac806c", "user:zzzzzzzzzzzzzzzzzzzzzzzzz"} {
+		if _, _, err := ParseCacheKey(e); err != ErrInvalidCacheKey {
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Implements the `convert` command.
+func runConvert(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, `Usage: uuid25 convert [-from F] [-to F] [ID ...]
+       uuid25 convert -json -path P [-path P ...] [-from F] [-to F] [FILE]
+
+Converts IDs into another format. With -json, reads a stream of JSON values
+(such as NDJSON) and rewrites the UUID fields at the given paths, writing one
+compact JSON value per line. A path consists of object keys and array
+wildcards, like '.items[].id'; missing keys and null values are left as is.
+
+With -spreadsheet, IDs are written as ="ID" formulas that Excel and Google
+Sheets keep as text instead of converting them into numbers, and such
+formulas and leading apostrophes are stripped from input IDs.
+
+Flags:
+`)
+		flags.PrintDefaults()
+	}
+	from := flags.String("from", "any", "accepted input `format`: any, uuid25, hex, hyphenated, braced, or urn")
+	to := flags.String("to", "uuid25", "output `format`: uuid25, hex, hyphenated, braced, or urn")
+	jsonMode := flags.Bool("json", false, "rewrite UUID fields in JSON input")
+	spreadsheet := flags.Bool("spreadsheet", false, "write and accept spreadsheet-safe IDs")
+	var paths pathList
+	flags.Var(&paths, "path", "`path` to a UUID field in -json mode (repeatable)")
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+
+	parse, err := parserFor(*from)
+	if err != nil {
+		fmt.Fprintf(stderr, "uuid25 convert: %v\n", err)
+		return 2
+	}
+	format, err := formatterFor(*to)
+	if err != nil {
+		fmt.Fprintf(stderr, "uuid25 convert: %v\n", err)
+		return 2
+	}
+	convert := func(s string) (string, error) {
+		t := s
+		if *spreadsheet {
+			t = uuid25.TrimSpreadsheetSafe(s)
+		}
+		uuid25, err := parse(t)
+		if err != nil {
+			return "", fmt.Errorf("invalid ID: %q", s)
+		}
+		return format(uuid25), nil
+	}
+	if *spreadsheet {
+		plain := format
+		format = func(id uuid25.Uuid25) string { return uuid25.ToSpreadsheetSafe(plain(id)) }
+	}
+
+	writer := bufio.NewWriter(stdout)
+	defer writer.Flush()
+
+	if !*jsonMode {
+		if len(paths) > 0 {
+			fmt.Fprintln(stderr, "uuid25 convert: -path requires -json")
+			return 2
+		}
+		err := forEachInput(flags.Args(), stdin, func(lineNo int, s string) error {
+			t, err := convert(s)
+			if err != nil {
+				return fmt.Errorf("%d: %w", lineNo, err)
+			}
+			_, err = fmt.Fprintln(writer, t)
+			return err
+		})
+		if err != nil {
+			writer.Flush()
+			fmt.Fprintf(stderr, "uuid25 convert: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	if len(paths) == 0 {
+		fmt.Fprintln(stderr, "uuid25 convert: -json requires at least one -path")
+		return 2
+	}
+	if flags.NArg() > 1 {
+		fmt.Fprintln(stderr, "uuid25 convert: -json accepts at most one input file")
+		return 2
+	} else if flags.NArg() == 1 {
+		file, err := os.Open(flags.Arg(0))
+		if err != nil {
+			fmt.Fprintf(stderr, "uuid25 convert: %v\n", err)
+			return 2
+		}
+		defer file.Close()
+		stdin = file
+	}
+
+	decoder := json.NewDecoder(stdin)
+	var compacted bytes.Buffer
+	for {
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err == io.EOF {
+			return 0
+		} else if err != nil {
+			writer.Flush()
+			fmt.Fprintf(stderr, "uuid25 convert: %v\n", err)
+			return 1
+		}
+		for _, path := range paths {
+			if value, err = rewriteJSON(value, path.segments, convert); err != nil {
+				writer.Flush()
+				fmt.Fprintf(stderr, "uuid25 convert: %s: %v\n", path.source, err)
+				return 1
+			}
+		}
+		compacted.Reset()
+		if err := json.Compact(&compacted, value); err != nil {
+			panic("unreachable")
+		}
+		compacted.WriteByte('\n')
+		if _, err := writer.Write(compacted.Bytes()); err != nil {
+			fmt.Fprintf(stderr, "uuid25 convert: %v\n", err)
+			return 1
+		}
+	}
+}
+
+// Returns the function that formats a Uuid25 value in the format named `name`.
+func formatterFor(name string) (func(uuid25.Uuid25) string, error) {
+	switch name {
+	case "uuid25":
+		return uuid25.Uuid25.String, nil
+	case "hex":
+		return uuid25.Uuid25.ToHex, nil
+	case "hyphenated":
+		return uuid25.Uuid25.ToHyphenated, nil
+	case "braced":
+		return uuid25.Uuid25.ToBraced, nil
+	case "urn":
+		return uuid25.Uuid25.ToUrn, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", name)
+	}
+}
+
+// A segment of a JSON path: an object key or, if `key` is empty, a wildcard
+// over array elements.
+type pathSegment struct {
+	key string
+}
+
+// A parsed JSON path, keeping the source text for error messages.
+type jsonPath struct {
+	source   string
+	segments []pathSegment
+}
+
+// A list of JSON paths implementing the flag.Value interface.
+type pathList []jsonPath
+
+// Implements the flag.Value interface.
+func (paths *pathList) String() string {
+	if paths == nil {
+		return ""
+	}
+	sources := make([]string, len(*paths))
+	for i, e := range *paths {
+		sources[i] = e.source
+	}
+	return strings.Join(sources, ",")
+}
+
+// Implements the flag.Value interface.
+func (paths *pathList) Set(source string) error {
+	segments, err := parsePath(source)
+	if err != nil {
+		return err
+	}
+	*paths = append(*paths, jsonPath{source, segments})
+	return nil
+}
+
+// Parses a JSON path such as `.items[].id` into segments.
+func parsePath(source string) ([]pathSegment, error) {
+	if source == "." {
+		return nil, nil
+	}
+	var segments []pathSegment
+	for s := source; len(s) > 0; {
+		if strings.HasPrefix(s, "[]") {
+			segments = append(segments, pathSegment{})
+			s = s[2:]
+		} else if s[0] == '.' {
+			end := strings.IndexAny(s[1:], ".[") + 1
+			if end == 0 {
+				end = len(s)
+			}
+			if end == 1 {
+				return nil, fmt.Errorf("empty key in path %q", source)
+			}
+			segments = append(segments, pathSegment{key: s[1:end]})
+			s = s[end:]
+		} else {
+			return nil, fmt.Errorf("invalid path %q", source)
+		}
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("invalid path %q", source)
+	}
+	return segments, nil
+}
+
+// An error signaling that a JSON value does not match the expected type.
+var errPathMismatch = errors.New("path does not match the input structure")
+
+// Rewrites the string values located at `path` in `value` using `convert`.
+//
+// Missing object keys and null values are ignored, whereas a type mismatch
+// along the path is reported as an error.
+func rewriteJSON(value json.RawMessage, path []pathSegment, convert func(string) (string, error)) (json.RawMessage, error) {
+	value = bytes.TrimSpace(value)
+	if string(value) == "null" {
+		return value, nil
+	}
+	if len(path) == 0 {
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return nil, errPathMismatch
+		}
+		t, err := convert(s)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(t)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	var result bytes.Buffer
+	if path[0].key == "" {
+		if token != json.Delim('[') {
+			return nil, errPathMismatch
+		}
+		result.WriteByte('[')
+		for i := 0; decoder.More(); i += 1 {
+			var element json.RawMessage
+			if err := decoder.Decode(&element); err != nil {
+				return nil, err
+			}
+			if element, err = rewriteJSON(element, path[1:], convert); err != nil {
+				return nil, err
+			}
+			if i > 0 {
+				result.WriteByte(',')
+			}
+			result.Write(element)
+		}
+		result.WriteByte(']')
+	} else {
+		if token != json.Delim('{') {
+			return nil, errPathMismatch
+		}
+		result.WriteByte('{')
+		for i := 0; decoder.More(); i += 1 {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			var member json.RawMessage
+			if err := decoder.Decode(&member); err != nil {
+				return nil, err
+			}
+			if key := token.(string); key == path[0].key {
+				if member, err = rewriteJSON(member, path[1:], convert); err != nil {
+					return nil, err
+				}
+			}
+			if i > 0 {
+				result.WriteByte(',')
+			}
+			keyJSON, _ := json.Marshal(token)
+			result.Write(keyJSON)
+			result.WriteByte(':')
+			result.Write(member)
+		}
+		result.WriteByte('}')
+	}
+	return result.Bytes(), nil
+}
@@ -0,0 +1,167 @@
+// Command uuid25 validates and converts UUID strings in the Uuid25 and other
+// conventional formats.
+//
+// Usage:
+//
+//	uuid25 <command> [arguments]
+//
+// The commands are:
+//
+//	validate  check that IDs are valid and exit non-zero otherwise
+//	convert   convert IDs or UUID fields in JSON into another format
+//	sort      sort IDs by their 128-bit values
+//	uniq      sort IDs and remove duplicates
+//	setop     compute the difference, intersection, or union of two ID lists
+//	report    summarize the formats and validity of IDs
+//
+// Each command reads IDs from its arguments (or files for sort, uniq, setop, and
+// report) or, if none are given, from the standard input, one per line. The
+// process exits with 0 on success, 1 if an invalid ID is found, and 2 on usage
+// errors.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// Executes the command specified by `args` and returns the exit code.
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return 2
+	}
+	switch args[0] {
+	case "validate":
+		return runValidate(args[1:], stdin, stdout, stderr)
+	case "convert":
+		return runConvert(args[1:], stdin, stdout, stderr)
+	case "sort":
+		return runSort(args[1:], stdin, stdout, stderr, false)
+	case "uniq":
+		return runSort(args[1:], stdin, stdout, stderr, true)
+	case "setop":
+		return runSetop(args[1:], stdin, stdout, stderr)
+	case "report":
+		return runReport(args[1:], stdin, stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		printUsage(stdout)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "uuid25: unknown command %q\n", args[0])
+		printUsage(stderr)
+		return 2
+	}
+}
+
+// Prints the top-level usage message.
+func printUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage: uuid25 <command> [arguments]
+
+Commands:
+  validate  check that IDs are valid and exit non-zero otherwise
+  convert   convert IDs or UUID fields in JSON into another format
+  sort      sort IDs by their 128-bit values
+  uniq      sort IDs and remove duplicates
+  setop     compute the difference, intersection, or union of two ID lists
+  report    summarize the formats and validity of IDs
+
+Run 'uuid25 <command> -h' for details of each command.
+`)
+}
+
+// Calls `fn` for each input ID taken from `args` or, if `args` is empty, from
+// the lines of `stdin`.
+//
+// Blank lines are skipped and surrounding whitespace is trimmed. The line
+// number passed to `fn` is 1-based and counts every input line or argument.
+func forEachInput(args []string, stdin io.Reader, fn func(lineNo int, s string) error) error {
+	if len(args) > 0 {
+		for i, e := range args {
+			if err := fn(i+1, e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return scanLines(stdin, fn)
+}
+
+// Calls `fn` for each line of the files named `names` or, if `names` is empty,
+// of `stdin`.
+//
+// Blank lines are skipped and surrounding whitespace is trimmed. Errors
+// returned by `fn` are annotated with the file name and line number.
+func forEachFileLine(names []string, stdin io.Reader, fn func(s string) error) error {
+	if len(names) == 0 {
+		return scanLines(stdin, func(lineNo int, s string) error {
+			if err := fn(s); err != nil {
+				return fmt.Errorf("%d: %w", lineNo, err)
+			}
+			return nil
+		})
+	}
+	for _, name := range names {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		err = scanLines(file, func(lineNo int, s string) error {
+			if err := fn(s); err != nil {
+				return fmt.Errorf("%s:%d: %w", name, lineNo, err)
+			}
+			return nil
+		})
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Calls `fn` for each non-blank line of `r` with surrounding whitespace
+// trimmed.
+func scanLines(r io.Reader, fn func(lineNo int, s string) error) error {
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo += 1 {
+		s := strings.TrimSpace(scanner.Text())
+		if s == "" {
+			continue
+		}
+		if err := fn(lineNo, s); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Returns the parser function that accepts the input format named `name`.
+func parserFor(name string) (func(string) (uuid25.Uuid25, error), error) {
+	switch name {
+	case "any":
+		return uuid25.Parse, nil
+	case "uuid25":
+		return uuid25.ParseUuid25, nil
+	case "hex":
+		return uuid25.ParseHex, nil
+	case "hyphenated":
+		return uuid25.ParseHyphenated, nil
+	case "braced":
+		return uuid25.ParseBraced, nil
+	case "urn":
+		return uuid25.ParseUrn, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", name)
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Runs the command with `args` and `input` and returns the exit code and
+// outputs.
+func runWith(args []string, input string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(input), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// Tests the exit codes of the validate command.
+func TestValidate(t *testing.T) {
+	const v4 = "dpoadk8izg9y4tte7vy1xt94o" // e7a1d63b-7117-4423-8988-afcf12161878
+	const v7 = "01867b2c-a0dd-7000-8000-000000000000"
+
+	if code, _, _ := runWith([]string{"validate", v4, v7}, ""); code != 0 {
+		t.Error("valid IDs must pass")
+	}
+	if code, _, _ := runWith([]string{"validate"}, v4+"\n\n  "+v7+"  \n"); code != 0 {
+		t.Error("valid IDs must pass from stdin")
+	}
+	if code, _, _ := runWith([]string{"validate", v4, "0"}, ""); code != 1 {
+		t.Error("invalid ID must fail")
+	}
+	if code, _, _ := runWith([]string{"validate", "-version", "7", v7}, ""); code != 0 {
+		t.Error("v7 ID must pass with -version 7")
+	}
+	if code, _, _ := runWith([]string{"validate", "--version", "7", v4}, ""); code != 1 {
+		t.Error("v4 ID must fail with -version 7")
+	}
+	if code, _, _ := runWith([]string{"validate", "-format", "uuid25", v7}, ""); code != 1 {
+		t.Error("hyphenated ID must fail with -format uuid25")
+	}
+	if code, _, _ := runWith([]string{"validate", "-format", "foo", v4}, ""); code != 2 {
+		t.Error("unknown format must be a usage error")
+	}
+
+	_, _, stderr := runWith([]string{"validate"}, "x\ny\n"+v4+"\n")
+	if strings.Count(stderr, "invalid ID") != 1 {
+		t.Error("validate must stop at the first invalid ID")
+	}
+	code, _, stderr := runWith([]string{"validate", "-all"}, "x\ny\n"+v4+"\n")
+	if code != 1 || strings.Count(stderr, "invalid ID:") != 2 {
+		t.Error("validate -all must report all invalid IDs")
+	}
+}
+
+// Tests the line and JSON modes of the convert command.
+func TestConvert(t *testing.T) {
+	code, stdout, _ := runWith([]string{"convert", "-to", "hyphenated"}, "dpoadk8izg9y4tte7vy1xt94o\n{e7a1d63b-7117-4423-8988-afcf12161878}\n")
+	if code != 0 || stdout != "e7a1d63b-7117-4423-8988-afcf12161878\ne7a1d63b-7117-4423-8988-afcf12161878\n" {
+		t.Error("convert must convert each line")
+	}
+	if code, _, _ := runWith([]string{"convert"}, "foo\n"); code != 1 {
+		t.Error("convert must fail on invalid input")
+	}
+	code, stdout, _ = runWith([]string{"convert", "-spreadsheet", "-to", "hex"}, "=\"dpoadk8izg9y4tte7vy1xt94o\"\n'0000000000000000000000001\n")
+	if code != 0 || stdout != "=\"e7a1d63b711744238988afcf12161878\"\n=\"00000000000000000000000000000001\"\n" {
+		t.Error("convert -spreadsheet must unwrap input and wrap output")
+	}
+
+	input := `{"items": [{"id": "e7a1d63b-7117-4423-8988-afcf12161878", "n": 1.50}, {"id": null}], "id": "x"}
+{"items": [], "owner": {"id": "E7A1D63B711744238988AFCF12161878"}}
+`
+	code, stdout, stderr := runWith([]string{"convert", "-json", "-path", ".items[].id", "-path", ".owner.id"}, input)
+	expected := `{"items":[{"id":"dpoadk8izg9y4tte7vy1xt94o","n":1.50},{"id":null}],"id":"x"}
+{"items":[],"owner":{"id":"dpoadk8izg9y4tte7vy1xt94o"}}
+`
+	if code != 0 || stdout != expected {
+		t.Errorf("unexpected JSON output: %q, %q", stdout, stderr)
+	}
+
+	if code, _, _ := runWith([]string{"convert", "-json", "-path", ".id"}, `{"id": "x"}`); code != 1 {
+		t.Error("convert -json must fail on invalid ID")
+	}
+	if code, _, _ := runWith([]string{"convert", "-json", "-path", ".id[]"}, `{"id": "x"}`); code != 1 {
+		t.Error("convert -json must fail on structure mismatch")
+	}
+	if code, _, _ := runWith([]string{"convert", "-json", "-path", "id"}, `{}`); code != 2 {
+		t.Error("convert -json must reject malformed path")
+	}
+}
+
+// Tests the sort and uniq commands with and without spilling to files.
+func TestSortUniq(t *testing.T) {
+	input := `ffffffff-ffff-ffff-ffff-ffffffffffff
+00000000000000000000000000000001
+e7a1d63b-7117-4423-8988-afcf12161878
+0000000000000000000000001
+{E7A1D63B-7117-4423-8988-AFCF12161878}
+urn:uuid:00000000-0000-0000-0000-000000000000
+`
+	sorted := `00000000000000000000000000000000
+00000000000000000000000000000001
+00000000000000000000000000000001
+e7a1d63b711744238988afcf12161878
+e7a1d63b711744238988afcf12161878
+ffffffffffffffffffffffffffffffff
+`
+	unique := `00000000000000000000000000000000
+00000000000000000000000000000001
+e7a1d63b711744238988afcf12161878
+ffffffffffffffffffffffffffffffff
+`
+	counted := `      1 0000000000000000000000000
+      2 0000000000000000000000001
+      2 dpoadk8izg9y4tte7vy1xt94o
+      1 f5lxx1zz5pnorynqglhzmsp33
+`
+
+	for _, buffer := range []string{"1", "2", "1000"} {
+		if _, stdout, _ := runWith([]string{"sort", "-buffer", buffer, "-to", "hex"}, input); stdout != sorted {
+			t.Errorf("unexpected sort output with buffer %s: %q", buffer, stdout)
+		}
+		if _, stdout, _ := runWith([]string{"sort", "-u", "-buffer", buffer, "-to", "hex"}, input); stdout != unique {
+			t.Errorf("unexpected sort -u output with buffer %s: %q", buffer, stdout)
+		}
+		if _, stdout, _ := runWith([]string{"uniq", "-buffer", buffer, "-to", "hex"}, input); stdout != unique {
+			t.Errorf("unexpected uniq output with buffer %s: %q", buffer, stdout)
+		}
+		if _, stdout, _ := runWith([]string{"uniq", "-c", "-buffer", buffer}, input); stdout != counted {
+			t.Errorf("unexpected uniq -c output with buffer %s: %q", buffer, stdout)
+		}
+	}
+
+	if code, _, _ := runWith([]string{"sort"}, "foo\n"); code != 1 {
+		t.Error("sort must fail on invalid input")
+	}
+}
+
+// Tests the set operations of the setop command.
+func TestSetop(t *testing.T) {
+	dir := t.TempDir()
+	fileA := filepath.Join(dir, "a.txt")
+	fileB := filepath.Join(dir, "b.txt")
+	os.WriteFile(fileA, []byte("0000000000000000000000003\n00000000-0000-0000-0000-000000000001\n0000000000000000000000002\n0000000000000000000000001\n"), 0o644)
+	os.WriteFile(fileB, []byte("{00000000-0000-0000-0000-000000000004}\n00000000000000000000000000000002\n"), 0o644)
+
+	cases := map[string]string{
+		"diff":      "0000000000000000000000001\n0000000000000000000000003\n",
+		"intersect": "0000000000000000000000002\n",
+		"union":     "0000000000000000000000001\n0000000000000000000000002\n0000000000000000000000003\n0000000000000000000000004\n",
+		"symdiff":   "0000000000000000000000001\n0000000000000000000000003\n0000000000000000000000004\n",
+	}
+	for op, expected := range cases {
+		code, stdout, stderr := runWith([]string{"setop", "-op", op, "-buffer", "1", fileA, fileB}, "")
+		if code != 0 || stdout != expected {
+			t.Errorf("unexpected setop -op %s output: %q, %q", op, stdout, stderr)
+		}
+	}
+
+	if _, stdout, _ := runWith([]string{"setop", "-op", "diff", fileB, "-"}, "0000000000000000000000004\n"); stdout != "0000000000000000000000002\n" {
+		t.Error("setop must read '-' from stdin")
+	}
+	if code, _, _ := runWith([]string{"setop", "-op", "xor", fileA, fileB}, ""); code != 2 {
+		t.Error("setop must reject unknown operation")
+	}
+	if code, _, _ := runWith([]string{"setop", "-op", "diff", fileA}, ""); code != 2 {
+		t.Error("setop must require two files")
+	}
+}
+
+// Tests the output of the report command.
+func TestReport(t *testing.T) {
+	input := "3ud3gtvgolimgu9lah6aie99o\n40EB9860-CF3E-45E2-A90E-B82236AC806C\nfoo\n{40eb9860-cf3e-45e2-a90e-b82236ac806c}\n"
+	code, stdout, _ := runWith([]string{"report"}, input)
+	expected := "total\t4\nuuid25\t1\nhex\t0\nhyphenated\t1\nbraced\t1\nurn\t0\nnon-canonical\t1\ninvalid\t1\ninvalid sample\t\"foo\"\n"
+	if code != 0 || stdout != expected {
+		t.Errorf("unexpected report output: %q", stdout)
+	}
+
+	code, stdout, _ = runWith([]string{"report", "-json"}, input)
+	if code != 0 || stdout != `{"total":4,"formats":{"braced":1,"hyphenated":1,"uuid25":1},"non_canonical":1,"invalid":1,"invalid_samples":["foo"]}`+"\n" {
+		t.Errorf("unexpected report output: %q", stdout)
+	}
+}
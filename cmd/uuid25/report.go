@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Implements the `report` command.
+func runReport(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("report", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, `Usage: uuid25 report [-json] [FILE ...]
+
+Summarizes the formats of the IDs in the files or the standard input: the
+number of IDs per format, of those not in the canonical lowercase form, and of
+invalid entries, followed by samples of the invalid entries.
+
+Flags:
+`)
+		flags.PrintDefaults()
+	}
+	asJSON := flags.Bool("json", false, "print the report as a JSON object")
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+
+	var report uuid25.Report
+	err := forEachFileLine(flags.Args(), stdin, func(s string) error {
+		report.Add(s)
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "uuid25 report: %v\n", err)
+		return 2
+	}
+
+	if *asJSON {
+		data, err := json.Marshal(&report)
+		if err != nil {
+			fmt.Fprintf(stderr, "uuid25 report: %v\n", err)
+			return 2
+		}
+		fmt.Fprintf(stdout, "%s\n", data)
+		return 0
+	}
+
+	fmt.Fprintf(stdout, "total\t%d\n", report.Total)
+	for f := uuid25.FormatUuid25; f <= uuid25.FormatUrn; f += 1 {
+		fmt.Fprintf(stdout, "%s\t%d\n", f, report.Formats[f])
+	}
+	fmt.Fprintf(stdout, "non-canonical\t%d\n", report.NonCanonical)
+	fmt.Fprintf(stdout, "invalid\t%d\n", report.Invalid)
+	for _, e := range report.InvalidSamples {
+		fmt.Fprintf(stdout, "invalid sample\t%q\n", e)
+	}
+	return 0
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Implements the `setop` command.
+func runSetop(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("setop", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, `Usage: uuid25 setop -op OP [-to F] [-buffer N] FILE_A FILE_B
+
+Compares two ID lists by their 128-bit values regardless of the input formats
+and prints the resulting set in ascending order without duplicates. FILE_A or
+FILE_B may be '-' to read from the standard input.
+
+Operations:
+  diff       IDs in FILE_A but not in FILE_B
+  intersect  IDs in both FILE_A and FILE_B
+  union      IDs in either FILE_A or FILE_B
+  symdiff    IDs in either FILE_A or FILE_B but not in both
+
+Flags:
+`)
+		flags.PrintDefaults()
+	}
+	op := flags.String("op", "", "set `operation`: diff, intersect, union, or symdiff")
+	to := flags.String("to", "uuid25", "output `format`: uuid25, hex, hyphenated, braced, or urn")
+	bufferSize := flags.Int("buffer", 1<<20, "maximum `number` of IDs to sort in memory at once")
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+
+	// select which of (only in A, only in B, in both) to emit
+	var emitA, emitB, emitBoth bool
+	switch *op {
+	case "diff":
+		emitA = true
+	case "intersect":
+		emitBoth = true
+	case "union":
+		emitA, emitB, emitBoth = true, true, true
+	case "symdiff":
+		emitA, emitB = true, true
+	default:
+		fmt.Fprintf(stderr, "uuid25 setop: unknown operation %q\n", *op)
+		return 2
+	}
+	format, err := formatterFor(*to)
+	if err != nil {
+		fmt.Fprintf(stderr, "uuid25 setop: %v\n", err)
+		return 2
+	}
+	if *bufferSize < 1 {
+		fmt.Fprintf(stderr, "uuid25 setop: invalid buffer size: %d\n", *bufferSize)
+		return 2
+	}
+	if flags.NArg() != 2 || (flags.Arg(0) == "-" && flags.Arg(1) == "-") {
+		fmt.Fprintln(stderr, "uuid25 setop: two input files are required")
+		return 2
+	}
+
+	var keys [2]*uniqueKeys
+	for i, name := range flags.Args() {
+		sorter := &externalSorter{bufferSize: *bufferSize}
+		defer sorter.Close()
+		var names []string
+		if name != "-" {
+			names = []string{name}
+		}
+		err := forEachFileLine(names, stdin, func(s string) error {
+			uuid25, err := uuid25.Parse(s)
+			if err != nil {
+				return fmt.Errorf("invalid ID: %q", s)
+			}
+			return sorter.Add(uuid25.ToBytes())
+		})
+		if err == nil {
+			var sorted *sortedKeys
+			sorted, err = sorter.Sorted()
+			keys[i] = &uniqueKeys{keys: sorted}
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "uuid25 setop: %v\n", err)
+			return 1
+		}
+	}
+
+	writer := bufio.NewWriter(stdout)
+	write := func(key [16]byte) {
+		fmt.Fprintln(writer, format(uuid25.FromBytes(key[:])))
+	}
+
+	a, okA, err := keys[0].Next()
+	if err != nil {
+		fmt.Fprintf(stderr, "uuid25 setop: %v\n", err)
+		return 1
+	}
+	b, okB, err := keys[1].Next()
+	for err == nil && (okA || okB) {
+		var cmp int
+		if !okA {
+			cmp = 1
+		} else if !okB {
+			cmp = -1
+		} else {
+			cmp = bytes.Compare(a[:], b[:])
+		}
+
+		if cmp < 0 {
+			if emitA {
+				write(a)
+			}
+			a, okA, err = keys[0].Next()
+		} else if cmp > 0 {
+			if emitB {
+				write(b)
+			}
+			b, okB, err = keys[1].Next()
+		} else {
+			if emitBoth {
+				write(a)
+			}
+			if a, okA, err = keys[0].Next(); err == nil {
+				b, okB, err = keys[1].Next()
+			}
+		}
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "uuid25 setop: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// An iterator that skips duplicates in sorted keys.
+type uniqueKeys struct {
+	keys *sortedKeys
+	prev [16]byte
+	seen bool
+}
+
+// Returns the next distinct key or `false` if the iterator is exhausted.
+func (keys *uniqueKeys) Next() ([16]byte, bool, error) {
+	for {
+		key, ok, err := keys.keys.Next()
+		if err != nil || !ok {
+			return key, ok, err
+		}
+		if !keys.seen || key != keys.prev {
+			keys.prev, keys.seen = key, true
+			return key, true, nil
+		}
+	}
+}
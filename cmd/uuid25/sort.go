@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Implements the `sort` and `uniq` commands.
+func runSort(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer, unique bool) int {
+	name := "sort"
+	if unique {
+		name = "uniq"
+	}
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		if unique {
+			fmt.Fprint(stderr, `Usage: uuid25 uniq [-c] [-to F] [-buffer N] [FILE ...]
+
+Sorts IDs by their 128-bit values and removes duplicates.
+`)
+		} else {
+			fmt.Fprint(stderr, `Usage: uuid25 sort [-u] [-to F] [-buffer N] [FILE ...]
+
+Sorts IDs by their 128-bit values.
+`)
+		}
+		fmt.Fprint(stderr, `
+Inputs may mix any supported formats. Inputs larger than the in-memory buffer
+are sorted in runs spilled to temporary files and then merged.
+
+Flags:
+`)
+		flags.PrintDefaults()
+	}
+	to := flags.String("to", "uuid25", "output `format`: uuid25, hex, hyphenated, braced, or urn")
+	bufferSize := flags.Int("buffer", 1<<20, "maximum `number` of IDs to sort in memory at once")
+	count := false
+	if unique {
+		flags.BoolVar(&count, "c", false, "prefix each ID with the number of occurrences")
+	} else {
+		flags.BoolVar(&unique, "u", false, "remove duplicates")
+	}
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+
+	format, err := formatterFor(*to)
+	if err != nil {
+		fmt.Fprintf(stderr, "uuid25 %s: %v\n", name, err)
+		return 2
+	}
+	if *bufferSize < 1 {
+		fmt.Fprintf(stderr, "uuid25 %s: invalid buffer size: %d\n", name, *bufferSize)
+		return 2
+	}
+
+	sorter := &externalSorter{bufferSize: *bufferSize}
+	defer sorter.Close()
+	err = forEachFileLine(flags.Args(), stdin, func(s string) error {
+		uuid25, err := uuid25.Parse(s)
+		if err != nil {
+			return fmt.Errorf("invalid ID: %q", s)
+		}
+		return sorter.Add(uuid25.ToBytes())
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "uuid25 %s: %v\n", name, err)
+		return 1
+	}
+
+	writer := bufio.NewWriter(stdout)
+	writeLine := func(key [16]byte, n int) error {
+		if count {
+			fmt.Fprintf(writer, "%7d ", n)
+		}
+		_, err := fmt.Fprintln(writer, format(uuid25.FromBytes(key[:])))
+		return err
+	}
+
+	var prev [16]byte
+	n := 0
+	err = sorter.Iterate(func(key [16]byte) error {
+		if !unique {
+			return writeLine(key, 1)
+		}
+		if n > 0 && key == prev {
+			n += 1
+			return nil
+		}
+		if n > 0 {
+			if err := writeLine(prev, n); err != nil {
+				return err
+			}
+		}
+		prev, n = key, 1
+		return nil
+	})
+	if err == nil && unique && n > 0 {
+		err = writeLine(prev, n)
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "uuid25 %s: %v\n", name, err)
+		return 1
+	}
+	return 0
+}
+
+// A sorter of 16-byte keys that spills sorted runs to temporary files when the
+// number of keys exceeds `bufferSize`.
+type externalSorter struct {
+	bufferSize int
+	buffer     [][16]byte
+	runs       []*os.File
+}
+
+// Adds a key to the sorter.
+func (sorter *externalSorter) Add(key [16]byte) error {
+	if len(sorter.buffer) >= sorter.bufferSize {
+		if err := sorter.spill(); err != nil {
+			return err
+		}
+	}
+	sorter.buffer = append(sorter.buffer, key)
+	return nil
+}
+
+// Sorts the keys in memory and writes them to a new temporary file.
+func (sorter *externalSorter) spill() error {
+	sorter.sortBuffer()
+	file, err := os.CreateTemp("", "uuid25-sort-*")
+	if err != nil {
+		return err
+	}
+	sorter.runs = append(sorter.runs, file)
+
+	writer := bufio.NewWriter(file)
+	for _, e := range sorter.buffer {
+		if _, err := writer.Write(e[:]); err != nil {
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	sorter.buffer = sorter.buffer[:0]
+	return nil
+}
+
+// Sorts the keys held in memory.
+func (sorter *externalSorter) sortBuffer() {
+	sort.Slice(sorter.buffer, func(i, j int) bool {
+		return bytes.Compare(sorter.buffer[i][:], sorter.buffer[j][:]) < 0
+	})
+}
+
+// Calls `fn` for each key added to the sorter in ascending order.
+func (sorter *externalSorter) Iterate(fn func(key [16]byte) error) error {
+	keys, err := sorter.Sorted()
+	if err != nil {
+		return err
+	}
+	for {
+		key, ok, err := keys.Next()
+		if err != nil {
+			return err
+		} else if !ok {
+			return nil
+		}
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+}
+
+// Returns an iterator that merges the in-memory buffer and the spilled runs in
+// ascending order.
+//
+// No keys may be added to the sorter after calling this method.
+func (sorter *externalSorter) Sorted() (*sortedKeys, error) {
+	sorter.sortBuffer()
+	h := make(mergeHeap, 0, len(sorter.runs)+1)
+	if len(sorter.buffer) > 0 {
+		h = append(h, &mergeSource{key: sorter.buffer[0], buffer: sorter.buffer[1:]})
+	}
+	for _, e := range sorter.runs {
+		if _, err := e.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		source := &mergeSource{reader: bufio.NewReader(e)}
+		if ok, err := source.next(); err != nil {
+			return nil, err
+		} else if ok {
+			h = append(h, source)
+		}
+	}
+	heap.Init(&h)
+	return &sortedKeys{heap: h}, nil
+}
+
+// An iterator over sorted keys returned by externalSorter.Sorted().
+type sortedKeys struct {
+	heap    mergeHeap
+	started bool
+}
+
+// Returns the next key or `false` if the iterator is exhausted.
+func (keys *sortedKeys) Next() ([16]byte, bool, error) {
+	if keys.started && len(keys.heap) > 0 {
+		if ok, err := keys.heap[0].next(); err != nil {
+			return [16]byte{}, false, err
+		} else if ok {
+			heap.Fix(&keys.heap, 0)
+		} else {
+			heap.Pop(&keys.heap)
+		}
+	}
+	keys.started = true
+	if len(keys.heap) == 0 {
+		return [16]byte{}, false, nil
+	}
+	return keys.heap[0].key, true, nil
+}
+
+// Removes the temporary files.
+func (sorter *externalSorter) Close() error {
+	var firstErr error
+	for _, e := range sorter.runs {
+		e.Close()
+		if err := os.Remove(e.Name()); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	sorter.runs = nil
+	return firstErr
+}
+
+// A sorted sequence of keys read from a run file or a memory buffer.
+type mergeSource struct {
+	key    [16]byte
+	reader *bufio.Reader
+	buffer [][16]byte
+}
+
+// Advances the source to the next key and reports whether it exists.
+func (source *mergeSource) next() (bool, error) {
+	if source.reader == nil {
+		if len(source.buffer) == 0 {
+			return false, nil
+		}
+		source.key, source.buffer = source.buffer[0], source.buffer[1:]
+		return true, nil
+	}
+	if _, err := io.ReadFull(source.reader, source.key[:]); err == io.EOF {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// A min-heap of merge sources implementing the heap.Interface interface.
+type mergeHeap []*mergeSource
+
+func (h mergeHeap) Len() int           { return len(h) }
+func (h mergeHeap) Less(i, j int) bool { return bytes.Compare(h[i].key[:], h[j].key[:]) < 0 }
+func (h mergeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap) Push(x any)        { *h = append(*h, x.(*mergeSource)) }
+func (h *mergeHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+)
+
+// An error signaling that an invalid ID has been found and reported.
+var errInvalidInput = errors.New("invalid input")
+
+// Implements the `validate` command.
+func runValidate(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, `Usage: uuid25 validate [-version N] [-format F] [-all] [ID ...]
+
+Checks that each ID is a valid UUID and exits with 1 on the first invalid one.
+
+Flags:
+`)
+		flags.PrintDefaults()
+	}
+	version := flags.Int("version", 0, "require UUID `version` N (0 accepts any version)")
+	format := flags.String("format", "any", "accepted input `format`: any, uuid25, hex, hyphenated, braced, or urn")
+	all := flags.Bool("all", false, "report all invalid IDs instead of stopping at the first")
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+
+	parse, err := parserFor(*format)
+	if err != nil {
+		fmt.Fprintf(stderr, "uuid25 validate: %v\n", err)
+		return 2
+	}
+	if *version < 0 || *version > 15 {
+		fmt.Fprintf(stderr, "uuid25 validate: version out of range: %d\n", *version)
+		return 2
+	}
+
+	nInvalid := 0
+	err = forEachInput(flags.Args(), stdin, func(lineNo int, s string) error {
+		uuid25, err := parse(s)
+		if err != nil {
+			fmt.Fprintf(stderr, "%d: invalid ID: %q\n", lineNo, s)
+		} else if *version != 0 && uuid25.Version() != *version {
+			fmt.Fprintf(stderr, "%d: not a version %d UUID: %q\n", lineNo, *version, s)
+		} else {
+			return nil
+		}
+
+		nInvalid += 1
+		if *all {
+			return nil
+		}
+		return errInvalidInput
+	})
+	if err != nil && err != errInvalidInput {
+		fmt.Fprintf(stderr, "uuid25 validate: %v\n", err)
+		return 2
+	}
+
+	if nInvalid > 0 {
+		if *all {
+			fmt.Fprintf(stderr, "%d invalid ID(s) found\n", nInvalid)
+		}
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,7 @@
+// Example of typed ID types generated by uuid25gen
+//
+// This package holds the output of uuid25gen for the entities below, serving as
+// both documentation and a compile check of the generated code.
+package example
+
+//go:generate go run .. -output ids_gen.go User OrderItem=item
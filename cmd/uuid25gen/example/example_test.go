@@ -0,0 +1,70 @@
+package example
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests the generated typed ID types.
+func TestGenerated(t *testing.T) {
+	x := uuid25.Uuid25("dpoadk8izg9y4tte7vy1xt94o")
+	user := UserIDFrom(x)
+	if user.String() != "user_dpoadk8izg9y4tte7vy1xt94o" || UserIDPrefix != "user" || OrderItemIDPrefix != "item" {
+		t.Fail()
+	}
+	if y, err := ParseUserID(user.String()); err != nil || y != user {
+		t.Fail()
+	}
+	if _, err := ParseOrderItemID(user.String()); err == nil {
+		t.Fail()
+	}
+	if b := NewUserID().Uuid25().ToBytes(); b[6]>>4 != 7 {
+		t.Fail()
+	}
+
+	var payload struct {
+		User UserID      `json:"user"`
+		Item OrderItemID `json:"item"`
+	}
+	data := `{"user":"user_dpoadk8izg9y4tte7vy1xt94o","item":"item_dpoadk8izg9y4tte7vy1xt94o"}`
+	if err := json.Unmarshal([]byte(data), &payload); err != nil || payload.Item.Uuid25() != x {
+		t.Fatal(err)
+	}
+	if out, err := json.Marshal(payload); err != nil || string(out) != data {
+		t.Fail()
+	}
+	if json.Unmarshal([]byte(`{"user":"item_dpoadk8izg9y4tte7vy1xt94o"}`), &payload) == nil {
+		t.Fail()
+	}
+
+	var scanned OrderItemID
+	if scanned.Scan(x.String()) != nil || scanned.Uuid25() != x {
+		t.Fail()
+	}
+	if scanned.Scan("item_0000000000000000000000000") != nil || scanned.Uuid25() != "0000000000000000000000000" {
+		t.Fail()
+	}
+	if scanned.Scan("user_0000000000000000000000000") == nil {
+		t.Fail()
+	}
+	scanned = ""
+	if scanned.Scan([]byte("item_"+x.String())) != nil || scanned.Uuid25() != x {
+		t.Fail()
+	}
+	if scanned.Scan([]byte("user_"+x.String())) == nil {
+		t.Fail()
+	}
+	var long any = []byte(strings.Repeat("x", 1000))
+	if n := testing.AllocsPerRun(10, func() { scanned.Scan(long) }); n != 0 {
+		t.Errorf("Scan must not allocate for too long input: %v", n)
+	}
+	if v, err := user.Value(); err != nil || v != x.String() {
+		t.Fail()
+	}
+	if _, err := UserID("").MarshalText(); err == nil {
+		t.Fail()
+	}
+}
@@ -0,0 +1,195 @@
+// Code generated by uuid25gen User=user OrderItem=item; DO NOT EDIT.
+
+package example
+
+import (
+	"database/sql/driver"
+	"errors"
+
+	"github.com/uuid25/go-uuid25"
+	"github.com/uuid25/go-uuid25/typed"
+)
+
+// The prefix of UserID values.
+const UserIDPrefix = "user"
+
+// A Uuid25 value typed as the ID of User, represented externally as
+// `user_3ud3gtvgolimgu9lah6aie99o`.
+type UserID uuid25.Uuid25
+
+// Generates a new UserID based on a UUIDv7.
+func NewUserID() UserID {
+	return UserID(uuid25.NewV7())
+}
+
+// Creates a UserID from a Uuid25 value.
+func UserIDFrom(id uuid25.Uuid25) UserID {
+	return UserID(id)
+}
+
+// Creates a UserID from the prefixed string representation.
+func ParseUserID(s string) (UserID, error) {
+	prefix, id, err := typed.Split(s)
+	if err != nil {
+		return "", err
+	} else if prefix != UserIDPrefix {
+		return "", typed.ErrInvalidTypedID
+	}
+	return UserID(id), nil
+}
+
+// Returns the untyped Uuid25 value.
+func (id UserID) Uuid25() uuid25.Uuid25 {
+	return uuid25.Uuid25(id)
+}
+
+// Returns the prefixed string representation.
+func (id UserID) String() string {
+	return typed.Format(UserIDPrefix, id.Uuid25())
+}
+
+// Implements the encoding.TextMarshaler interface.
+func (id UserID) MarshalText() ([]byte, error) {
+	if _, err := id.Uuid25().MarshalText(); err != nil {
+		return nil, err
+	}
+	return []byte(id.String()), nil
+}
+
+// Implements the encoding.TextUnmarshaler interface.
+func (id *UserID) UnmarshalText(text []byte) error {
+	if id == nil {
+		return errors.New("nil receiver")
+	}
+	result, err := ParseUserID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = result
+	return nil
+}
+
+// Implements the sql.Scanner interface, accepting the bare Uuid25 string and
+// the prefixed string representation in a string or []byte.
+func (id *UserID) Scan(src any) error {
+	if id == nil {
+		return errors.New("nil receiver")
+	}
+	var s string
+	switch src := src.(type) {
+	case string:
+		s = src
+	case []byte:
+		// copy only inputs as long as a prefixed ID
+		if len(src) == len(UserIDPrefix)+26 {
+			s = string(src)
+		}
+	}
+	if result, err := ParseUserID(s); err == nil {
+		*id = result
+		return nil
+	}
+	var x uuid25.Uuid25
+	if err := x.Scan(src); err != nil {
+		return err
+	}
+	*id = UserID(x)
+	return nil
+}
+
+// Implements the driver.Valuer interface, storing the bare Uuid25 string.
+func (id UserID) Value() (driver.Value, error) {
+	return id.Uuid25().Value()
+}
+
+// The prefix of OrderItemID values.
+const OrderItemIDPrefix = "item"
+
+// A Uuid25 value typed as the ID of OrderItem, represented externally as
+// `item_3ud3gtvgolimgu9lah6aie99o`.
+type OrderItemID uuid25.Uuid25
+
+// Generates a new OrderItemID based on a UUIDv7.
+func NewOrderItemID() OrderItemID {
+	return OrderItemID(uuid25.NewV7())
+}
+
+// Creates a OrderItemID from a Uuid25 value.
+func OrderItemIDFrom(id uuid25.Uuid25) OrderItemID {
+	return OrderItemID(id)
+}
+
+// Creates a OrderItemID from the prefixed string representation.
+func ParseOrderItemID(s string) (OrderItemID, error) {
+	prefix, id, err := typed.Split(s)
+	if err != nil {
+		return "", err
+	} else if prefix != OrderItemIDPrefix {
+		return "", typed.ErrInvalidTypedID
+	}
+	return OrderItemID(id), nil
+}
+
+// Returns the untyped Uuid25 value.
+func (id OrderItemID) Uuid25() uuid25.Uuid25 {
+	return uuid25.Uuid25(id)
+}
+
+// Returns the prefixed string representation.
+func (id OrderItemID) String() string {
+	return typed.Format(OrderItemIDPrefix, id.Uuid25())
+}
+
+// Implements the encoding.TextMarshaler interface.
+func (id OrderItemID) MarshalText() ([]byte, error) {
+	if _, err := id.Uuid25().MarshalText(); err != nil {
+		return nil, err
+	}
+	return []byte(id.String()), nil
+}
+
+// Implements the encoding.TextUnmarshaler interface.
+func (id *OrderItemID) UnmarshalText(text []byte) error {
+	if id == nil {
+		return errors.New("nil receiver")
+	}
+	result, err := ParseOrderItemID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = result
+	return nil
+}
+
+// Implements the sql.Scanner interface, accepting the bare Uuid25 string and
+// the prefixed string representation in a string or []byte.
+func (id *OrderItemID) Scan(src any) error {
+	if id == nil {
+		return errors.New("nil receiver")
+	}
+	var s string
+	switch src := src.(type) {
+	case string:
+		s = src
+	case []byte:
+		// copy only inputs as long as a prefixed ID
+		if len(src) == len(OrderItemIDPrefix)+26 {
+			s = string(src)
+		}
+	}
+	if result, err := ParseOrderItemID(s); err == nil {
+		*id = result
+		return nil
+	}
+	var x uuid25.Uuid25
+	if err := x.Scan(src); err != nil {
+		return err
+	}
+	*id = OrderItemID(x)
+	return nil
+}
+
+// Implements the driver.Valuer interface, storing the bare Uuid25 string.
+func (id OrderItemID) Value() (driver.Value, error) {
+	return id.Uuid25().Value()
+}
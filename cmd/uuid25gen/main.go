@@ -0,0 +1,268 @@
+// Command uuid25gen generates typed ID types built on Uuid25.
+//
+// Usage:
+//
+//	uuid25gen [-package NAME] [-output FILE] [-new=false] ENTITY[=PREFIX] ...
+//
+// For each entity, such as `User` or `OrderItem=item`, the command emits a
+// named type `UserID` whose values are represented externally as prefixed
+// strings like `user_3ud3gtvgolimgu9lah6aie99o` (see the typed package), along
+// with a constructor, a parser, text marshalers, and sql.Scanner and
+// driver.Valuer implementations that store the bare Uuid25 string. The prefix
+// defaults to the snake case of the entity name. It is intended to be invoked
+// through go:generate:
+//
+//	//go:generate go run github.com/uuid25/go-uuid25/cmd/uuid25gen -output ids_gen.go User Order=ord
+//
+// The package name defaults to $GOPACKAGE, which go:generate sets. With
+// -new=false, the generated code omits the NewXxxID() constructors.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/uuid25/go-uuid25/typed"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// Executes the command with `args` and returns the exit code.
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("uuid25gen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, `Usage: uuid25gen [-package NAME] [-output FILE] [-new=false] ENTITY[=PREFIX] ...
+
+Generates typed ID types built on Uuid25 for the given entities.
+
+Flags:
+`)
+		flags.PrintDefaults()
+	}
+	pkg := flags.String("package", os.Getenv("GOPACKAGE"), "package `name` of the generated file")
+	output := flags.String("output", "", "output `file` (default: standard output)")
+	withNew := flags.Bool("new", true, "generate NewXxxID() constructors using UUIDv7")
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if *pkg == "" || !token.IsIdentifier(*pkg) || flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	entities, err := parseEntities(flags.Args())
+	if err != nil {
+		fmt.Fprintf(stderr, "uuid25gen: %v\n", err)
+		return 2
+	}
+	src, err := generate(*pkg, entities, *withNew)
+	if err != nil {
+		fmt.Fprintf(stderr, "uuid25gen: %v\n", err)
+		return 1
+	}
+
+	if *output == "" {
+		_, err = stdout.Write(src)
+	} else {
+		err = os.WriteFile(*output, src, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "uuid25gen: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// An entity for which a typed ID type is generated.
+type entity struct {
+	Name   string
+	Prefix string
+}
+
+// Parses `ENTITY[=PREFIX]` arguments.
+func parseEntities(args []string) ([]entity, error) {
+	var entities []entity
+	seen := map[string]bool{}
+	for _, e := range args {
+		name, prefix, ok := strings.Cut(e, "=")
+		if !token.IsIdentifier(name) || !token.IsExported(name) {
+			return nil, fmt.Errorf("invalid entity name %q", name)
+		}
+		if !ok {
+			prefix = snakeCase(name)
+		}
+		if !typed.ValidPrefix(prefix) {
+			return nil, fmt.Errorf("invalid prefix %q for %s", prefix, name)
+		}
+		if seen[name] || seen["="+prefix] {
+			return nil, fmt.Errorf("duplicate entity %q", e)
+		}
+		seen[name], seen["="+prefix] = true, true
+		entities = append(entities, entity{name, prefix})
+	}
+	return entities, nil
+}
+
+// Converts a Go identifier into snake case: `OrderItem` to `order_item` and
+// `HTTPRequest` to `http_request`.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, c := range runes {
+		if unicode.IsUpper(c) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) ||
+				i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])) {
+				sb.WriteByte('_')
+			}
+			c = unicode.ToLower(c)
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
+
+// Returns the formatted source code of the typed ID types.
+func generate(pkg string, entities []entity, withNew bool) ([]byte, error) {
+	var buffer bytes.Buffer
+	err := fileTemplate.Execute(&buffer, struct {
+		Package  string
+		Entities []entity
+		WithNew  bool
+		Args     string
+	}{pkg, entities, withNew, argsOf(entities, withNew)})
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(buffer.Bytes())
+}
+
+// Returns the command-line arguments that reproduce the output.
+func argsOf(entities []entity, withNew bool) string {
+	var args []string
+	if !withNew {
+		args = append(args, "-new=false")
+	}
+	for _, e := range entities {
+		args = append(args, e.Name+"="+e.Prefix)
+	}
+	return strings.Join(args, " ")
+}
+
+var fileTemplate = template.Must(template.New("").Parse(`// Code generated by uuid25gen {{.Args}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"database/sql/driver"
+	"errors"
+
+	"github.com/uuid25/go-uuid25"
+	"github.com/uuid25/go-uuid25/typed"
+)
+{{range .Entities}}
+// The prefix of {{.Name}}ID values.
+const {{.Name}}IDPrefix = "{{.Prefix}}"
+
+// A Uuid25 value typed as the ID of {{.Name}}, represented externally as
+// ` + "`{{.Prefix}}_3ud3gtvgolimgu9lah6aie99o`" + `.
+type {{.Name}}ID uuid25.Uuid25
+{{if $.WithNew}}
+// Generates a new {{.Name}}ID based on a UUIDv7.
+func New{{.Name}}ID() {{.Name}}ID {
+	return {{.Name}}ID(uuid25.NewV7())
+}
+{{end}}
+// Creates a {{.Name}}ID from a Uuid25 value.
+func {{.Name}}IDFrom(id uuid25.Uuid25) {{.Name}}ID {
+	return {{.Name}}ID(id)
+}
+
+// Creates a {{.Name}}ID from the prefixed string representation.
+func Parse{{.Name}}ID(s string) ({{.Name}}ID, error) {
+	prefix, id, err := typed.Split(s)
+	if err != nil {
+		return "", err
+	} else if prefix != {{.Name}}IDPrefix {
+		return "", typed.ErrInvalidTypedID
+	}
+	return {{.Name}}ID(id), nil
+}
+
+// Returns the untyped Uuid25 value.
+func (id {{.Name}}ID) Uuid25() uuid25.Uuid25 {
+	return uuid25.Uuid25(id)
+}
+
+// Returns the prefixed string representation.
+func (id {{.Name}}ID) String() string {
+	return typed.Format({{.Name}}IDPrefix, id.Uuid25())
+}
+
+// Implements the encoding.TextMarshaler interface.
+func (id {{.Name}}ID) MarshalText() ([]byte, error) {
+	if _, err := id.Uuid25().MarshalText(); err != nil {
+		return nil, err
+	}
+	return []byte(id.String()), nil
+}
+
+// Implements the encoding.TextUnmarshaler interface.
+func (id *{{.Name}}ID) UnmarshalText(text []byte) error {
+	if id == nil {
+		return errors.New("nil receiver")
+	}
+	result, err := Parse{{.Name}}ID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = result
+	return nil
+}
+
+// Implements the sql.Scanner interface, accepting the bare Uuid25 string and
+// the prefixed string representation in a string or []byte.
+func (id *{{.Name}}ID) Scan(src any) error {
+	if id == nil {
+		return errors.New("nil receiver")
+	}
+	var s string
+	switch src := src.(type) {
+	case string:
+		s = src
+	case []byte:
+		// copy only inputs as long as a prefixed ID
+		if len(src) == len({{.Name}}IDPrefix)+26 {
+			s = string(src)
+		}
+	}
+	if result, err := Parse{{.Name}}ID(s); err == nil {
+		*id = result
+		return nil
+	}
+	var x uuid25.Uuid25
+	if err := x.Scan(src); err != nil {
+		return err
+	}
+	*id = {{.Name}}ID(x)
+	return nil
+}
+
+// Implements the driver.Valuer interface, storing the bare Uuid25 string.
+func (id {{.Name}}ID) Value() (driver.Value, error) {
+	return id.Uuid25().Value()
+}
+{{end}}`))
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Tests that the checked-in example is up to date with the generator.
+func TestGenerateExample(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-package", "example", "User", "OrderItem=item"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatal(stderr.String())
+	}
+	expected, err := os.ReadFile("example/ids_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(stdout.Bytes(), expected) {
+		t.Error("example/ids_gen.go is stale; run go generate")
+	}
+
+	stdout.Reset()
+	if run([]string{"-package", "example", "-new=false", "User"}, &stdout, &stderr) != 0 ||
+		strings.Contains(stdout.String(), "NewUserID") ||
+		strings.Contains(stdout.String(), "NewV7") {
+		t.Error("-new=false must omit constructors")
+	}
+}
+
+// Tests argument validation.
+func TestArgs(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	for _, args := range [][]string{
+		{"-package", "example"},
+		{"-package", "1x", "User"},
+		{"-package", "example", "user"},
+		{"-package", "example", "User=User"},
+		{"-package", "example", "User", "Account=user"},
+		{"-package", "example", "User", "User=usr"},
+	} {
+		if run(args, &stdout, &stderr) != 2 {
+			t.Errorf("%v must be rejected", args)
+		}
+	}
+}
+
+// Tests conversion of entity names into prefixes.
+func TestSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"User":        "user",
+		"OrderItem":   "order_item",
+		"HTTPRequest": "http_request",
+		"UserV2":      "user_v2",
+		"ID":          "id",
+	}
+	for name, expected := range cases {
+		if s := snakeCase(name); s != expected {
+			t.Errorf("unexpected snake case of %s: %s", name, s)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package uuid25
+
+import "math/bits"
+
+// Conversions between the 128-bit value and the digit strings, computed with
+// 64-bit words through math/bits instead of a generic digit-by-digit base
+// conversion.
+
+const base36Digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+const hexDigits = "0123456789abcdef"
+
+// 36^12, the largest power of 36 not exceeding 2^64. A 25-digit Uuid25 string
+// consists of a leading digit followed by two 12-digit chunks of this base.
+const base36Chunk = 4738381338321616896
+
+// An O(1) map from ASCII code points to case-insensitive Base36 digit values,
+// which maps invalid characters to 0xff.
+var digitValues = func() (table [256]byte) {
+	for i := range table {
+		table[i] = 0xff
+	}
+	for i := 0; i < 36; i += 1 {
+		table[base36Digits[i]] = byte(i)
+		if i >= 10 {
+			table['A'+i-10] = byte(i)
+		}
+	}
+	return
+}()
+
+// Encodes a 128-bit value into the 25-digit Base36 string.
+func encodeBase36(x Uint128) [25]byte {
+	q, r0 := x.DivMod64(base36Chunk)
+	q, r1 := q.DivMod64(base36Chunk)
+	var buffer [25]byte
+	buffer[0] = base36Digits[q.Lo] // q < 36 because x < 2^128 < 36^25
+	putBase36Chunk(buffer[1:13], r1)
+	putBase36Chunk(buffer[13:], r0)
+	return buffer
+}
+
+// Writes a value less than 36^12 as 12 Base36 digits.
+func putBase36Chunk(dst []byte, v uint64) {
+	for i := 11; i >= 0; i -= 1 {
+		dst[i] = base36Digits[v%36]
+		v /= 36
+	}
+}
+
+// Decodes a 25-digit case-insensitive Base36 string into a 128-bit value.
+func decodeBase36(s string) (Uint128, error) {
+	if len(s) != 25 {
+		return Uint128{}, ErrInvalidLength
+	}
+	d0 := digitValues[s[0]]
+	c1, ok1 := base36ChunkValue(s[1:13])
+	c2, ok2 := base36ChunkValue(s[13:])
+	if d0 >= 36 || !ok1 || !ok2 {
+		return Uint128{}, ErrInvalidDigit
+	}
+
+	// x = (d0 * 36^12 + c1) * 36^12 + c2, where only the last step may overflow
+	hi, lo := bits.Mul64(uint64(d0), base36Chunk)
+	lo, carry := bits.Add64(lo, c1, 0)
+	hi += carry
+
+	hi1, lo1 := bits.Mul64(lo, base36Chunk)
+	hi2, lo2 := bits.Mul64(hi, base36Chunk)
+	hi, carry = bits.Add64(hi1, lo2, 0)
+	if hi2 != 0 || carry != 0 {
+		return Uint128{}, ErrOverflow
+	}
+	lo, carry = bits.Add64(lo1, c2, 0)
+	hi, carry = bits.Add64(hi, 0, carry)
+	if carry != 0 {
+		return Uint128{}, ErrOverflow
+	}
+	return Uint128{hi, lo}, nil
+}
+
+// Decodes the 32-digit hexadecimal format into a 128-bit value.
+func decodeHex(s string) (Uint128, error) {
+	if len(s) != 32 {
+		return Uint128{}, ErrInvalidLength
+	}
+	hi, ok1 := hexValue(0, s[:16])
+	lo, ok2 := hexValue(0, s[16:])
+	if !ok1 || !ok2 {
+		return Uint128{}, ErrInvalidDigit
+	}
+	return Uint128{hi, lo}, nil
+}
+
+// Decodes the 8-4-4-4-12 hyphenated format into a 128-bit value.
+func decodeHyphenated(s string) (Uint128, error) {
+	if len(s) != 36 {
+		return Uint128{}, ErrInvalidLength
+	} else if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return Uint128{}, ErrInvalidDigit
+	}
+	hi, ok1 := hexValue(0, s[:8])
+	hi, ok2 := hexValue(hi, s[9:13])
+	hi, ok3 := hexValue(hi, s[14:18])
+	lo, ok4 := hexValue(0, s[19:23])
+	lo, ok5 := hexValue(lo, s[24:])
+	if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 {
+		return Uint128{}, ErrInvalidDigit
+	}
+	return Uint128{hi, lo}, nil
+}
+
+// Decodes the braced hyphenated format into a 128-bit value.
+func decodeBraced(s string) (Uint128, error) {
+	if len(s) != 38 {
+		return Uint128{}, ErrInvalidLength
+	} else if s[0] != '{' || s[37] != '}' {
+		return Uint128{}, ErrInvalidDigit
+	}
+	return decodeHyphenated(s[1:37])
+}
+
+// Decodes the RFC 4122 URN format into a 128-bit value.
+func decodeUrn(s string) (Uint128, error) {
+	if len(s) != 45 {
+		return Uint128{}, ErrInvalidLength
+	} else if (s[0] != 'U' && s[0] != 'u') ||
+		(s[1] != 'R' && s[1] != 'r') ||
+		(s[2] != 'N' && s[2] != 'n') ||
+		(s[3] != ':') ||
+		(s[4] != 'U' && s[4] != 'u') ||
+		(s[5] != 'U' && s[5] != 'u') ||
+		(s[6] != 'I' && s[6] != 'i') ||
+		(s[7] != 'D' && s[7] != 'd') ||
+		(s[8] != ':') {
+		return Uint128{}, ErrInvalidDigit
+	}
+	return decodeHyphenated(s[9:])
+}
+
+// Decodes a string in any supported format into a 128-bit value.
+func decodeAny(s string) (Uint128, error) {
+	switch len(s) {
+	case 25:
+		return decodeBase36(s)
+	case 32:
+		return decodeHex(s)
+	case 36:
+		return decodeHyphenated(s)
+	case 38:
+		return decodeBraced(s)
+	case 45:
+		return decodeUrn(s)
+	default:
+		return Uint128{}, ErrInvalidLength
+	}
+}
+
+// Returns the value of 12 Base36 digits.
+func base36ChunkValue(s string) (uint64, bool) {
+	var v uint64
+	for i := 0; i < len(s); i += 1 {
+		d := digitValues[s[i]]
+		if d >= 36 {
+			return 0, false
+		}
+		v = v*36 + uint64(d)
+	}
+	return v, true
+}
+
+// Accumulates the values of hexadecimal digits into `v`.
+func hexValue(v uint64, s string) (uint64, bool) {
+	for i := 0; i < len(s); i += 1 {
+		d := digitValues[s[i]]
+		if d >= 16 {
+			return 0, false
+		}
+		v = v<<4 | uint64(d)
+	}
+	return v, true
+}
+
+// Writes a 64-bit value as 16 hexadecimal digits.
+func putHex(dst []byte, v uint64) {
+	for i := 15; i >= 0; i -= 1 {
+		dst[i] = hexDigits[v&0xf]
+		v >>= 4
+	}
+}
+
+// Decodes the canonical string of a properly constructed value into the
+// 128-bit value.
+func (uuid25 Uuid25) toUint128() Uint128 {
+	x, err := decodeBase36(uuid25.String())
+	if err != nil {
+		panic("receiver not constructed properly")
+	}
+	return x
+}
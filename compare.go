@@ -0,0 +1,31 @@
+package uuid25
+
+import "strings"
+
+// Compares two values and returns -1, 0, or +1 if the receiver is less than,
+// equal to, or greater than `other`, respectively.
+//
+// The result is identical to bytes.Compare() of the 16-byte binary
+// representations because the canonical Uuid25 string is a fixed-length
+// lowercase Base36 numeral. This method panics if either value is not
+// constructed properly.
+func (uuid25 Uuid25) Compare(other Uuid25) int {
+	return strings.Compare(uuid25.String(), other.String())
+}
+
+// Reports whether the receiver sorts before `other` in the byte-wise order of
+// the 16-byte binary representations.
+//
+// This method panics if either value is not constructed properly.
+func (uuid25 Uuid25) Less(other Uuid25) bool {
+	return uuid25.String() < other.String()
+}
+
+// Compares two values in the byte-wise order of the 16-byte binary
+// representations, in the form expected by slices.SortFunc() and
+// slices.BinarySearchFunc():
+//
+//	slices.SortFunc(ids, uuid25.CompareFunc)
+func CompareFunc(a, b Uuid25) int {
+	return a.Compare(b)
+}
@@ -0,0 +1,45 @@
+package uuid25
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// Tests that Compare() and Less() agree with the byte-wise comparison.
+func TestCompare(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	ids := []Uuid25{Nil, FromBytes(bytes.Repeat([]byte{0xff}, 16))}
+	for _, e := range testCases {
+		ids = append(ids, Uuid25(e.uuid25))
+	}
+	for i := 0; i < 256; i += 1 {
+		var b [16]byte
+		rng.Read(b[:])
+		if i%2 == 0 {
+			// share a long prefix with the previous value
+			prev := ids[len(ids)-1].ToBytes()
+			copy(b[:i%16], prev[:])
+		}
+		ids = append(ids, FromBytes(b[:]))
+	}
+
+	for _, a := range ids {
+		for _, b := range ids {
+			ab, bb := a.ToBytes(), b.ToBytes()
+			want := bytes.Compare(ab[:], bb[:])
+			if a.Compare(b) != want || CompareFunc(a, b) != want || a.Less(b) != (want < 0) {
+				t.Errorf("unexpected ordering of %s and %s", a, b)
+			}
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i].Less(ids[j]) })
+	for i := 1; i < len(ids); i += 1 {
+		a, b := ids[i-1].ToBytes(), ids[i].ToBytes()
+		if bytes.Compare(a[:], b[:]) > 0 {
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package uuid25
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Parses a UUID string in any format accepted by Parse() into `dst`.
+//
+// Leading and trailing white space, such as the trailing newline of a value
+// read from a secret file, is ignored. On failure, this function leaves `dst`
+// unchanged, so a default value set beforehand survives an invalid input. This
+// function is intended as a callback for configuration loaders.
+func ParseInto(dst *Uuid25, s string) error {
+	if dst == nil {
+		return errors.New("nil destination")
+	}
+	uuid25, err := Parse(strings.TrimSpace(s))
+	if err != nil {
+		return err
+	}
+	*dst = uuid25
+	return nil
+}
+
+// An error returned by FromEnv() when the environment variable is not set.
+var ErrEnvNotSet = errors.New("environment variable not set")
+
+// Creates an instance from the value of an environment variable in any format
+// accepted by ParseInto().
+//
+// The returned error wraps ErrEnvNotSet if the variable is not set or a parse
+// error if the value is invalid, and it includes the variable name.
+func FromEnv(name string) (Uuid25, error) {
+	s, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("%s: %w", name, ErrEnvNotSet)
+	}
+	var uuid25 Uuid25
+	if err := ParseInto(&uuid25, s); err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return uuid25, nil
+}
@@ -0,0 +1,38 @@
+package uuid25
+
+import (
+	"errors"
+	"testing"
+)
+
+// Tests parsing into a destination.
+func TestParseInto(t *testing.T) {
+	for _, e := range testCases {
+		var x Uuid25
+		if ParseInto(&x, " "+e.hyphenated+"\n") != nil || string(x) != e.uuid25 {
+			t.Fail()
+		}
+	}
+	x := Uuid25("3ud3gtvgolimgu9lah6aie99o")
+	if ParseInto(&x, "invalid") == nil || x != "3ud3gtvgolimgu9lah6aie99o" {
+		t.Fail()
+	}
+	if ParseInto(nil, "3ud3gtvgolimgu9lah6aie99o") == nil {
+		t.Fail()
+	}
+}
+
+// Tests reading from environment variables.
+func TestFromEnv(t *testing.T) {
+	t.Setenv("UUID25_TEST_ID", "40eb9860-cf3e-45e2-a90e-b82236ac806c")
+	t.Setenv("UUID25_TEST_INVALID", "40eb9860")
+	if x, err := FromEnv("UUID25_TEST_ID"); x != "3ud3gtvgolimgu9lah6aie99o" || err != nil {
+		t.Fail()
+	}
+	if _, err := FromEnv("UUID25_TEST_INVALID"); !errors.Is(err, ErrParse) || err.Error()[:20] != "UUID25_TEST_INVALID:" {
+		t.Fail()
+	}
+	if _, err := FromEnv("UUID25_TEST_UNSET"); !errors.Is(err, ErrEnvNotSet) {
+		t.Fail()
+	}
+}
@@ -0,0 +1,50 @@
+package uuid25
+
+import "fmt"
+
+// Returns the value of a Uuid25 literal hardcoded in a program:
+// `uuid25.Const("3ud3gtvgolimgu9lah6aie99o")`.
+//
+// This function accepts only the canonical 25-digit lowercase Uuid25 format and
+// panics otherwise, so that a mistyped ID fails fast rather than flowing into
+// production data. It is intended for package-level variables and test
+// fixtures; the `uuid25const` analyzer in the ext/analysis package reports
+// invalid arguments at build time. Use Parse() for input from outside the
+// program.
+func Const(s string) Uuid25 {
+	if _, err := decodeBase36(s); err != nil {
+		panic(fmt.Sprintf("uuid25: invalid Const argument %q: %v", s, err))
+	}
+	for i := 0; i < len(s); i += 1 {
+		if c := s[i]; c >= 'A' && c <= 'Z' {
+			panic(fmt.Sprintf("uuid25: non-canonical Const argument %q", s))
+		}
+	}
+	return Uuid25(s)
+}
+
+// Creates an instance from a UUID string in any format accepted by Parse(),
+// panicking if the string is invalid.
+//
+// Unlike Const(), this function accepts the hyphenated and other formats, which
+// is convenient for fixtures copied from databases or other systems. Use Parse()
+// for input from outside the program.
+func MustParse(uuidString string) Uuid25 {
+	uuid25, err := Parse(uuidString)
+	if err != nil {
+		panic(fmt.Sprintf("uuid25: invalid MustParse argument %q: %v", uuidString, err))
+	}
+	return uuid25
+}
+
+// Creates an instance from a 16-byte UUID binary representation, panicking if
+// the length of `uuidBytes` is not 16.
+//
+// This function behaves the same as FromBytes() and is provided for symmetry
+// with MustParse() so that code building fixtures reads uniformly.
+func MustFromBytes(uuidBytes []byte) Uuid25 {
+	if len(uuidBytes) != 16 {
+		panic(fmt.Sprintf("uuid25: invalid MustFromBytes argument length %d", len(uuidBytes)))
+	}
+	return FromBytes(uuidBytes)
+}
@@ -0,0 +1,57 @@
+package uuid25
+
+import "testing"
+
+// Tests the constant constructor.
+func TestConst(t *testing.T) {
+	for _, e := range testCases {
+		if Const(e.uuid25) != Uuid25(e.uuid25) {
+			t.Fail()
+		}
+	}
+
+	for _, e := range []string{
+		"",
+		"DPOADK8IZG9Y4TTE7VY1XT94O",
+		"e7a1d63b-7117-4423-8988-afcf12161878",
+		"f5lxx1zz5pnorynqglhzmsp34",
+		"dpoadk8izg9y4tte7vy1xt94_",
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Const(%q) must panic", e)
+				}
+			}()
+			Const(e)
+		}()
+	}
+}
+
+// Tests the panicking constructors.
+func TestMust(t *testing.T) {
+	for _, e := range testCases {
+		if MustParse(e.hyphenated) != Uuid25(e.uuid25) || MustParse(e.urn) != Uuid25(e.uuid25) {
+			t.Fail()
+		}
+		if MustFromBytes(e.bytes) != Uuid25(e.uuid25) {
+			t.Fail()
+		}
+	}
+
+	for _, f := range []func(){
+		func() { MustParse("") },
+		func() { MustParse("e7a1d63b-7117-4423-8988-afcf1216187g") },
+		func() { MustFromBytes(nil) },
+		func() { MustFromBytes(make([]byte, 17)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fail()
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,50 @@
+//go:build cgo
+
+package main
+
+/*
+#include <stddef.h>
+
+#define UUID25_BUFFER_SIZE 46
+
+enum {
+	UUID25_OK = 0,
+	UUID25_ERR_INVALID_INPUT = -1,
+	UUID25_ERR_INVALID_FORMAT = -2,
+	UUID25_ERR_BUFFER_TOO_SMALL = -3,
+};
+*/
+import "C"
+
+import "unsafe"
+
+// Returns a Go slice that refers to a C buffer.
+func buffer(out *C.char, outLen C.size_t) []byte {
+	if out == nil {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(out)), int(outLen))
+}
+
+//export uuid25_parse
+func uuid25_parse(s *C.char, out *C.char, outLen C.size_t) C.int {
+	if s == nil {
+		return statusInvalidInput
+	}
+	return C.int(parse(C.GoString(s), buffer(out, outLen)))
+}
+
+//export uuid25_format
+func uuid25_format(s *C.char, name *C.char, out *C.char, outLen C.size_t) C.int {
+	if s == nil {
+		return statusInvalidInput
+	} else if name == nil {
+		return statusInvalidFormat
+	}
+	return C.int(format(C.GoString(s), C.GoString(name), buffer(out, outLen)))
+}
+
+//export uuid25_new_v7
+func uuid25_new_v7(out *C.char, outLen C.size_t) C.int {
+	return C.int(newV7(buffer(out, outLen)))
+}
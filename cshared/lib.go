@@ -0,0 +1,82 @@
+// C-shared library exporting the Uuid25 encoding
+//
+// This package builds a shared library with a C ABI so that non-Go components
+// can share the Uuid25 implementation of this module:
+//
+//	go build -buildmode=c-shared -o libuuid25.so ./cshared
+//
+// The build also generates the header `libuuid25.h`, which declares the
+// following functions. Each of them writes a NUL-terminated string into a
+// caller-allocated buffer `out` of `outLen` bytes, where a buffer of
+// `UUID25_BUFFER_SIZE` bytes fits any output, and returns `UUID25_OK` or a
+// negative status code:
+//
+//	// Parses a UUID string in any supported format into the Uuid25 format.
+//	int uuid25_parse(char *s, char *out, size_t outLen);
+//
+//	// Parses a UUID string and formats it in the format `name`, one of
+//	// "uuid25", "hex", "hyphenated", "braced", and "urn".
+//	int uuid25_format(char *s, char *name, char *out, size_t outLen);
+//
+//	// Generates a time-ordered UUID (UUIDv7) in the Uuid25 format.
+//	int uuid25_new_v7(char *out, size_t outLen);
+//
+// Building this package requires cgo.
+package main
+
+import (
+	"github.com/uuid25/go-uuid25"
+)
+
+func main() {}
+
+// Status codes shared with the C header.
+const (
+	statusOK             = 0
+	statusInvalidInput   = -1
+	statusInvalidFormat  = -2
+	statusBufferTooSmall = -3
+)
+
+// Writes `s` and a terminating NUL into `out`.
+func writeString(out []byte, s string) int {
+	if len(out) < len(s)+1 {
+		return statusBufferTooSmall
+	}
+	copy(out, s)
+	out[len(s)] = 0
+	return statusOK
+}
+
+func parse(s string, out []byte) int {
+	id, err := uuid25.Parse(s)
+	if err != nil {
+		return statusInvalidInput
+	}
+	return writeString(out, id.String())
+}
+
+func format(s string, name string, out []byte) int {
+	id, err := uuid25.Parse(s)
+	if err != nil {
+		return statusInvalidInput
+	}
+	switch name {
+	case "uuid25":
+		return writeString(out, id.String())
+	case "hex":
+		return writeString(out, id.ToHex())
+	case "hyphenated":
+		return writeString(out, id.ToHyphenated())
+	case "braced":
+		return writeString(out, id.ToBraced())
+	case "urn":
+		return writeString(out, id.ToUrn())
+	default:
+		return statusInvalidFormat
+	}
+}
+
+func newV7(out []byte) int {
+	return writeString(out, uuid25.NewV7().String())
+}
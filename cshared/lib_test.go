@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+// Tests the functions behind the C exports.
+func TestExports(t *testing.T) {
+	out := make([]byte, 46)
+	if parse("40eb9860-cf3e-45e2-a90e-b82236ac806c", out) != statusOK ||
+		string(out[:26]) != "3ud3gtvgolimgu9lah6aie99o\x00" {
+		t.Fail()
+	}
+	if parse("invalid", out) != statusInvalidInput {
+		t.Fail()
+	}
+
+	if format("3ud3gtvgolimgu9lah6aie99o", "urn", out) != statusOK ||
+		string(out) != "urn:uuid:40eb9860-cf3e-45e2-a90e-b82236ac806c\x00" {
+		t.Fail()
+	}
+	if format("3ud3gtvgolimgu9lah6aie99o", "base64", out) != statusInvalidFormat {
+		t.Fail()
+	}
+	if format("3ud3gtvgolimgu9lah6aie99o", "urn", out[:45]) != statusBufferTooSmall {
+		t.Fail()
+	}
+
+	if newV7(out) != statusOK || out[25] != 0 {
+		t.Fail()
+	}
+	if newV7(out[:25]) != statusBufferTooSmall || newV7(nil) != statusBufferTooSmall {
+		t.Fail()
+	}
+}
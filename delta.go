@@ -0,0 +1,65 @@
+package uuid25
+
+import "errors"
+
+// An error returned by ReadDelta() when the input is not a valid delta
+// encoding.
+var ErrInvalidDelta = errors.New("invalid delta encoding")
+
+// Returns the difference `b - a` of the 128-bit values of two UUIDs, wrapping
+// around on underflow, so that `Advance(a, Diff(a, b)) == b` always holds.
+//
+// When `b` is less than `a`, the result is the two's complement of the
+// distance, which AppendDelta() encodes as compactly as the positive distance.
+func Diff(a, b Uuid25) Uint128 {
+	return b.toUint128().Sub(a.toUint128())
+}
+
+// Returns the UUID whose 128-bit value is that of `uuid25` plus `delta`,
+// wrapping around on overflow.
+func Advance(uuid25 Uuid25, delta Uint128) Uuid25 {
+	buffer := encodeBase36(uuid25.toUint128().Add(delta))
+	return Uuid25(buffer[:])
+}
+
+// Appends the variable-length encoding of a delta returned by Diff() to `dst`
+// and returns the extended buffer.
+//
+// The delta is interpreted as a signed two's complement integer, mapped to an
+// unsigned integer by zigzag encoding so that small negative deltas stay small,
+// and then written in the unsigned LEB128 format (7 bits per byte, least
+// significant group first). The encoding takes 1 to 19 bytes, so streams of
+// nearly sequential IDs, such as UUIDv7 generated by a single process, are
+// stored in far fewer than 16 bytes per ID.
+func AppendDelta(dst []byte, delta Uint128) []byte {
+	// zigzag: (delta << 1) ^ (delta >> 127 as an arithmetic shift)
+	sign := uint64(int64(delta.Hi) >> 63)
+	x := delta.Lsh(1).Xor(Uint128{sign, sign})
+	for x.Hi != 0 || x.Lo >= 0x80 {
+		dst = append(dst, byte(x.Lo)|0x80)
+		x = x.Rsh(7)
+	}
+	return append(dst, byte(x.Lo))
+}
+
+// Decodes a delta encoded by AppendDelta() at the beginning of `src` and
+// returns the delta and the number of bytes read.
+//
+// This function returns ErrInvalidDelta if `src` ends before the encoding
+// completes or the encoding exceeds 128 bits.
+func ReadDelta(src []byte) (Uint128, int, error) {
+	var x Uint128
+	for i := 0; i < len(src) && i < 19; i += 1 {
+		group := Uint128From64(uint64(src[i] & 0x7f))
+		if i == 18 && src[i] > 0x03 {
+			return Uint128{}, 0, ErrInvalidDelta // beyond 128 bits
+		}
+		x = x.Or(group.Lsh(uint(7 * i)))
+		if src[i] < 0x80 {
+			// inverse zigzag: (x >> 1) ^ -(x & 1)
+			sign := -(x.Lo & 1)
+			return x.Rsh(1).Xor(Uint128{sign, sign}), i + 1, nil
+		}
+	}
+	return Uint128{}, 0, ErrInvalidDelta
+}
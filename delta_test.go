@@ -0,0 +1,84 @@
+package uuid25
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Tests difference computation and advancement.
+func TestDiffAdvance(t *testing.T) {
+	a, _ := Parse("01809424-3e59-7c05-9219-566f82fff672")
+	b, _ := Parse("01809424-3e5a-7000-8000-000000000000")
+	if d := Diff(a, b); d.Hi != 0xf3fa || d.Lo != 0xede6_a990_7d00_098e || Advance(a, d) != b {
+		t.Errorf("unexpected delta: %x %x", d.Hi, d.Lo)
+	}
+	if d := Diff(b, a); d != Diff(a, b).Not().Inc() || Advance(b, d) != a {
+		t.Fail()
+	}
+	if Diff(a, a) != (Uint128{}) || Advance(a, Uint128{}) != a {
+		t.Fail()
+	}
+
+	max := Uuid25("f5lxx1zz5pnorynqglhzmsp33")
+	zero := Uuid25("0000000000000000000000000")
+	if Advance(max, Uint128From64(1)) != zero || Diff(max, zero) != Uint128From64(1) {
+		t.Fail()
+	}
+}
+
+// Tests the variable-length delta encoding.
+func TestDeltaEncoding(t *testing.T) {
+	minusOne := Uint128{}.Dec()
+	cases := []struct {
+		delta Uint128
+		size  int
+	}{
+		{Uint128{}, 1},
+		{Uint128From64(1), 1},
+		{minusOne, 1},
+		{Uint128From64(63), 1},
+		{Uint128From64(64), 2},
+		{Uint128From64(1 << 40), 6},
+		{Uint128{1 << 62, 0}, 19},
+		{Uint128{1<<63 - 1, 1<<64 - 1}, 19},
+		{Uint128{1 << 63, 0}, 19},
+	}
+	for _, e := range cases {
+		data := AppendDelta([]byte{0xff}, e.delta)
+		if len(data) != e.size+1 {
+			t.Errorf("unexpected size for %v: %d", e.delta, len(data)-1)
+		}
+		if d, n, err := ReadDelta(append(data[1:], 0xff)); err != nil || n != e.size || d != e.delta {
+			t.Errorf("unexpected decoding for %v: %v %d %v", e.delta, d, n, err)
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i += 1 {
+		d := Uint128{r.Uint64(), r.Uint64()}.Rsh(uint(r.Intn(128)))
+		if r.Intn(2) == 0 {
+			d = d.Not().Inc()
+		}
+		if x, _, err := ReadDelta(AppendDelta(nil, d)); err != nil || x != d {
+			t.Fatalf("round trip failed for %v", d)
+		}
+	}
+
+	continued := func(n int) []byte {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = 0x80
+		}
+		return b
+	}
+	for _, e := range [][]byte{
+		nil,
+		continued(1),
+		continued(19),
+		append(continued(18), 0x04),
+	} {
+		if _, _, err := ReadDelta(e); err != ErrInvalidDelta {
+			t.Errorf("%x must be rejected", e)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package uuid25
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding"
+	"hash"
+)
+
+// The maximum number of namespaces whose hash states a Deriver keeps.
+const deriverCacheLimit = 1024
+
+// A generator of name-based UUIDs that caches the hash state of each namespace.
+//
+// Deriving a name-based UUID involves decoding the namespace and feeding it
+// into a hash function before the name. A deriver does this once per namespace
+// and restores the saved hash state for subsequent names, which speeds up bulk
+// generation of deterministic IDs under a small set of namespaces, such as in
+// import pipelines. The results are identical to those of uncached
+// implementations.
+//
+// A deriver is not safe for concurrent use; create one per goroutine instead.
+// It keeps the states of up to 1024 namespaces and discards all of them when
+// the limit is exceeded.
+type Deriver struct {
+	version byte
+	h       hash.Hash
+	states  map[Uuid25][]byte
+	last    Uuid25
+	state   []byte
+	buf     [sha256.Size]byte
+}
+
+// Creates a deriver of UUIDv5 values, which are based on SHA-1.
+func NewV5Deriver() *Deriver {
+	return &Deriver{version: 5, h: sha1.New(), states: make(map[Uuid25][]byte)}
+}
+
+// Creates a deriver of name-based UUIDv8 values based on SHA-256, as
+// illustrated in RFC 9562 Appendix B.2.
+func NewV8Deriver() *Deriver {
+	return &Deriver{version: 8, h: sha256.New(), states: make(map[Uuid25][]byte)}
+}
+
+// Returns the name-based UUID of `name` in `namespace`.
+func (d *Deriver) Derive(namespace Uuid25, name []byte) Uuid25 {
+	if d.state == nil || namespace != d.last {
+		d.state = d.stateOf(namespace)
+		d.last = namespace
+	}
+	if err := d.h.(encoding.BinaryUnmarshaler).UnmarshalBinary(d.state); err != nil {
+		panic(err)
+	}
+	d.h.Write(name)
+	sum := d.h.Sum(d.buf[:0])
+	sum[6] = d.version<<4 | sum[6]&0x0f
+	sum[8] = 0x80 | sum[8]&0x3f
+	return FromBytes(sum[:16])
+}
+
+// Returns the hash state after writing `namespace`, computing it if not cached.
+func (d *Deriver) stateOf(namespace Uuid25) []byte {
+	if state, ok := d.states[namespace]; ok {
+		return state
+	}
+	d.h.Reset()
+	ns := namespace.ToBytes()
+	d.h.Write(ns[:])
+	state, err := d.h.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	if len(d.states) >= deriverCacheLimit {
+		d.states = make(map[Uuid25][]byte)
+	}
+	d.states[namespace] = state
+	return state
+}
@@ -0,0 +1,67 @@
+package uuid25
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Tests Deriver against the RFC 9562 examples and the uncached implementation.
+func TestDeriver(t *testing.T) {
+	dns, _ := Parse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	url, _ := Parse("6ba7b811-9dad-11d1-80b4-00c04fd430c8")
+
+	v5 := NewV5Deriver()
+	for i := 0; i < 3; i += 1 {
+		if v5.Derive(dns, []byte("www.example.com")).ToHyphenated() != "2ed6657d-e927-568b-95e1-2665a8aea6a2" {
+			t.Fail()
+		}
+		for _, ns := range []Uuid25{dns, url} {
+			name := []byte("name-" + strconv.Itoa(i))
+			if v5.Derive(ns, name) != newV5(ns, name) {
+				t.Fail()
+			}
+		}
+	}
+
+	v8 := NewV8Deriver()
+	for i := 0; i < 3; i += 1 {
+		if v8.Derive(dns, []byte("www.example.com")).ToHyphenated() != "5c146b14-3c52-8afd-938a-375d0df1fbf6" {
+			t.Fail()
+		}
+		if v8.Derive(url, []byte("www.example.com")) == v8.Derive(dns, []byte("www.example.com")) {
+			t.Fail()
+		}
+	}
+}
+
+// Tests that Deriver keeps working after exceeding the cache limit.
+func TestDeriverCacheLimit(t *testing.T) {
+	d := NewV5Deriver()
+	ns := Nil
+	for i := 0; i <= deriverCacheLimit; i += 1 {
+		ns = newV5(ns, nil)
+		if d.Derive(ns, []byte("x")) != newV5(ns, []byte("x")) {
+			t.Fail()
+		}
+	}
+	if len(d.states) > deriverCacheLimit {
+		t.Fail()
+	}
+}
+
+// Benchmarks Deriver against the uncached implementation.
+func BenchmarkDeriver(b *testing.B) {
+	ns, _ := Parse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	name := []byte("www.example.com")
+	b.Run("Cached", func(b *testing.B) {
+		d := NewV5Deriver()
+		for i := 0; i < b.N; i += 1 {
+			d.Derive(ns, name)
+		}
+	})
+	b.Run("Uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i += 1 {
+			newV5(ns, name)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package uuid25
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+)
+
+// An order-independent digest of a set of UUIDs.
+//
+// The digest consists of the number of elements and the sum modulo 2^128 of
+// the truncated SHA-256 hashes of their binary representations. Two replicas
+// holding the same collection of IDs compute the same digest regardless of
+// the order of insertion, so comparing the digests, typically 24 bytes
+// exchanged with MarshalBinary, cheaply tells whether the collections differ.
+// Elements can also be removed incrementally.
+//
+// The digest is meant for detecting accidental divergence, not for
+// authentication; an adversary choosing the IDs can forge collisions. The zero
+// value is the digest of the empty set.
+type SetDigest struct {
+	count uint64
+	sum   Uint128
+}
+
+// Returns the digest of a collection of UUIDs.
+func Digest(ids []Uuid25) SetDigest {
+	var d SetDigest
+	for _, e := range ids {
+		d.Add(e)
+	}
+	return d
+}
+
+// Adds a UUID to the digest.
+//
+// The digest treats the collection as a multiset; adding an ID twice is not
+// the same as adding it once.
+func (d *SetDigest) Add(id Uuid25) {
+	d.count += 1
+	d.sum = d.sum.Add(elementHash(id))
+}
+
+// Removes a UUID previously added to the digest.
+func (d *SetDigest) Remove(id Uuid25) {
+	d.count -= 1
+	d.sum = d.sum.Sub(elementHash(id))
+}
+
+// Returns the number of elements in the digest.
+func (d SetDigest) Count() uint64 {
+	return d.count
+}
+
+// Implements the encoding.BinaryMarshaler interface.
+//
+// The result is the 8-byte big-endian count followed by the 16-byte sum.
+func (d SetDigest) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 24)
+	binary.BigEndian.PutUint64(b, d.count)
+	binary.BigEndian.PutUint64(b[8:], d.sum.Hi)
+	binary.BigEndian.PutUint64(b[16:], d.sum.Lo)
+	return b, nil
+}
+
+// Implements the encoding.BinaryUnmarshaler interface.
+func (d *SetDigest) UnmarshalBinary(data []byte) error {
+	if d == nil {
+		return errors.New("nil receiver")
+	}
+	if len(data) != 24 {
+		return errors.New("invalid length")
+	}
+	d.count = binary.BigEndian.Uint64(data)
+	d.sum = Uint128{Hi: binary.BigEndian.Uint64(data[8:]), Lo: binary.BigEndian.Uint64(data[16:])}
+	return nil
+}
+
+// Returns the first 128 bits of the SHA-256 hash of a UUID as an integer.
+func elementHash(id Uuid25) Uint128 {
+	b := id.ToBytes()
+	h := sha256.Sum256(b[:])
+	return Uint128{Hi: binary.BigEndian.Uint64(h[:8]), Lo: binary.BigEndian.Uint64(h[8:16])}
+}
@@ -0,0 +1,51 @@
+package uuid25
+
+import "testing"
+
+// Tests that Digest is independent of the order of elements.
+func TestDigest(t *testing.T) {
+	ids := make([]Uuid25, len(testCases))
+	for i, e := range testCases {
+		ids[i] = Uuid25(e.uuid25)
+	}
+	reversed := make([]Uuid25, len(ids))
+	for i, e := range ids {
+		reversed[len(ids)-1-i] = e
+	}
+	d := Digest(ids)
+	if d != Digest(reversed) || d.Count() != uint64(len(ids)) {
+		t.Fail()
+	}
+	if d == Digest(ids[1:]) || d == Digest(append(ids, ids[0])) {
+		t.Fail()
+	}
+	if (SetDigest{}) != Digest(nil) {
+		t.Fail()
+	}
+
+	e := Digest(ids[1:])
+	e.Add(ids[0])
+	if e != d {
+		t.Fail()
+	}
+	e.Remove(ids[0])
+	if e != Digest(ids[1:]) {
+		t.Fail()
+	}
+}
+
+// Tests the binary encoding of SetDigest.
+func TestDigestMarshalBinary(t *testing.T) {
+	d := Digest([]Uuid25{"3ud3gtvgolimgu9lah6aie99o", "dpoadk8izg9y4tte7vy1xt94o"})
+	b, err := d.MarshalBinary()
+	if err != nil || len(b) != 24 || b[7] != 2 {
+		t.Fail()
+	}
+	var x SetDigest
+	if x.UnmarshalBinary(b) != nil || x != d {
+		t.Fail()
+	}
+	if x.UnmarshalBinary(b[1:]) == nil {
+		t.Fail()
+	}
+}
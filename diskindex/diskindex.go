@@ -0,0 +1,243 @@
+// Sorted on-disk index of UUID keys
+//
+// This package writes sorted UUID keys, optionally with fixed-size values, to
+// a flat file and looks them up by binary search. The file is designed to be
+// memory-mapped, so that read-only membership checks over sets too large for
+// in-memory maps cost little more than the page cache.
+//
+// The file consists of a 16-byte header followed by fixed-size records:
+//
+//   - header: 8-byte magic `UUID25IX`, 4-byte big-endian format version (1),
+//     and 4-byte big-endian value size in bytes
+//   - records: 16-byte binary representation of a key followed by the value,
+//     sorted by key in ascending order without duplicates
+package diskindex
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+const (
+	magic     = "UUID25IX"
+	version   = 1
+	headerLen = 16
+	keyLen    = 16
+)
+
+// An error returned when a file is not a valid index.
+var ErrInvalidIndex = errors.New("invalid index file")
+
+// An error returned when a reader is used after Close().
+var ErrClosed = errors.New("index reader closed")
+
+// An error returned when keys are not added in strictly ascending order.
+var ErrUnsorted = errors.New("keys not in strictly ascending order")
+
+// A writer of an index file.
+type Writer struct {
+	w         *bufio.Writer
+	valueSize int
+	prev      [keyLen]byte
+	count     int
+}
+
+// Creates a writer that writes an index with values of `valueSize` bytes to
+// `w`, writing the header immediately.
+func NewWriter(w io.Writer, valueSize int) (*Writer, error) {
+	if valueSize < 0 || valueSize > 1<<31-1-keyLen {
+		return nil, errors.New("invalid value size")
+	}
+	writer := &Writer{w: bufio.NewWriter(w), valueSize: valueSize}
+	var header [headerLen]byte
+	copy(header[:8], magic)
+	binary.BigEndian.PutUint32(header[8:12], version)
+	binary.BigEndian.PutUint32(header[12:16], uint32(valueSize))
+	if _, err := writer.w.Write(header[:]); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// Appends a key and its value, which must be greater than the previously added
+// key and exactly as long as the value size, respectively.
+func (writer *Writer) Add(key uuid25.Uuid25, value []byte) error {
+	if len(value) != writer.valueSize {
+		return errors.New("value size mismatch")
+	}
+	b := key.ToBytes()
+	if writer.count > 0 && bytes.Compare(b[:], writer.prev[:]) <= 0 {
+		return ErrUnsorted
+	}
+	if _, err := writer.w.Write(b[:]); err != nil {
+		return err
+	}
+	if _, err := writer.w.Write(value); err != nil {
+		return err
+	}
+	writer.prev = b
+	writer.count += 1
+	return nil
+}
+
+// Writes any buffered data to the underlying writer.
+func (writer *Writer) Flush() error {
+	return writer.w.Flush()
+}
+
+// A reader of an index file.
+//
+// A Reader is safe for concurrent use, including Close(), which waits for
+// ongoing reads to complete.
+type Reader struct {
+	mu        sync.RWMutex
+	r         io.ReaderAt
+	closer    io.Closer
+	closed    bool
+	valueSize int
+	count     int
+}
+
+// Creates a reader of an index of `size` bytes read through `r`.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	if size < headerLen {
+		return nil, ErrInvalidIndex
+	}
+	var header [headerLen]byte
+	if _, err := r.ReadAt(header[:], 0); err != nil {
+		return nil, err
+	}
+	if string(header[:8]) != magic || binary.BigEndian.Uint32(header[8:12]) != version {
+		return nil, ErrInvalidIndex
+	}
+	valueSize := int64(binary.BigEndian.Uint32(header[12:16]))
+	recordSize := keyLen + valueSize
+	if (size-headerLen)%recordSize != 0 {
+		return nil, ErrInvalidIndex
+	}
+	return &Reader{r: r, valueSize: int(valueSize), count: int((size - headerLen) / recordSize)}, nil
+}
+
+// Returns the number of keys in the index.
+func (reader *Reader) Len() int {
+	return reader.count
+}
+
+// Returns the size of the values in bytes.
+func (reader *Reader) ValueSize() int {
+	return reader.valueSize
+}
+
+// Returns the key at position `i` in ascending order.
+func (reader *Reader) KeyAt(i int) (uuid25.Uuid25, error) {
+	b, err := reader.keyAt(i)
+	if err != nil {
+		return "", err
+	}
+	return uuid25.FromBytes(b[:]), nil
+}
+
+// Returns the value at position `i` in ascending order of keys.
+func (reader *Reader) ValueAt(i int) ([]byte, error) {
+	if i < 0 || i >= reader.count {
+		return nil, errors.New("index out of range")
+	}
+	value := make([]byte, reader.valueSize)
+	if err := reader.readAt(value, reader.offset(i)+keyLen); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (reader *Reader) keyAt(i int) ([keyLen]byte, error) {
+	var b [keyLen]byte
+	if i < 0 || i >= reader.count {
+		return b, errors.New("index out of range")
+	}
+	err := reader.readAt(b[:], reader.offset(i))
+	return b, err
+}
+
+// Reads `len(p)` bytes at offset `off`, or returns ErrClosed after Close().
+func (reader *Reader) readAt(p []byte, off int64) error {
+	reader.mu.RLock()
+	defer reader.mu.RUnlock()
+	if reader.closed {
+		return ErrClosed
+	}
+	_, err := reader.r.ReadAt(p, off)
+	return err
+}
+
+func (reader *Reader) offset(i int) int64 {
+	return headerLen + int64(i)*int64(keyLen+reader.valueSize)
+}
+
+// Returns the position of `key` in the index, or the position at which it would
+// be inserted and `false` if not present.
+func (reader *Reader) Search(key uuid25.Uuid25) (int, bool, error) {
+	target := key.ToBytes()
+	var firstErr error
+	i := sort.Search(reader.count, func(i int) bool {
+		b, err := reader.keyAt(i)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		return bytes.Compare(b[:], target[:]) >= 0
+	})
+	if firstErr != nil {
+		return 0, false, firstErr
+	}
+	if i < reader.count {
+		b, err := reader.keyAt(i)
+		if err != nil {
+			return 0, false, err
+		}
+		return i, b == target, nil
+	}
+	return i, false, nil
+}
+
+// Reports whether the index contains `key`.
+func (reader *Reader) Contains(key uuid25.Uuid25) (bool, error) {
+	_, ok, err := reader.Search(key)
+	return ok, err
+}
+
+// Returns the value of `key`, or `nil` and `false` if not present.
+func (reader *Reader) Get(key uuid25.Uuid25) ([]byte, bool, error) {
+	i, ok, err := reader.Search(key)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	value, err := reader.ValueAt(i)
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Releases the resources held by a reader created by Open().
+//
+// Subsequent reads return ErrClosed, and calling Close() again has no effect.
+func (reader *Reader) Close() error {
+	reader.mu.Lock()
+	defer reader.mu.Unlock()
+	if reader.closed {
+		return nil
+	}
+	reader.closed = true
+	if reader.closer == nil {
+		return nil
+	}
+	err := reader.closer.Close()
+	reader.closer = nil
+	return err
+}
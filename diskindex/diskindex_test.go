@@ -0,0 +1,153 @@
+package diskindex
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests writing an index and looking up keys through Open() and NewReader().
+func TestWriteRead(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	keys := make([][16]byte, 1000)
+	for i := range keys {
+		r.Read(keys[i][:])
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i][:], keys[j][:]) < 0 })
+
+	var buffer bytes.Buffer
+	writer, _ := NewWriter(&buffer, 4)
+	for i, e := range keys {
+		var value [4]byte
+		binary.BigEndian.PutUint32(value[:], uint32(i))
+		if err := writer.Add(uuid25.FromBytes(e[:]), value[:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if writer.Add(uuid25.FromBytes(keys[0][:]), make([]byte, 4)) != ErrUnsorted {
+		t.Fail()
+	}
+	if writer.Add(uuid25.FromBytes(keys[0][:]), nil) == nil {
+		t.Fail()
+	}
+	writer.Flush()
+
+	name := filepath.Join(t.TempDir(), "index")
+	os.WriteFile(name, buffer.Bytes(), 0o644)
+	opened, err := Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer opened.Close()
+	inMemory, _ := NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+
+	for _, reader := range []*Reader{opened, inMemory} {
+		if reader.Len() != len(keys) || reader.ValueSize() != 4 {
+			t.Fatal("unexpected index size")
+		}
+		for i, e := range keys {
+			key := uuid25.FromBytes(e[:])
+			if value, ok, err := reader.Get(key); !ok || err != nil || binary.BigEndian.Uint32(value) != uint32(i) {
+				t.Fatalf("unexpected value for key %d", i)
+			}
+			if x, _ := reader.KeyAt(i); x != key {
+				t.Fatalf("unexpected key at %d", i)
+			}
+		}
+
+		var absent [16]byte
+		r.Read(absent[:])
+		if ok, err := reader.Contains(uuid25.FromBytes(absent[:])); ok || err != nil {
+			t.Fail()
+		}
+		if i, ok, _ := reader.Search("0000000000000000000000000"); i != 0 || ok {
+			t.Fail()
+		}
+		if i, ok, _ := reader.Search("f5lxx1zz5pnorynqglhzmsp33"); i != len(keys) || ok {
+			t.Fail()
+		}
+	}
+}
+
+// Tests that a reader fails cleanly after Close(), even with concurrent reads.
+func TestClose(t *testing.T) {
+	var buffer bytes.Buffer
+	writer, _ := NewWriter(&buffer, 0)
+	for i := 0; i < 1000; i += 1 {
+		var b [16]byte
+		binary.BigEndian.PutUint64(b[8:], uint64(i))
+		writer.Add(uuid25.FromBytes(b[:]), nil)
+	}
+	writer.Flush()
+	name := filepath.Join(t.TempDir(), "index")
+	os.WriteFile(name, buffer.Bytes(), 0o644)
+	reader, err := Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i += 1 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j += 1 {
+				if _, err := reader.KeyAt(j); err != nil && err != ErrClosed {
+					t.Error(err)
+					return
+				}
+				if _, _, err := reader.Search(uuid25.Max); err != nil && err != ErrClosed {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	if err := reader.Close(); err != nil {
+		t.Fail()
+	}
+	wg.Wait()
+
+	if _, err := reader.KeyAt(0); err != ErrClosed {
+		t.Fail()
+	}
+	if _, err := reader.ValueAt(0); err != ErrClosed {
+		t.Fail()
+	}
+	if _, err := reader.Contains(uuid25.Nil); err != ErrClosed {
+		t.Fail()
+	}
+	if reader.Close() != nil {
+		t.Fail()
+	}
+}
+
+// Tests if malformed files are rejected.
+func TestInvalidIndex(t *testing.T) {
+	var buffer bytes.Buffer
+	writer, _ := NewWriter(&buffer, 0)
+	writer.Add("0000000000000000000000001", nil)
+	writer.Flush()
+	data := buffer.Bytes()
+
+	if reader, err := NewReader(bytes.NewReader(data), int64(len(data))); err != nil || reader.Len() != 1 {
+		t.Fail()
+	}
+	if _, err := NewReader(bytes.NewReader(data), int64(len(data)-1)); err != ErrInvalidIndex {
+		t.Fail()
+	}
+	if _, err := NewReader(bytes.NewReader(data[:8]), 8); err != ErrInvalidIndex {
+		t.Fail()
+	}
+	corrupted := append([]byte("XXXX"), data[4:]...)
+	if _, err := NewReader(bytes.NewReader(corrupted), int64(len(corrupted))); err != ErrInvalidIndex {
+		t.Fail()
+	}
+}
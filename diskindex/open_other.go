@@ -0,0 +1,28 @@
+//go:build !unix || tinygo
+
+package diskindex
+
+import (
+	"os"
+)
+
+// Opens an index file, reading it through the file handle on platforms without
+// memory mapping support.
+func Open(name string) (*Reader, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	reader, err := NewReader(file, info.Size())
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	reader.closer = file
+	return reader, nil
+}
@@ -0,0 +1,45 @@
+//go:build unix && !tinygo
+
+package diskindex
+
+import (
+	"bytes"
+	"os"
+	"syscall"
+)
+
+// Opens an index file by mapping it into memory.
+func Open(name string) (*Reader, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+	if size < headerLen || int64(int(size)) != size {
+		return nil, ErrInvalidIndex
+	}
+
+	data, err := syscall.Mmap(int(file.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := NewReader(bytes.NewReader(data), size)
+	if err != nil {
+		syscall.Munmap(data)
+		return nil, err
+	}
+	reader.closer = mapping(data)
+	return reader, nil
+}
+
+// A memory mapping released by Close().
+type mapping []byte
+
+func (m mapping) Close() error {
+	return syscall.Munmap(m)
+}
@@ -0,0 +1,18 @@
+package uuid25
+
+import "encoding/binary"
+
+// Returns the deterministic ID of the event at `sequence` in the event stream
+// identified by `streamID`.
+//
+// The result is a UUIDv5 that uses the stream ID as the namespace and the
+// big-endian 8-byte representation of the sequence number as the name, so
+// replaying a stream always reproduces the same event IDs, and the IDs of
+// different streams or sequence numbers collide only with the probability of a
+// SHA-1 collision. The same value can be computed in other languages with any
+// UUIDv5 implementation.
+func EventID(streamID Uuid25, sequence uint64) Uuid25 {
+	var name [8]byte
+	binary.BigEndian.PutUint64(name[:], sequence)
+	return newV5(streamID, name[:])
+}
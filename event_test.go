@@ -0,0 +1,18 @@
+package uuid25
+
+import "testing"
+
+// Tests EventID against a value computed by another UUIDv5 implementation.
+func TestEventID(t *testing.T) {
+	stream, _ := Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	x := EventID(stream, 1)
+	if x.ToHyphenated() != "7314708b-26d3-50cb-8361-33709f5e5d0a" {
+		t.Fail()
+	}
+	if EventID(stream, 1) != x {
+		t.Fail()
+	}
+	if EventID(stream, 2) == x || EventID(Nil, 1) == x {
+		t.Fail()
+	}
+}
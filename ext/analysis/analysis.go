@@ -0,0 +1,52 @@
+// Extension that provides static analyzers for code using Uuid25
+//
+// This package provides go/analysis analyzers that catch mistakes in the use of
+// this module at build time. They can be run with the bundled `uuid25vet`
+// command, through `go vet -vettool`, or from golangci-lint and other drivers:
+//
+//	go build -o uuid25vet ./cmd/uuid25vet # in this directory
+//	go vet -vettool=$(which uuid25vet) ./...
+//
+// This package is a separate module so that its golang.org/x/tools dependency
+// and newer Go version requirement do not apply to users of the core package.
+// The module refers to the core package in the same tree, so build the command
+// from a checkout of the repository rather than with `go install`.
+package uuid25analysis
+
+import (
+	"bytes"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// The import path of the core package.
+const uuid25Path = "github.com/uuid25/go-uuid25"
+
+// All analyzers provided by this package.
+var Analyzers = []*analysis.Analyzer{ConstAnalyzer, MisuseAnalyzer}
+
+// Reports whether `obj` is the object named `name` in the core package.
+func isUuid25Object(obj types.Object, name string) bool {
+	return obj != nil && obj.Pkg() != nil && obj.Pkg().Path() == uuid25Path && obj.Name() == name
+}
+
+// Returns the value of `expr` if it is a constant string.
+func constString(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+// Returns the source code of `node`.
+func render(fset *token.FileSet, node ast.Node) string {
+	var buffer bytes.Buffer
+	format.Node(&buffer, fset, node)
+	return buffer.String()
+}
@@ -0,0 +1,17 @@
+package uuid25analysis
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// Tests the checks of Uuid25 literals and their suggested fixes.
+func TestConstAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), ConstAnalyzer, "constcheck")
+}
+
+// Tests the checks of string conversions and comparisons.
+func TestMisuseAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), MisuseAnalyzer, "misuse")
+}
@@ -0,0 +1,16 @@
+// Command uuid25vet runs the analyzers of the uuid25analysis package.
+//
+// Usage:
+//
+//	uuid25vet [flags] [packages]
+//	go vet -vettool=$(which uuid25vet) [packages]
+package main
+
+import (
+	uuid25analysis "github.com/uuid25/go-uuid25/ext/analysis"
+	"golang.org/x/tools/go/analysis/multichecker"
+)
+
+func main() {
+	multichecker.Main(uuid25analysis.Analyzers...)
+}
@@ -0,0 +1,97 @@
+package uuid25analysis
+
+import (
+	"go/ast"
+	"go/types"
+	"strconv"
+
+	"github.com/uuid25/go-uuid25"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// An analyzer that reports invalid UUID literals passed to uuid25.Const() or
+// converted directly into uuid25.Uuid25.
+//
+// Both forms accept only the canonical 25-digit lowercase Uuid25 format. When
+// a literal is a valid UUID in another format or case, the analyzer suggests
+// replacing it with the canonical Uuid25 string. It also reports non-constant
+// arguments to uuid25.Const(), which is meant for literals only.
+var ConstAnalyzer = &analysis.Analyzer{
+	Name:     "uuid25const",
+	Doc:      "check that hardcoded Uuid25 literals are valid and canonical",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runConst,
+}
+
+func runConst(pass *analysis.Pass) (any, error) {
+	if pass.Pkg.Path() == uuid25Path {
+		return nil, nil // the core package constructs invalid values deliberately
+	}
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		if len(call.Args) != 1 {
+			return
+		}
+		arg := call.Args[0]
+
+		if tv, ok := pass.TypesInfo.Types[call.Fun]; ok && tv.IsType() {
+			named, ok := tv.Type.(*types.Named)
+			if !ok || !isUuid25Object(named.Obj(), "Uuid25") {
+				return
+			}
+			if s, ok := constString(pass, arg); ok && s != "" {
+				checkLiteral(pass, arg, s, "uuid25.Uuid25 conversion")
+			}
+			return
+		}
+
+		var obj types.Object
+		switch fun := ast.Unparen(call.Fun).(type) {
+		case *ast.Ident:
+			obj = pass.TypesInfo.Uses[fun]
+		case *ast.SelectorExpr:
+			obj = pass.TypesInfo.Uses[fun.Sel]
+		}
+		if _, ok := obj.(*types.Func); !ok || !isUuid25Object(obj, "Const") {
+			return
+		}
+		if s, ok := constString(pass, arg); ok {
+			checkLiteral(pass, arg, s, "uuid25.Const argument")
+		} else {
+			pass.Reportf(arg.Pos(), "uuid25.Const argument must be a constant; use uuid25.Parse for runtime values")
+		}
+	})
+	return nil, nil
+}
+
+// Reports `s` if it is not a canonical Uuid25 string, suggesting the canonical
+// form if `s` is a valid UUID in another format.
+func checkLiteral(pass *analysis.Pass, arg ast.Expr, s string, context string) {
+	x, err := uuid25.Parse(s)
+	if err != nil {
+		pass.Reportf(arg.Pos(), "invalid UUID in %s: %q (%v)", context, s, err)
+		return
+	} else if x.String() == s {
+		return
+	}
+
+	diagnostic := analysis.Diagnostic{
+		Pos:     arg.Pos(),
+		End:     arg.End(),
+		Message: "non-canonical Uuid25 in " + context + ": " + strconv.Quote(s) + " should be " + strconv.Quote(x.String()),
+	}
+	if _, ok := ast.Unparen(arg).(*ast.BasicLit); ok {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "Replace with the canonical Uuid25 string",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     arg.Pos(),
+				End:     arg.End(),
+				NewText: []byte(strconv.Quote(x.String())),
+			}},
+		}}
+	}
+	pass.Report(diagnostic)
+}
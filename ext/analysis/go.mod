@@ -0,0 +1,14 @@
+module github.com/uuid25/go-uuid25/ext/analysis
+
+go 1.25.0
+
+require github.com/uuid25/go-uuid25 v0.0.0-00010101000000-000000000000
+
+require (
+	golang.org/x/mod v0.37.0 // indirect
+	golang.org/x/sync v0.21.0 // indirect
+	golang.org/x/tools v0.47.0
+)
+
+// The analyzers are developed against the core package in the same tree.
+replace github.com/uuid25/go-uuid25 => ../..
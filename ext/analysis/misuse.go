@@ -0,0 +1,192 @@
+package uuid25analysis
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/uuid25/go-uuid25"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// An analyzer that reports patterns that bypass the safe API of Uuid25 during
+// adoption.
+//
+// It reports `string(x)` conversions of uuid25.Uuid25 values, which skip the
+// validity check of String(), and comparisons of Uuid25 values with string
+// literals in other formats such as hexadecimal or hyphenated ones, which are
+// always false because Uuid25 values hold the 25-digit representation.
+//
+// Conversions passed to functions of the core package, such as
+// `uuid25.ParseUuid25(string(x))`, are not reported because those functions
+// validate the string. Neither are conversions passed as arguments for the
+// format string of printf-like functions, such as `t.Errorf("%q", string(x))`,
+// because they are the way to print values that may be invalid, on which
+// String() panics.
+var MisuseAnalyzer = &analysis.Analyzer{
+	Name:     "uuid25misuse",
+	Doc:      "check for string conversions and comparisons that misuse Uuid25 values",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runMisuse,
+}
+
+func runMisuse(pass *analysis.Pass) (any, error) {
+	if pass.Pkg.Path() == uuid25Path {
+		return nil, nil // the core package converts its own representation
+	}
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	filter := []ast.Node{(*ast.CallExpr)(nil), (*ast.BinaryExpr)(nil)}
+	inspect.WithStack(filter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if len(stack) < 2 || !isCoreCall(pass, stack[len(stack)-2]) &&
+				!isFormatArg(pass, stack[len(stack)-2], n) {
+				checkStringConversion(pass, n)
+			}
+		case *ast.BinaryExpr:
+			if n.Op == token.EQL || n.Op == token.NEQ {
+				checkComparison(pass, n.X, n.Y)
+				checkComparison(pass, n.Y, n.X)
+			}
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// Reports whether `n` is a call to a function of the core package.
+func isCoreCall(pass *analysis.Pass, n ast.Node) bool {
+	call, ok := n.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	var obj types.Object
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		obj = pass.TypesInfo.Uses[fun]
+	case *ast.SelectorExpr:
+		obj = pass.TypesInfo.Uses[fun.Sel]
+	}
+	fn, ok := obj.(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == uuid25Path &&
+		fn.Type().(*types.Signature).Recv() == nil
+}
+
+// Reports whether `arg` is passed to `n` as an argument for the format string
+// of a printf-like function, whose name ends with "f" and whose last two
+// parameters are `format string` and `args ...any`.
+func isFormatArg(pass *analysis.Pass, n ast.Node, arg ast.Expr) bool {
+	call, ok := n.(*ast.CallExpr)
+	if !ok || call.Ellipsis.IsValid() {
+		return false
+	}
+	var name string
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		name = fun.Name
+	case *ast.SelectorExpr:
+		name = fun.Sel.Name
+	}
+	sig, ok := pass.TypesInfo.TypeOf(call.Fun).(*types.Signature)
+	if !ok || !sig.Variadic() || !strings.HasSuffix(name, "f") {
+		return false
+	}
+	params := sig.Params()
+	if params.Len() < 2 || params.At(params.Len()-2).Type() != types.Typ[types.String] {
+		return false
+	}
+	if elem, ok := params.At(params.Len() - 1).Type().(*types.Slice); !ok ||
+		!types.Identical(elem.Elem(), types.NewInterfaceType(nil, nil)) {
+		return false
+	}
+	for i := params.Len() - 1; i < len(call.Args); i += 1 {
+		if call.Args[i] == arg {
+			return true
+		}
+	}
+	return false
+}
+
+// Reports `string(x)` where `x` is a Uuid25 value.
+func checkStringConversion(pass *analysis.Pass, call *ast.CallExpr) {
+	if len(call.Args) != 1 {
+		return
+	}
+	tv, ok := pass.TypesInfo.Types[call.Fun]
+	if !ok || !tv.IsType() || tv.Type != types.Typ[types.String] {
+		return
+	}
+	arg := call.Args[0]
+	if !isUuid25Type(pass.TypesInfo.TypeOf(arg)) {
+		return
+	}
+
+	receiver := arg
+	switch arg.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.CallExpr, *ast.IndexExpr, *ast.ParenExpr:
+	default:
+		receiver = &ast.ParenExpr{X: arg}
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: "string conversion of uuid25.Uuid25 skips validation; use the String method",
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Replace with the String method",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     call.Pos(),
+				End:     call.End(),
+				NewText: []byte(render(pass.Fset, receiver) + ".String()"),
+			}},
+		}},
+	})
+}
+
+// Reports `x == lit` where `x` is a Uuid25 value and `lit` is a constant that
+// is not a canonical Uuid25 string.
+func checkComparison(pass *analysis.Pass, x ast.Expr, lit ast.Expr) {
+	if !isUuid25Type(pass.TypesInfo.TypeOf(x)) {
+		return
+	}
+	s, ok := constString(pass, lit)
+	if !ok || s == "" {
+		return
+	}
+	id, err := uuid25.Parse(s)
+	if err == nil && id.String() == s {
+		return
+	}
+
+	diagnostic := analysis.Diagnostic{
+		Pos:     lit.Pos(),
+		End:     lit.End(),
+		Message: "comparison of uuid25.Uuid25 with " + strconv.Quote(s) + " is always false",
+	}
+	if err == nil {
+		diagnostic.Message += "; use " + strconv.Quote(id.String())
+		if _, ok := ast.Unparen(lit).(*ast.BasicLit); ok {
+			diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+				Message: "Replace with the canonical Uuid25 string",
+				TextEdits: []analysis.TextEdit{{
+					Pos:     lit.Pos(),
+					End:     lit.End(),
+					NewText: []byte(strconv.Quote(id.String())),
+				}},
+			}}
+		}
+	}
+	pass.Report(diagnostic)
+}
+
+// Reports whether `t` is uuid25.Uuid25.
+func isUuid25Type(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	return ok && isUuid25Object(named.Obj(), "Uuid25")
+}
@@ -0,0 +1,32 @@
+package constcheck
+
+import (
+	"github.com/uuid25/go-uuid25"
+)
+
+const (
+	fixture = "dpoadk8izg9y4tte7vy1xt94o"
+	prefix  = "dpoadk8izg9y4tte7vy1xt94"
+)
+
+var (
+	a = uuid25.Const("dpoadk8izg9y4tte7vy1xt94o")
+	b = uuid25.Const(fixture)
+	c = uuid25.Uuid25("dpoadk8izg9y4tte7vy1xt94o")
+	d = uuid25.Uuid25("")
+
+	e = uuid25.Const("dpoadk8izg9y4tte7vy1xt94")             // want `invalid UUID in uuid25.Const argument: "dpoadk8izg9y4tte7vy1xt94"`
+	f = uuid25.Const("e7a1d63b-7117-4423-8988-afcf12161878") // want `non-canonical Uuid25 in uuid25.Const argument: "e7a1d63b-7117-4423-8988-afcf12161878" should be "dpoadk8izg9y4tte7vy1xt94o"`
+	g = uuid25.Uuid25("DPOADK8IZG9Y4TTE7VY1XT94O")           // want `non-canonical Uuid25 in uuid25.Uuid25 conversion`
+	h = uuid25.Uuid25("f5lxx1zz5pnorynqglhzmsp34")           // want `invalid UUID in uuid25.Uuid25 conversion`
+	i = uuid25.Const(prefix + "o")
+	j = uuid25.Const(prefix + "!")                               // want `invalid UUID`
+	k = uuid25.Const(string(c))                                  // want `uuid25.Const argument must be a constant`
+	l = (uuid25.Const)("{e7a1d63b-7117-4423-8988-afcf12161878}") // want `non-canonical Uuid25`
+	m = string("e7a1d63b-7117-4423-8988-afcf12161878")
+	n = uuid25.Uuid25(fixture)
+)
+
+type Other string
+
+var o = Other("foo")
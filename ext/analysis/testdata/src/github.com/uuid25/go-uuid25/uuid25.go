@@ -0,0 +1,10 @@
+// Stub of the core package for analyzer tests.
+package uuid25
+
+type Uuid25 string
+
+func Const(s string) Uuid25 { return Uuid25(s) }
+
+func Parse(s string) (Uuid25, error) { return Uuid25(s), nil }
+
+func (u Uuid25) String() string { return string(u) }
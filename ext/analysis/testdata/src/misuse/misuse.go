@@ -0,0 +1,37 @@
+package misuse
+
+import (
+	"fmt"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+type tb interface {
+	Errorf(format string, args ...any)
+}
+
+type record struct {
+	ID uuid25.Uuid25
+}
+
+func f(t tb, x uuid25.Uuid25, r record, ids []uuid25.Uuid25, s string) {
+	fmt.Println(string(x))         // want `string conversion of uuid25.Uuid25 skips validation`
+	fmt.Println(string(r.ID))      // want `string conversion`
+	fmt.Println(string(ids[0]))    // want `string conversion`
+	fmt.Println(string(x + "foo")) // want `string conversion`
+	fmt.Println(x.String(), string(s))
+	uuid25.Parse(string(x))
+	fmt.Printf("%q %s", string(x), s)
+	t.Errorf("invalid Uuid25 value %q", string(r.ID))
+	_ = fmt.Errorf("%w: %s", fmt.Errorf("foo"), string(x))
+	fmt.Sprint(string(x))    // want `string conversion`
+	fmt.Printf(string(x), 1) // want `string conversion`
+
+	_ = x == "dpoadk8izg9y4tte7vy1xt94o"
+	_ = x == uuid25.Uuid25(s)
+	_ = s == "e7a1d63b-7117-4423-8988-afcf12161878"
+	_ = x == "e7a1d63b-7117-4423-8988-afcf12161878" // want `comparison of uuid25.Uuid25 with "e7a1d63b-7117-4423-8988-afcf12161878" is always false; use "dpoadk8izg9y4tte7vy1xt94o"`
+	_ = "E7A1D63B711744238988AFCF12161878" != r.ID  // want `always false`
+	_ = x == "not a uuid"                           // want `comparison of uuid25.Uuid25 with "not a uuid" is always false$`
+	_ = x == ""
+}
@@ -0,0 +1,71 @@
+// Extension that decodes Uuid25 fields in configuration structs
+//
+// The functions in this package plug Uuid25 parsing into configuration
+// libraries without depending on them: the decode hook is a plain function
+// that mapstructure, and thus viper and koanf, accept as a `DecodeHookFunc`,
+// and the parser function fits the `FuncMap` of caarlos0/env. Configuration
+// values in any supported UUID format are normalized into the Uuid25 format.
+//
+// kelseyhightower/envconfig needs no registration because it decodes fields
+// through the encoding.TextUnmarshaler interface, which Uuid25 implements.
+package uuid25config
+
+import (
+	"reflect"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// The reflect.Type of uuid25.Uuid25, used as a key to register parser functions.
+var Uuid25Type = reflect.TypeOf(uuid25.Uuid25(""))
+
+// Returns a mapstructure decode hook that converts strings into Uuid25 values.
+//
+// The hook parses a string in any format accepted by uuid25.ParseInto() when
+// the target type is uuid25.Uuid25 and passes any other data through. Because
+// mapstructure applies hooks to every field it decodes, Uuid25 fields in nested
+// structs, pointers, slices, and maps are covered automatically. An empty
+// string is decoded as the zero value so that optional fields may be left
+// blank.
+//
+// Usage with viper:
+//
+//	err := v.Unmarshal(&cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+//		uuid25config.StringToUuid25HookFunc(),
+//		mapstructure.StringToTimeDurationHookFunc(),
+//	)))
+func StringToUuid25HookFunc() func(from reflect.Type, to reflect.Type, data any) (any, error) {
+	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		if from.Kind() != reflect.String || to != Uuid25Type {
+			return data, nil
+		}
+		s := reflect.ValueOf(data).String()
+		if s == "" {
+			return uuid25.Uuid25(""), nil
+		}
+		var id uuid25.Uuid25
+		if err := uuid25.ParseInto(&id, s); err != nil {
+			return nil, err
+		}
+		return id, nil
+	}
+}
+
+// Parses an environment variable value in any format accepted by
+// uuid25.ParseInto(), for use as a caarlos0/env `ParserFunc`.
+//
+// The library reports a returned error along with the variable name when
+// parsing the configuration, so invalid IDs are surfaced at startup:
+//
+//	err := env.ParseWithOptions(&cfg, env.Options{
+//		FuncMap: map[reflect.Type]env.ParserFunc{
+//			uuid25config.Uuid25Type: uuid25config.ParseEnv,
+//		},
+//	})
+func ParseEnv(value string) (any, error) {
+	var id uuid25.Uuid25
+	if err := uuid25.ParseInto(&id, value); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
@@ -0,0 +1,60 @@
+package uuid25config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests the mapstructure decode hook.
+func TestStringToUuid25HookFunc(t *testing.T) {
+	hook := StringToUuid25HookFunc()
+	stringType := reflect.TypeOf("")
+	idType := reflect.TypeOf(uuid25.Uuid25(""))
+
+	if v, err := hook(stringType, idType, "40eb9860-cf3e-45e2-a90e-b82236ac806c"); v != uuid25.Uuid25("3ud3gtvgolimgu9lah6aie99o") || err != nil {
+		t.Fail()
+	}
+	if v, err := hook(stringType, idType, "urn:uuid:40eb9860-cf3e-45e2-a90e-b82236ac806c\n"); v != uuid25.Uuid25("3ud3gtvgolimgu9lah6aie99o") || err != nil {
+		t.Fail()
+	}
+	if v, err := hook(stringType, idType, ""); v != uuid25.Uuid25("") || err != nil {
+		t.Fail()
+	}
+	if _, err := hook(stringType, idType, "40eb9860"); !errors.Is(err, uuid25.ErrParse) {
+		t.Fail()
+	}
+
+	// other data must pass through untouched
+	if v, err := hook(stringType, stringType, "40eb9860"); v != "40eb9860" || err != nil {
+		t.Fail()
+	}
+	if v, err := hook(reflect.TypeOf(42), idType, 42); v != 42 || err != nil {
+		t.Fail()
+	}
+	if v, err := hook(stringType, reflect.PtrTo(idType), "3ud3gtvgolimgu9lah6aie99o"); v != "3ud3gtvgolimgu9lah6aie99o" || err != nil {
+		t.Fail()
+	}
+}
+
+// Tests the environment variable parser function.
+func TestParseEnv(t *testing.T) {
+	if v, err := ParseEnv(" 40EB9860-CF3E-45E2-A90E-B82236AC806C "); v != uuid25.Uuid25("3ud3gtvgolimgu9lah6aie99o") || err != nil {
+		t.Fail()
+	}
+	if _, err := ParseEnv(""); !errors.Is(err, uuid25.ErrInvalidLength) {
+		t.Fail()
+	}
+	if _, err := ParseEnv("zzzzzzzzzzzzzzzzzzzzzzzzz"); !errors.Is(err, uuid25.ErrOverflow) {
+		t.Fail()
+	}
+
+	// ParseEnv must be convertible to the ParserFunc type of caarlos0/env
+	type parserFunc func(v string) (interface{}, error)
+	funcMap := map[reflect.Type]parserFunc{Uuid25Type: ParseEnv}
+	if v, err := funcMap[Uuid25Type]("3ud3gtvgolimgu9lah6aie99o"); v != uuid25.Uuid25("3ud3gtvgolimgu9lah6aie99o") || err != nil {
+		t.Fail()
+	}
+}
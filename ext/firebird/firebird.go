@@ -0,0 +1,68 @@
+// Extension that maps Uuid25 values to Firebird CHAR(16) OCTETS columns
+//
+// Legacy Firebird and InterBase schemas store UUIDs in `CHAR(16) CHARACTER SET
+// OCTETS` columns, which hold 16 raw bytes in the order produced by GEN_UUID()
+// and understood by UUID_TO_CHAR() and CHAR_TO_UUID() (the RFC 9562 order). This
+// package provides a wrapper type whose sql.Scanner and driver.Valuer
+// implementations read and write such values through database/sql drivers
+// such as firebirdsql. This package does not depend on any Firebird driver.
+//
+// Because OCTETS values are not text, they must not undergo character set
+// conversion. Value() therefore always binds a byte slice, which drivers
+// transmit as binary data, rather than a string, which they may transliterate
+// into the connection character set and corrupt. Scan() accepts the 16 raw
+// bytes in either a byte slice or a string, since some drivers return CHAR
+// columns as strings regardless of their character set.
+package uuid25firebird
+
+import (
+	"database/sql/driver"
+	"errors"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// A Uuid25 variant that is stored as CHAR(16) CHARACTER SET OCTETS.
+//
+// Convert between this type and Uuid25 with a plain type conversion:
+//
+//	var id uuid25.Uuid25
+//	err := row.Scan((*uuid25firebird.Octets)(&id))
+//	_, err = db.Exec("INSERT INTO t (id) VALUES (?)", uuid25firebird.Octets(id))
+type Octets uuid25.Uuid25
+
+// Implements the sql.Scanner interface.
+//
+// This method accepts the 16 raw bytes as a byte slice or a string, as well as
+// any string accepted by uuid25.Parse(), such as the output of UUID_TO_CHAR().
+func (o *Octets) Scan(src any) error {
+	if o == nil {
+		return errors.New("nil receiver")
+	}
+	switch src := src.(type) {
+	case []byte:
+		if len(src) == 16 {
+			*o = Octets(uuid25.FromBytes(src))
+			return nil
+		}
+		return (*uuid25.Uuid25)(o).Scan(src)
+	case string:
+		if len(src) == 16 {
+			*o = Octets(uuid25.FromBytes([]byte(src)))
+			return nil
+		}
+		return (*uuid25.Uuid25)(o).Scan(src)
+	default:
+		return uuid25.ErrUnsupportedType
+	}
+}
+
+// Implements the driver.Valuer interface, returning the 16 raw bytes as a byte
+// slice.
+func (o Octets) Value() (driver.Value, error) {
+	data, err := uuid25.Uuid25(o).MarshalWire()
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,46 @@
+package uuid25firebird
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+var (
+	_ sql.Scanner   = (*Octets)(nil)
+	_ driver.Valuer = Octets("")
+)
+
+// Tests the sql.Scanner and driver.Valuer implementations.
+func TestOctets(t *testing.T) {
+	id := uuid25.Uuid25("dpoadk8izg9y4tte7vy1xt94o")
+	b := id.ToBytes()
+
+	if v, err := Octets(id).Value(); err != nil || !bytes.Equal(v.([]byte), b[:]) {
+		t.Fail()
+	}
+	if _, err := Octets("").Value(); err == nil {
+		t.Fail()
+	}
+
+	for _, src := range []any{
+		b[:],
+		string(b[:]),
+		"E7A1D63B-7117-4423-8988-AFCF12161878",
+		[]byte("dpoadk8izg9y4tte7vy1xt94o"),
+	} {
+		var x Octets
+		if err := x.Scan(src); err != nil || x != Octets(id) {
+			t.Errorf("unexpected result for %v: %v", src, err)
+		}
+	}
+	for _, src := range []any{nil, 1, []byte{1, 2, 3}, "foo"} {
+		var x Octets
+		if x.Scan(src) == nil {
+			t.Errorf("%v must be rejected", src)
+		}
+	}
+}
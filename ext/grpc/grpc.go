@@ -0,0 +1,146 @@
+// Extension that propagates Uuid25 correlation IDs through gRPC metadata
+//
+// The interceptors in this package normalize whatever UUID format a peer sends
+// into the Uuid25 format and make the correlation ID available to handlers
+// through the context.
+//
+// This package is a separate module so that its google.golang.org/grpc
+// dependency does not apply to users of the core package.
+package uuid25grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/uuid25/go-uuid25"
+	"github.com/uuid25/go-uuid25/ext"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// The default metadata key carrying the correlation ID.
+const DefaultMetadataKey = "x-correlation-id"
+
+type contextKey struct{}
+
+// Returns a copy of `ctx` that carries a correlation ID.
+func NewContext(ctx context.Context, id uuid25.Uuid25) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// Returns the correlation ID carried by `ctx`, if any.
+func FromContext(ctx context.Context) (uuid25.Uuid25, bool) {
+	id, ok := ctx.Value(contextKey{}).(uuid25.Uuid25)
+	return id, ok
+}
+
+// An option to configure the interceptors.
+type Option func(*config)
+
+type config struct {
+	key      string
+	generate func() uuid25.Uuid25
+}
+
+// Sets the metadata key carrying the correlation ID. The key is lowercased as
+// required by gRPC.
+func WithMetadataKey(key string) Option {
+	return func(c *config) { c.key = strings.ToLower(key) }
+}
+
+// Sets the function generating a correlation ID when none is received. The
+// default is uuid25ext.NewV4().
+func WithGenerator(generate func() uuid25.Uuid25) Option {
+	return func(c *config) { c.generate = generate }
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{key: DefaultMetadataKey, generate: uuid25ext.NewV4}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Returns the correlation ID in `md` or generates a new one if `md` has no
+// valid ID.
+func (c *config) extract(md metadata.MD) uuid25.Uuid25 {
+	for _, e := range md.Get(c.key) {
+		if id, err := uuid25.Parse(e); err == nil {
+			return id
+		}
+	}
+	return c.generate()
+}
+
+// Returns a server-side context carrying the correlation ID received from the
+// client.
+func (c *config) serverContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return NewContext(ctx, c.extract(md))
+}
+
+// Returns a client-side context whose outgoing metadata carries the correlation
+// ID in the Uuid25 format.
+//
+// The ID is taken from, in order of precedence, the context value set by
+// NewContext(), the existing outgoing metadata, and the generator.
+func (c *config) clientContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	id, ok := FromContext(ctx)
+	if !ok {
+		id = c.extract(md)
+		ctx = NewContext(ctx, id)
+	}
+	md = md.Copy()
+	md.Set(c.key, id.String())
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// Returns a unary server interceptor that extracts the correlation ID from the
+// incoming metadata, generating one if absent or invalid, and stores it in the
+// context passed to the handler.
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	c := newConfig(opts)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(c.serverContext(ctx), req)
+	}
+}
+
+// Returns a stream server interceptor that extracts the correlation ID from the
+// incoming metadata, generating one if absent or invalid, and stores it in the
+// stream context.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	c := newConfig(opts)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ss, c.serverContext(ss.Context())})
+	}
+}
+
+// Returns a unary client interceptor that injects the correlation ID into the
+// outgoing metadata.
+func UnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
+	c := newConfig(opts)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		return invoker(c.clientContext(ctx), method, req, reply, cc, callOpts...)
+	}
+}
+
+// Returns a stream client interceptor that injects the correlation ID into the
+// outgoing metadata.
+func StreamClientInterceptor(opts ...Option) grpc.StreamClientInterceptor {
+	c := newConfig(opts)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(c.clientContext(ctx), desc, cc, method, callOpts...)
+	}
+}
+
+// A server stream wrapper that overrides the context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (ss *serverStream) Context() context.Context {
+	return ss.ctx
+}
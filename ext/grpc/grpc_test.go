@@ -0,0 +1,85 @@
+package uuid25grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Tests if the server interceptors normalize or generate correlation IDs.
+func TestServerInterceptors(t *testing.T) {
+	unary := UnaryServerInterceptor()
+	stream := StreamServerInterceptor(WithMetadataKey("X-Request-ID"))
+
+	cases := []struct {
+		md       metadata.MD
+		expected uuid25.Uuid25
+	}{
+		{metadata.Pairs(DefaultMetadataKey, "e7a1d63b-7117-4423-8988-afcf12161878"), "dpoadk8izg9y4tte7vy1xt94o"},
+		{metadata.Pairs(DefaultMetadataKey, "urn:uuid:e7a1d63b-7117-4423-8988-afcf12161878"), "dpoadk8izg9y4tte7vy1xt94o"},
+		{metadata.Pairs(DefaultMetadataKey, "foo"), ""},
+		{metadata.MD{}, ""},
+	}
+	for _, e := range cases {
+		ctx := metadata.NewIncomingContext(context.Background(), e.md)
+		var received uuid25.Uuid25
+		unary(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+			received, _ = FromContext(ctx)
+			return nil, nil
+		})
+		if (e.expected != "" && received != e.expected) || (e.expected == "" && len(received) != 25) {
+			t.Errorf("unexpected correlation ID: %q", received)
+		}
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "E7A1D63B711744238988AFCF12161878"))
+	stream(nil, &serverStream{nil, ctx}, &grpc.StreamServerInfo{}, func(srv any, ss grpc.ServerStream) error {
+		if id, _ := FromContext(ss.Context()); id != "dpoadk8izg9y4tte7vy1xt94o" {
+			t.Errorf("unexpected correlation ID: %q", id)
+		}
+		return nil
+	})
+}
+
+// Tests if the client interceptors inject correlation IDs.
+func TestClientInterceptors(t *testing.T) {
+	unary := UnaryClientInterceptor(WithGenerator(func() uuid25.Uuid25 { return "0000000000000000000000001" }))
+	stream := StreamClientInterceptor()
+
+	sent := func(ctx context.Context) string {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		if values := md.Get(DefaultMetadataKey); len(values) == 1 {
+			return values[0]
+		}
+		return ""
+	}
+	invoke := func(ctx context.Context) string {
+		var result string
+		unary(ctx, "/Svc/M", nil, nil, nil, func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			result = sent(ctx)
+			return nil
+		})
+		return result
+	}
+
+	if s := invoke(context.Background()); s != "0000000000000000000000001" {
+		t.Errorf("unexpected correlation ID: %q", s)
+	}
+	if s := invoke(NewContext(context.Background(), "dpoadk8izg9y4tte7vy1xt94o")); s != "dpoadk8izg9y4tte7vy1xt94o" {
+		t.Errorf("unexpected correlation ID: %q", s)
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), DefaultMetadataKey, "{e7a1d63b-7117-4423-8988-afcf12161878}")
+	if s := invoke(ctx); s != "dpoadk8izg9y4tte7vy1xt94o" {
+		t.Errorf("unexpected correlation ID: %q", s)
+	}
+
+	stream(context.Background(), &grpc.StreamDesc{}, nil, "/Svc/S", func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if s := sent(ctx); len(s) != 25 {
+			t.Errorf("unexpected correlation ID: %q", s)
+		}
+		return nil, nil
+	})
+}
@@ -0,0 +1,23 @@
+// Extension that exposes Uuid25 conversions to JavaScript in browser WASM
+//
+// This package registers functions that parse, format, and generate Uuid25
+// values on a JavaScript object through syscall/js, so a Go-WASM frontend and
+// its JavaScript code share the exact conversion logic of the backend. It is
+// available only when built with `GOOS=js GOARCH=wasm`:
+//
+//	func main() {
+//		uuid25js.RegisterGlobal("uuid25")
+//		select {} // keep the functions alive
+//	}
+//
+// The registered object provides the following functions, which return null
+// for invalid arguments instead of throwing:
+//
+//   - `parse(s)`: parses a string in any supported format into the Uuid25
+//     format
+//   - `format(s, name)`: parses a string and formats it in the format `name`,
+//     one of "uuid25", "hex", "hyphenated", "braced", and "urn"
+//   - `generate()`: returns a new random (version 4) UUID in the Uuid25 format
+//   - `fromBytes(bytes)`: converts a 16-byte Uint8Array into the Uuid25 format
+//   - `toBytes(s)`: parses a string into a 16-byte Uint8Array
+package uuid25js
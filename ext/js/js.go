@@ -0,0 +1,89 @@
+//go:build js && wasm
+
+package uuid25js
+
+import (
+	"syscall/js"
+
+	"github.com/uuid25/go-uuid25"
+	"github.com/uuid25/go-uuid25/ext"
+)
+
+// Sets the conversion functions as properties of a JavaScript object.
+func Register(target js.Value) {
+	target.Set("parse", js.FuncOf(parse))
+	target.Set("format", js.FuncOf(format))
+	target.Set("generate", js.FuncOf(generate))
+	target.Set("fromBytes", js.FuncOf(fromBytes))
+	target.Set("toBytes", js.FuncOf(toBytes))
+}
+
+// Creates a JavaScript object holding the conversion functions and assigns it
+// to a global variable.
+func RegisterGlobal(name string) {
+	target := js.Global().Get("Object").New()
+	Register(target)
+	js.Global().Set(name, target)
+}
+
+// Parses the first argument, which must be a string.
+func parseArg(args []js.Value) (uuid25.Uuid25, bool) {
+	if len(args) < 1 || args[0].Type() != js.TypeString {
+		return "", false
+	}
+	id, err := uuid25.Parse(args[0].String())
+	return id, err == nil
+}
+
+func parse(this js.Value, args []js.Value) any {
+	if id, ok := parseArg(args); ok {
+		return id.String()
+	}
+	return nil
+}
+
+func format(this js.Value, args []js.Value) any {
+	id, ok := parseArg(args)
+	if !ok || len(args) < 2 || args[1].Type() != js.TypeString {
+		return nil
+	}
+	switch args[1].String() {
+	case "uuid25":
+		return id.String()
+	case "hex":
+		return id.ToHex()
+	case "hyphenated":
+		return id.ToHyphenated()
+	case "braced":
+		return id.ToBraced()
+	case "urn":
+		return id.ToUrn()
+	default:
+		return nil
+	}
+}
+
+func generate(this js.Value, args []js.Value) any {
+	return uuid25ext.NewV4().String()
+}
+
+func fromBytes(this js.Value, args []js.Value) any {
+	if len(args) < 1 || !args[0].InstanceOf(js.Global().Get("Uint8Array")) ||
+		args[0].Length() != 16 {
+		return nil
+	}
+	var b [16]byte
+	js.CopyBytesToGo(b[:], args[0])
+	return uuid25.FromBytes(b[:]).String()
+}
+
+func toBytes(this js.Value, args []js.Value) any {
+	id, ok := parseArg(args)
+	if !ok {
+		return nil
+	}
+	b := id.ToBytes()
+	array := js.Global().Get("Uint8Array").New(16)
+	js.CopyBytesToJS(array, b[:])
+	return array
+}
@@ -0,0 +1,49 @@
+//go:build js && wasm
+
+package uuid25js
+
+import (
+	"syscall/js"
+	"testing"
+)
+
+// Tests the registered functions through JavaScript values.
+func TestRegister(t *testing.T) {
+	RegisterGlobal("uuid25Test")
+	obj := js.Global().Get("uuid25Test")
+
+	if v := obj.Call("parse", "40eb9860-cf3e-45e2-a90e-b82236ac806c"); v.String() != "3ud3gtvgolimgu9lah6aie99o" {
+		t.Fail()
+	}
+	if v := obj.Call("parse", "invalid"); !v.IsNull() {
+		t.Fail()
+	}
+	if v := obj.Call("parse", 42); !v.IsNull() {
+		t.Fail()
+	}
+
+	if v := obj.Call("format", "3ud3gtvgolimgu9lah6aie99o", "urn"); v.String() != "urn:uuid:40eb9860-cf3e-45e2-a90e-b82236ac806c" {
+		t.Fail()
+	}
+	if v := obj.Call("format", "3ud3gtvgolimgu9lah6aie99o", "hex"); v.String() != "40eb9860cf3e45e2a90eb82236ac806c" {
+		t.Fail()
+	}
+	if v := obj.Call("format", "3ud3gtvgolimgu9lah6aie99o", "unknown"); !v.IsNull() {
+		t.Fail()
+	}
+
+	if v := obj.Call("generate"); v.Type() != js.TypeString || len(v.String()) != 25 {
+		t.Fail()
+	}
+
+	bytes := obj.Call("toBytes", "3ud3gtvgolimgu9lah6aie99o")
+	if bytes.Length() != 16 || bytes.Index(0).Int() != 0x40 || bytes.Index(15).Int() != 0x6c {
+		t.Fail()
+	}
+	if v := obj.Call("fromBytes", bytes); v.String() != "3ud3gtvgolimgu9lah6aie99o" {
+		t.Fail()
+	}
+	if v := obj.Call("fromBytes", js.Global().Get("Uint8Array").New(15)); !v.IsNull() {
+		t.Fail()
+	}
+}
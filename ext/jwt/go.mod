@@ -0,0 +1,10 @@
+module github.com/uuid25/go-uuid25/ext/jwt
+
+go 1.19
+
+require (
+	github.com/golang-jwt/jwt/v5 v5.2.1
+	github.com/uuid25/go-uuid25 v0.4.0
+)
+
+require github.com/google/uuid v1.6.0 // indirect
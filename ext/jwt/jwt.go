@@ -0,0 +1,83 @@
+// Extension that integrates Uuid25 IDs with github.com/golang-jwt/jwt/v5
+//
+// The helpers in this package emit the `jti` claim and custom claims in the
+// Uuid25 format and validate them on parse, accepting any supported UUID format
+// but rejecting malformed values and the Nil UUID.
+//
+// This package is a separate module so that its golang-jwt/jwt dependency does
+// not apply to users of the core package.
+package uuid25jwt
+
+import (
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/uuid25/go-uuid25"
+	"github.com/uuid25/go-uuid25/ext"
+)
+
+// The registered claims whose `jti` holds a Uuid25 value.
+//
+// This type implements the jwt.ClaimsValidator interface, so jwt.Parse() and
+// jwt.ParseWithClaims() reject tokens whose `jti` is missing, malformed, or the
+// Nil UUID in addition to the standard validation. Embed this type in custom
+// claims structs to inherit the validation.
+type RegisteredClaims struct {
+	jwt.RegisteredClaims
+}
+
+// Creates registered claims with `jti` set to a Uuid25 value.
+func NewRegisteredClaims(id uuid25.Uuid25) RegisteredClaims {
+	return RegisteredClaims{jwt.RegisteredClaims{ID: id.String()}}
+}
+
+// Creates registered claims with `jti` set to a new random UUID.
+func NewRegisteredClaimsV4() RegisteredClaims {
+	return NewRegisteredClaims(uuid25ext.NewV4())
+}
+
+// Returns the `jti` claim as a Uuid25 value.
+func (claims RegisteredClaims) JTI() (uuid25.Uuid25, error) {
+	return ParseClaimValue("jti", claims.ID)
+}
+
+// Implements the jwt.ClaimsValidator interface.
+func (claims RegisteredClaims) Validate() error {
+	_, err := claims.JTI()
+	return err
+}
+
+// Returns a custom claim in jwt.MapClaims as a Uuid25 value.
+func ParseClaim(claims jwt.MapClaims, name string) (uuid25.Uuid25, error) {
+	value, ok := claims[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", jwt.ErrTokenRequiredClaimMissing, name)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("%w: %s is not a string", jwt.ErrTokenInvalidClaims, name)
+	}
+	return ParseClaimValue(name, s)
+}
+
+// Parses the value of a claim named `name` as a Uuid25 value.
+//
+// The returned error wraps jwt.ErrTokenRequiredClaimMissing if the value is
+// empty, jwt.ErrTokenInvalidId if the `jti` claim is invalid, or
+// jwt.ErrTokenInvalidClaims if another claim is invalid.
+func ParseClaimValue(name string, value string) (uuid25.Uuid25, error) {
+	if value == "" {
+		return "", fmt.Errorf("%w: %s", jwt.ErrTokenRequiredClaimMissing, name)
+	}
+	invalid := jwt.ErrTokenInvalidClaims
+	if name == "jti" {
+		invalid = jwt.ErrTokenInvalidId
+	}
+	id, err := uuid25.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s is not a valid UUID", invalid, name)
+	} else if id.IsNil() {
+		return "", fmt.Errorf("%w: %s is the Nil UUID", invalid, name)
+	}
+	return id, nil
+}
@@ -0,0 +1,71 @@
+package uuid25jwt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/uuid25/go-uuid25"
+)
+
+var key = []byte("secret")
+
+// Tests issuing and parsing tokens with a Uuid25 `jti`.
+func TestRegisteredClaims(t *testing.T) {
+	id, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	signed, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, NewRegisteredClaims(id)).SignedString(key)
+
+	var claims RegisteredClaims
+	if _, err := jwt.ParseWithClaims(signed, &claims, keyFunc); err != nil {
+		t.Fatal(err)
+	}
+	if x, err := claims.JTI(); x != id || err != nil {
+		t.Fail()
+	}
+	if len(NewRegisteredClaimsV4().ID) != 25 {
+		t.Fail()
+	}
+
+	cases := map[string]error{
+		"":                                     jwt.ErrTokenRequiredClaimMissing,
+		"foo":                                  jwt.ErrTokenInvalidId,
+		"0000000000000000000000000":            jwt.ErrTokenInvalidId,
+		"00000000-0000-0000-0000-000000000000": jwt.ErrTokenInvalidId,
+	}
+	for jti, expected := range cases {
+		signed, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{ID: jti}).SignedString(key)
+		var claims RegisteredClaims
+		if _, err := jwt.ParseWithClaims(signed, &claims, keyFunc); !errors.Is(err, expected) {
+			t.Errorf("unexpected error for jti %q: %v", jti, err)
+		}
+	}
+}
+
+// Tests parsing custom claims.
+func TestParseClaim(t *testing.T) {
+	claims := jwt.MapClaims{
+		"sid":  "{E7A1D63B-7117-4423-8988-AFCF12161878}",
+		"num":  42.0,
+		"bad":  "foo",
+		"null": "00000000000000000000000000000000",
+	}
+	if x, err := ParseClaim(claims, "sid"); x != "dpoadk8izg9y4tte7vy1xt94o" || err != nil {
+		t.Fail()
+	}
+	if _, err := ParseClaim(claims, "num"); !errors.Is(err, jwt.ErrTokenInvalidClaims) {
+		t.Fail()
+	}
+	if _, err := ParseClaim(claims, "bad"); !errors.Is(err, jwt.ErrTokenInvalidClaims) {
+		t.Fail()
+	}
+	if _, err := ParseClaim(claims, "null"); !errors.Is(err, jwt.ErrTokenInvalidClaims) {
+		t.Fail()
+	}
+	if _, err := ParseClaim(claims, "missing"); !errors.Is(err, jwt.ErrTokenRequiredClaimMissing) {
+		t.Fail()
+	}
+}
+
+func keyFunc(*jwt.Token) (any, error) {
+	return key, nil
+}
@@ -0,0 +1,83 @@
+// Extension that derives Kafka partition keys from Uuid25 values
+//
+// The helpers in this package produce message keys and partition numbers that
+// are stable across languages, so that events keyed by the same UUID land on
+// the same partition regardless of which client produced them. This package
+// does not depend on any Kafka client library.
+package uuid25kafka
+
+import (
+	"github.com/uuid25/go-uuid25"
+)
+
+// Returns the 16-byte binary representation of a Uuid25 value for use as a
+// message key.
+func Key(id uuid25.Uuid25) []byte {
+	b := id.ToBytes()
+	return b[:]
+}
+
+// Returns the 25-digit string representation of a Uuid25 value as a byte slice
+// for use as a message key.
+func StringKey(id uuid25.Uuid25) []byte {
+	return []byte(id.String())
+}
+
+// Returns the partition that the Java client's default partitioner assigns to
+// messages with the 16-byte key returned by Key().
+//
+// This function panics if `numPartitions` is not positive.
+func Partition(id uuid25.Uuid25, numPartitions int32) int32 {
+	return PartitionForKey(Key(id), numPartitions)
+}
+
+// Returns the partition that the Java client's default partitioner assigns to
+// messages with an arbitrary non-null key, i.e., `toPositive(murmur2(key)) %
+// numPartitions`.
+//
+// This function panics if `numPartitions` is not positive.
+func PartitionForKey(key []byte, numPartitions int32) int32 {
+	if numPartitions <= 0 {
+		panic("number of partitions must be positive")
+	}
+	return (Murmur2(key) & 0x7fffffff) % numPartitions
+}
+
+// Computes the 32-bit MurmurHash2 of `data` exactly as the Java client's
+// `Utils.murmur2()` does.
+func Murmur2(data []byte) int32 {
+	const seed uint32 = 0x9747b28c
+	const m uint32 = 0x5bd1e995
+	const r = 24
+
+	length := len(data)
+	h := seed ^ uint32(length)
+	length4 := length / 4
+	for i := 0; i < length4; i += 1 {
+		i4 := i * 4
+		k := uint32(data[i4]) | uint32(data[i4+1])<<8 | uint32(data[i4+2])<<16 | uint32(data[i4+3])<<24
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+	}
+
+	tail := data[length4*4:]
+	switch len(tail) {
+	case 3:
+		h ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(tail[0])
+		h *= m
+	}
+
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return int32(h)
+}
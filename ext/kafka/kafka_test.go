@@ -0,0 +1,45 @@
+package uuid25kafka
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests Murmur2() against the test vectors of the Java client.
+func TestMurmur2(t *testing.T) {
+	cases := map[string]int32{
+		"21":                         -973932308,
+		"foobar":                     -790332482,
+		"a-little-bit-long-string":   -985981536,
+		"a-little-bit-longer-string": -1486304829,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": -58897971,
+		"abc": 479470107,
+	}
+	for k, v := range cases {
+		if Murmur2([]byte(k)) != v {
+			t.Errorf("unexpected hash of %q: %d", k, Murmur2([]byte(k)))
+		}
+	}
+}
+
+// Tests key and partition derivation.
+func TestPartition(t *testing.T) {
+	id, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	if !bytes.Equal(Key(id), []byte{231, 161, 214, 59, 113, 23, 68, 35, 137, 136, 175, 207, 18, 22, 24, 120}) {
+		t.Fail()
+	}
+	if string(StringKey(id)) != "dpoadk8izg9y4tte7vy1xt94o" {
+		t.Fail()
+	}
+	for _, n := range []int32{1, 3, 12, 1000} {
+		p := Partition(id, n)
+		if p < 0 || p >= n || p != PartitionForKey(Key(id), n) {
+			t.Fail()
+		}
+	}
+	if PartitionForKey([]byte("foobar"), 7) != (-790332482&0x7fffffff)%7 {
+		t.Fail()
+	}
+}
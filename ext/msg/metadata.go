@@ -0,0 +1,110 @@
+package uuid25msg
+
+import "github.com/uuid25/go-uuid25"
+
+// The message ID, correlation ID, and causation ID of a message.
+//
+// The correlation ID is shared by all messages originating from the same
+// initial message, and the causation ID is the message ID of the message that
+// directly caused this one. Empty fields are not set.
+type Metadata struct {
+	MessageID     uuid25.Uuid25
+	CorrelationID uuid25.Uuid25
+	CausationID   uuid25.Uuid25
+}
+
+// Creates the metadata of an initial message that starts a new correlation
+// with its own message ID.
+func NewMetadata(messageID uuid25.Uuid25) Metadata {
+	return Metadata{MessageID: messageID, CorrelationID: messageID}
+}
+
+// Returns the metadata of a message with `messageID` caused by the message
+// described by `m`, inheriting its correlation ID.
+//
+// If `m` has no correlation ID, the message ID of `m` is used instead.
+func (m Metadata) Caused(messageID uuid25.Uuid25) Metadata {
+	correlationID := m.CorrelationID
+	if correlationID == "" {
+		correlationID = m.MessageID
+	}
+	return Metadata{MessageID: messageID, CorrelationID: correlationID, CausationID: m.MessageID}
+}
+
+// The header keys used to encode Metadata.
+type HeaderNames struct {
+	MessageID     string
+	CorrelationID string
+	CausationID   string
+}
+
+// The header keys for NATS, using NATSMsgIDHeader for the message ID.
+var NATSHeaderNames = HeaderNames{
+	MessageID:     NATSMsgIDHeader,
+	CorrelationID: "Correlation-Id",
+	CausationID:   "Causation-Id",
+}
+
+// The header keys for AMQP header tables.
+var AMQPHeaderNames = HeaderNames{
+	MessageID:     AMQPMessageIDHeader,
+	CorrelationID: "correlation-id",
+	CausationID:   "causation-id",
+}
+
+// Sets the non-empty fields of metadata in a NATS-style header map.
+func EncodeNATS[H ~map[string][]string](header H, names HeaderNames, m Metadata) {
+	m.each(names, func(key string, id uuid25.Uuid25) { SetNATS(header, key, id) })
+}
+
+// Gets metadata from a NATS-style header map.
+//
+// The message ID is required and this function returns ErrNoHeader if it is
+// not present, while the correlation ID and causation ID are left empty if
+// absent. A parse error is returned if any present value is not a valid UUID.
+func DecodeNATS[H ~map[string][]string](header H, names HeaderNames) (Metadata, error) {
+	return decode(names, func(key string) (uuid25.Uuid25, error) { return GetNATS(header, key) })
+}
+
+// Sets the non-empty fields of metadata in an AMQP-style header table.
+func EncodeAMQP[T ~map[string]any](table T, names HeaderNames, m Metadata) {
+	m.each(names, func(key string, id uuid25.Uuid25) { SetAMQP(table, key, id) })
+}
+
+// Gets metadata from an AMQP-style header table.
+//
+// The message ID is required and this function returns ErrNoHeader if it is
+// not present, while the correlation ID and causation ID are left empty if
+// absent. An error is returned if any present value is not a valid UUID.
+func DecodeAMQP[T ~map[string]any](table T, names HeaderNames) (Metadata, error) {
+	return decode(names, func(key string) (uuid25.Uuid25, error) { return GetAMQP(table, key) })
+}
+
+// Calls `fn` with the header key and value of each non-empty field.
+func (m Metadata) each(names HeaderNames, fn func(key string, id uuid25.Uuid25)) {
+	if m.MessageID != "" {
+		fn(names.MessageID, m.MessageID)
+	}
+	if m.CorrelationID != "" {
+		fn(names.CorrelationID, m.CorrelationID)
+	}
+	if m.CausationID != "" {
+		fn(names.CausationID, m.CausationID)
+	}
+}
+
+// Builds metadata from the header values returned by `get`.
+func decode(names HeaderNames, get func(key string) (uuid25.Uuid25, error)) (Metadata, error) {
+	var m Metadata
+	var err error
+	if m.MessageID, err = get(names.MessageID); err != nil {
+		return Metadata{}, err
+	}
+	if m.CorrelationID, err = get(names.CorrelationID); err != nil && err != ErrNoHeader {
+		return Metadata{}, err
+	}
+	if m.CausationID, err = get(names.CausationID); err != nil && err != ErrNoHeader {
+		return Metadata{}, err
+	}
+	return m, nil
+}
@@ -0,0 +1,81 @@
+package uuid25msg
+
+import (
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests that caused messages inherit the correlation ID.
+func TestMetadataCaused(t *testing.T) {
+	a, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	b, _ := uuid25.Parse("40eb9860-cf3e-45e2-a90e-b82236ac806c")
+	c, _ := uuid25.Parse("f38a6b1f-576f-4c22-8d4a-5f72613483f6")
+
+	root := NewMetadata(a)
+	if root != (Metadata{MessageID: a, CorrelationID: a}) {
+		t.Fail()
+	}
+	child := root.Caused(b)
+	if child != (Metadata{MessageID: b, CorrelationID: a, CausationID: a}) {
+		t.Fail()
+	}
+	if child.Caused(c) != (Metadata{MessageID: c, CorrelationID: a, CausationID: b}) {
+		t.Fail()
+	}
+	if (Metadata{MessageID: b}).Caused(c) != (Metadata{MessageID: c, CorrelationID: b, CausationID: b}) {
+		t.Fail()
+	}
+}
+
+// Tests encoding and decoding metadata in NATS-style headers.
+func TestMetadataNATS(t *testing.T) {
+	a, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	b, _ := uuid25.Parse("40eb9860-cf3e-45e2-a90e-b82236ac806c")
+	m := NewMetadata(a).Caused(b)
+
+	header := natsHeader{}
+	EncodeNATS(header, NATSHeaderNames, m)
+	if header["Nats-Msg-Id"][0] != b.String() || header["Correlation-Id"][0] != a.String() {
+		t.Fail()
+	}
+	if x, err := DecodeNATS(header, NATSHeaderNames); x != m || err != nil {
+		t.Fail()
+	}
+
+	header = natsHeader{}
+	EncodeNATS(header, NATSHeaderNames, Metadata{MessageID: a})
+	if len(header) != 1 {
+		t.Fail()
+	}
+	if x, err := DecodeNATS(header, NATSHeaderNames); x != (Metadata{MessageID: a}) || err != nil {
+		t.Fail()
+	}
+	if _, err := DecodeNATS(natsHeader{}, NATSHeaderNames); err != ErrNoHeader {
+		t.Fail()
+	}
+	header["Causation-Id"] = []string{"foo"}
+	if _, err := DecodeNATS(header, NATSHeaderNames); err == nil || err == ErrNoHeader {
+		t.Fail()
+	}
+}
+
+// Tests encoding and decoding metadata in AMQP-style tables.
+func TestMetadataAMQP(t *testing.T) {
+	a, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	b, _ := uuid25.Parse("40eb9860-cf3e-45e2-a90e-b82236ac806c")
+	m := NewMetadata(a).Caused(b)
+
+	table := amqpTable{}
+	EncodeAMQP(table, AMQPHeaderNames, m)
+	if table["message-id"] != b.String() || table["causation-id"] != a.String() {
+		t.Fail()
+	}
+	if x, err := DecodeAMQP(table, AMQPHeaderNames); x != m || err != nil {
+		t.Fail()
+	}
+	table["correlation-id"] = int64(1)
+	if _, err := DecodeAMQP(table, AMQPHeaderNames); err == nil {
+		t.Fail()
+	}
+}
@@ -0,0 +1,83 @@
+// Extension that propagates Uuid25 message IDs through message headers
+//
+// The helpers in this package set and get Uuid25 values in the header maps of
+// NATS (`nats.Header`, a `map[string][]string`) and AMQP 0-9-1 (`amqp.Table`,
+// a `map[string]any`) without depending on the client libraries. Values are
+// always written in the Uuid25 format and read from any supported format.
+//
+// Metadata ties together the message ID, correlation ID, and causation ID of a
+// message and is encoded into and decoded from the same header maps.
+package uuid25msg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// The header used by NATS JetStream for message deduplication.
+const NATSMsgIDHeader = "Nats-Msg-Id"
+
+// The default header carrying a message ID in AMQP header tables.
+const AMQPMessageIDHeader = "message-id"
+
+// An error returned when a header is not present.
+var ErrNoHeader = errors.New("header not found")
+
+// Sets a Uuid25 value in a NATS-style header map, replacing existing values.
+//
+// As with `nats.Header`, the key is case-sensitive.
+func SetNATS[H ~map[string][]string](header H, key string, id uuid25.Uuid25) {
+	header[key] = []string{id.String()}
+}
+
+// Gets a Uuid25 value from a NATS-style header map.
+//
+// This function returns ErrNoHeader if the header is not present and a parse
+// error if the first value is not a valid UUID.
+func GetNATS[H ~map[string][]string](header H, key string) (uuid25.Uuid25, error) {
+	values := header[key]
+	if len(values) == 0 {
+		return "", ErrNoHeader
+	}
+	return parseHeader(key, values[0])
+}
+
+// Sets a Uuid25 value in an AMQP-style header table as a string.
+func SetAMQP[T ~map[string]any](table T, key string, id uuid25.Uuid25) {
+	table[key] = id.String()
+}
+
+// Gets a Uuid25 value from an AMQP-style header table.
+//
+// The value may be a string, a byte slice holding a string representation, or a
+// 16-byte slice holding the binary representation. This function returns
+// ErrNoHeader if the header is not present and an error if the value is not a
+// valid UUID.
+func GetAMQP[T ~map[string]any](table T, key string) (uuid25.Uuid25, error) {
+	value, ok := table[key]
+	if !ok || value == nil {
+		return "", ErrNoHeader
+	}
+	switch value := value.(type) {
+	case string:
+		return parseHeader(key, value)
+	case []byte:
+		if len(value) == 16 {
+			return uuid25.FromBytes(value), nil
+		}
+		return parseHeader(key, string(value))
+	default:
+		return "", fmt.Errorf("header %q: unsupported type %T", key, value)
+	}
+}
+
+// Parses a header value, annotating errors with the header key.
+func parseHeader(key string, value string) (uuid25.Uuid25, error) {
+	id, err := uuid25.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("header %q: %w", key, err)
+	}
+	return id, nil
+}
@@ -0,0 +1,70 @@
+package uuid25msg
+
+import (
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+type natsHeader map[string][]string
+
+type amqpTable map[string]any
+
+// Tests setting and getting IDs in NATS-style headers.
+func TestNATS(t *testing.T) {
+	id, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	header := natsHeader{}
+	SetNATS(header, NATSMsgIDHeader, id)
+	if header[NATSMsgIDHeader][0] != "dpoadk8izg9y4tte7vy1xt94o" {
+		t.Fail()
+	}
+	if x, err := GetNATS(header, NATSMsgIDHeader); x != id || err != nil {
+		t.Fail()
+	}
+
+	header["X-Other"] = []string{"{E7A1D63B-7117-4423-8988-AFCF12161878}"}
+	if x, err := GetNATS(header, "X-Other"); x != id || err != nil {
+		t.Fail()
+	}
+	if _, err := GetNATS(header, "x-other"); err != ErrNoHeader {
+		t.Fail()
+	}
+	header["X-Bad"] = []string{"foo"}
+	if _, err := GetNATS(header, "X-Bad"); err == nil || err == ErrNoHeader {
+		t.Fail()
+	}
+}
+
+// Tests setting and getting IDs in AMQP-style tables.
+func TestAMQP(t *testing.T) {
+	id, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	table := amqpTable{}
+	SetAMQP(table, AMQPMessageIDHeader, id)
+	if table[AMQPMessageIDHeader] != "dpoadk8izg9y4tte7vy1xt94o" {
+		t.Fail()
+	}
+	if x, err := GetAMQP(table, AMQPMessageIDHeader); x != id || err != nil {
+		t.Fail()
+	}
+
+	bs := id.ToBytes()
+	table["binary"] = bs[:]
+	table["text"] = []byte("e7a1d63b711744238988afcf12161878")
+	table["number"] = int64(42)
+	table["bad"] = "foo"
+	if x, err := GetAMQP(table, "binary"); x != id || err != nil {
+		t.Fail()
+	}
+	if x, err := GetAMQP(table, "text"); x != id || err != nil {
+		t.Fail()
+	}
+	if _, err := GetAMQP(table, "number"); err == nil {
+		t.Fail()
+	}
+	if _, err := GetAMQP(table, "bad"); err == nil {
+		t.Fail()
+	}
+	if _, err := GetAMQP(table, "missing"); err != ErrNoHeader {
+		t.Fail()
+	}
+}
@@ -0,0 +1,91 @@
+// Extension that maps Uuid25 values to SQL Server UNIQUEIDENTIFIER columns
+//
+// SQL Server stores and transmits UNIQUEIDENTIFIER values in a mixed-endian
+// byte order inherited from Microsoft GUIDs: the first three groups of the
+// hyphenated form (4, 2, and 2 bytes) are little-endian, while the last eight
+// bytes are in the RFC 9562 order. Drivers such as go-mssqldb return the raw
+// bytes in this order when scanning into a byte slice, which silently garbles
+// UUIDs if the bytes are used as is. This package provides a wrapper type whose
+// sql.Scanner and driver.Valuer implementations perform the byte swapping, so
+// that values round-trip between SQL Server and Uuid25 and are displayed by SQL
+// Server in the same hyphenated form as ToHyphenated(). This package does not
+// depend on any SQL Server driver.
+package uuid25mssql
+
+import (
+	"database/sql/driver"
+	"errors"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// A Uuid25 variant that is stored as UNIQUEIDENTIFIER.
+//
+// Convert between this type and Uuid25 with a plain type conversion:
+//
+//	var id uuid25.Uuid25
+//	err := row.Scan((*uuid25mssql.UniqueIdentifier)(&id))
+//	_, err = db.Exec("INSERT INTO t (id) VALUES (@p1)", uuid25mssql.UniqueIdentifier(id))
+type UniqueIdentifier uuid25.Uuid25
+
+// Implements the sql.Scanner interface.
+//
+// This method accepts a 16-byte slice in the SQL Server byte order, as returned
+// for UNIQUEIDENTIFIER columns, and any string accepted by uuid25.Parse(), such
+// as the output of `CONVERT(char(36), id)`.
+func (u *UniqueIdentifier) Scan(src any) error {
+	if u == nil {
+		return errors.New("nil receiver")
+	}
+	switch src := src.(type) {
+	case []byte:
+		if len(src) == 16 {
+			*u = UniqueIdentifier(FromWireBytes(src))
+			return nil
+		}
+		return (*uuid25.Uuid25)(u).Scan(src)
+	case string:
+		return (*uuid25.Uuid25)(u).Scan(src)
+	default:
+		return uuid25.ErrUnsupportedType
+	}
+}
+
+// Implements the driver.Valuer interface, returning the 16 bytes in the SQL
+// Server byte order.
+func (u UniqueIdentifier) Value() (driver.Value, error) {
+	if _, err := uuid25.Uuid25(u).MarshalText(); err != nil {
+		return nil, err
+	}
+	b := ToWireBytes(uuid25.Uuid25(u))
+	return b[:], nil
+}
+
+// Returns the 16-byte representation of a Uuid25 value in the SQL Server byte
+// order.
+func ToWireBytes(id uuid25.Uuid25) [16]byte {
+	b := id.ToBytes()
+	swap(&b)
+	return b
+}
+
+// Creates a Uuid25 value from 16 bytes in the SQL Server byte order.
+//
+// This function panics if the length of the byte slice is not 16.
+func FromWireBytes(b []byte) uuid25.Uuid25 {
+	if len(b) != 16 {
+		panic("the length of byte slice must be 16")
+	}
+	var x [16]byte
+	copy(x[:], b)
+	swap(&x)
+	return uuid25.FromBytes(x[:])
+}
+
+// Converts between the RFC 9562 and SQL Server byte orders, reversing the first
+// three groups in place. The conversion is its own inverse.
+func swap(b *[16]byte) {
+	b[0], b[1], b[2], b[3] = b[3], b[2], b[1], b[0]
+	b[4], b[5] = b[5], b[4]
+	b[6], b[7] = b[7], b[6]
+}
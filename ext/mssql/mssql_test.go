@@ -0,0 +1,61 @@
+package uuid25mssql
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+var (
+	_ sql.Scanner   = (*UniqueIdentifier)(nil)
+	_ driver.Valuer = UniqueIdentifier("")
+)
+
+// The bytes of e7a1d63b-7117-4423-8988-afcf12161878 in the SQL Server byte
+// order.
+var wire = []byte{
+	0x3b, 0xd6, 0xa1, 0xe7, 0x17, 0x71, 0x23, 0x44,
+	0x89, 0x88, 0xaf, 0xcf, 0x12, 0x16, 0x18, 0x78,
+}
+
+// Tests the conversion from/to the SQL Server byte order.
+func TestWireBytes(t *testing.T) {
+	id := uuid25.Uuid25("dpoadk8izg9y4tte7vy1xt94o")
+	if b := ToWireBytes(id); !bytes.Equal(b[:], wire) {
+		t.Fail()
+	}
+	if FromWireBytes(wire) != id {
+		t.Fail()
+	}
+}
+
+// Tests the sql.Scanner and driver.Valuer implementations.
+func TestScannerValuer(t *testing.T) {
+	id := uuid25.Uuid25("dpoadk8izg9y4tte7vy1xt94o")
+	if v, err := UniqueIdentifier(id).Value(); err != nil || !bytes.Equal(v.([]byte), wire) {
+		t.Fail()
+	}
+	if _, err := UniqueIdentifier("").Value(); err == nil {
+		t.Fail()
+	}
+
+	for _, src := range []any{
+		wire,
+		"E7A1D63B-7117-4423-8988-AFCF12161878",
+		[]byte("dpoadk8izg9y4tte7vy1xt94o"),
+	} {
+		var x UniqueIdentifier
+		if err := x.Scan(src); err != nil || x != UniqueIdentifier(id) {
+			t.Errorf("unexpected result for %v: %v", src, err)
+		}
+	}
+	for _, src := range []any{nil, 1, []byte{1, 2, 3}, "foo"} {
+		var x UniqueIdentifier
+		if x.Scan(src) == nil {
+			t.Errorf("%v must be rejected", src)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Extension that maps Uuid25 values to Oracle RAW(16) columns
+//
+// Oracle has no UUID type; UUIDs are conventionally stored in RAW(16) columns,
+// generated by SYS_GUID(), and displayed or exchanged as 32-digit uppercase
+// hexadecimal strings by RAWTOHEX() and most tools. This package provides a
+// wrapper type whose sql.Scanner and driver.Valuer implementations read and
+// write RAW(16) values through database/sql drivers such as godror, along with
+// conversions from and to the uppercase hexadecimal form. This package does not
+// depend on any Oracle driver.
+//
+// RAW(16) values are stored in the byte order of RFC 9562, which is also the
+// order of the hexadecimal form, so a value inserted through this package is
+// displayed by RAWTOHEX() exactly as ToHex() formats it, only in uppercase.
+package uuid25oracle
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// A Uuid25 variant that is stored as RAW(16).
+//
+// Convert between this type and Uuid25 with a plain type conversion:
+//
+//	var id uuid25.Uuid25
+//	err := row.Scan((*uuid25oracle.Raw)(&id))
+//	_, err = db.Exec("INSERT INTO t (id) VALUES (:1)", uuid25oracle.Raw(id))
+type Raw uuid25.Uuid25
+
+// Implements the sql.Scanner interface.
+//
+// This method accepts a 16-byte slice, as returned for RAW(16) columns, and any
+// string accepted by uuid25.Parse(), which includes the 32-digit uppercase
+// hexadecimal form returned by RAWTOHEX() and by drivers configured to fetch
+// RAW as strings.
+func (raw *Raw) Scan(src any) error {
+	if raw == nil {
+		return errors.New("nil receiver")
+	}
+	switch src := src.(type) {
+	case []byte:
+		if len(src) == 16 {
+			*raw = Raw(uuid25.FromBytes(src))
+			return nil
+		}
+		return (*uuid25.Uuid25)(raw).Scan(src)
+	case string:
+		return (*uuid25.Uuid25)(raw).Scan(src)
+	default:
+		return uuid25.ErrUnsupportedType
+	}
+}
+
+// Implements the driver.Valuer interface, returning the 16-byte binary
+// representation to be bound to a RAW(16) parameter.
+func (raw Raw) Value() (driver.Value, error) {
+	data, err := uuid25.Uuid25(raw).MarshalWire()
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Returns the 32-digit uppercase hexadecimal form used by SYS_GUID() and
+// RAWTOHEX(): `40EB9860CF3E45E2A90EB82236AC806C`.
+//
+// The result can be compared with the output of RAWTOHEX() or embedded in
+// HEXTORAW() literals.
+func ToSysGUID(id uuid25.Uuid25) string {
+	return strings.ToUpper(id.ToHex())
+}
+
+// Creates a Uuid25 value from the 32-digit hexadecimal form of a RAW(16) value,
+// such as the output of SYS_GUID() or RAWTOHEX(), in either case.
+func FromSysGUID(s string) (uuid25.Uuid25, error) {
+	return uuid25.ParseHex(s)
+}
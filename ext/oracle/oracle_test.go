@@ -0,0 +1,61 @@
+package uuid25oracle
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+var (
+	_ sql.Scanner   = (*Raw)(nil)
+	_ driver.Valuer = Raw("")
+)
+
+// Tests the conversion from/to RAW(16) values.
+func TestRaw(t *testing.T) {
+	id := uuid25.Uuid25("dpoadk8izg9y4tte7vy1xt94o")
+	b := id.ToBytes()
+
+	v, err := Raw(id).Value()
+	if data, ok := v.([]byte); err != nil || !ok || !bytes.Equal(data, b[:]) {
+		t.Fail()
+	}
+	if _, err := Raw("").Value(); err == nil {
+		t.Fail()
+	}
+
+	for _, src := range []any{
+		b[:],
+		"E7A1D63B711744238988AFCF12161878",
+		"e7a1d63b-7117-4423-8988-afcf12161878",
+		[]byte("dpoadk8izg9y4tte7vy1xt94o"),
+	} {
+		var x Raw
+		if err := x.Scan(src); err != nil || x != Raw(id) {
+			t.Errorf("unexpected result for %v: %v", src, err)
+		}
+	}
+	for _, src := range []any{nil, 1, []byte{1, 2, 3}, "foo"} {
+		var x Raw
+		if x.Scan(src) == nil {
+			t.Errorf("%v must be rejected", src)
+		}
+	}
+}
+
+// Tests the conversion from/to the SYS_GUID() form.
+func TestSysGUID(t *testing.T) {
+	id := uuid25.Uuid25("dpoadk8izg9y4tte7vy1xt94o")
+	if ToSysGUID(id) != "E7A1D63B711744238988AFCF12161878" {
+		t.Fail()
+	}
+	if x, err := FromSysGUID("E7A1D63B711744238988AFCF12161878"); err != nil || x != id {
+		t.Fail()
+	}
+	if _, err := FromSysGUID("e7a1d63b-7117-4423-8988-afcf12161878"); err == nil {
+		t.Fail()
+	}
+}
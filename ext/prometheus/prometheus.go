@@ -0,0 +1,65 @@
+// Extension that derives Prometheus label values and exemplars from Uuid25
+// values
+//
+// Putting raw IDs into metric labels creates a new time series for every ID
+// and soon exhausts the memory of the monitoring system. The helpers in this
+// package instead map IDs into a bounded number of buckets for labels and
+// attach full IDs to observations as exemplars, which are stored per sample
+// rather than per series:
+//
+//	requests.WithLabelValues(uuid25prom.BucketLabel(tenant, 1)).Inc()
+//	latency.(prometheus.ExemplarObserver).ObserveWithExemplar(
+//		elapsed.Seconds(), uuid25prom.Exemplar("request_id", requestID))
+//
+// The returned maps are assignable to prometheus.Labels. This package does not
+// depend on the Prometheus client library.
+package uuid25prom
+
+import (
+	"strings"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Returns a label value of `2*depth` lowercase hexadecimal digits derived from
+// a hash of an ID, so that the label has at most `256^depth` distinct values.
+//
+// The value is uuid25.FanoutPrefix() without slashes, which is uniformly
+// distributed even over UUIDv7 values sharing the same timestamp and is
+// reproducible in other languages. A depth of 1 yields 256 buckets, which suits
+// most labels; a depth of 2 yields 65536 buckets and is rarely appropriate.
+// This function panics if `depth` is not between 1 and 8.
+func BucketLabel(id uuid25.Uuid25, depth int) string {
+	if depth < 1 {
+		panic("depth out of range")
+	}
+	return strings.ReplaceAll(uuid25.FanoutPrefix(id, depth), "/", "")
+}
+
+// Returns the exemplar labels carrying the full ID under the label name
+// `name`, such as "request_id" or "trace_id".
+//
+// The ID is represented in the 25-digit Uuid25 format, which leaves enough room
+// within the 128-character limit that Prometheus imposes on the combined
+// exemplar labels. This function panics if `name` is not a valid label name.
+func Exemplar(name string, id uuid25.Uuid25) map[string]string {
+	if !validLabelName(name) {
+		panic("invalid label name: " + name)
+	}
+	return map[string]string{name: id.String()}
+}
+
+// Reports whether `name` matches the Prometheus label name syntax
+// `[a-zA-Z_][a-zA-Z0-9_]*` and is not reserved for internal use.
+func validLabelName(name string) bool {
+	if name == "" || strings.HasPrefix(name, "__") {
+		return false
+	}
+	for i := 0; i < len(name); i += 1 {
+		c := name[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || i > 0 && c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
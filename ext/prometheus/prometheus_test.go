@@ -0,0 +1,58 @@
+package uuid25prom
+
+import (
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests that BucketLabel() has bounded cardinality.
+func TestBucketLabel(t *testing.T) {
+	id := uuid25.MustParse("e7a1d63b-7117-4423-8988-afcf12161878")
+	if BucketLabel(id, 1) != "a2" || BucketLabel(id, 2) != "a272" {
+		t.Errorf("unexpected labels: %s %s", BucketLabel(id, 1), BucketLabel(id, 2))
+	}
+
+	seen := map[string]bool{}
+	for i := 0; i < 4096; i += 1 {
+		label := BucketLabel(uuid25.NewV7(), 1)
+		if len(label) != 2 {
+			t.Fail()
+		}
+		seen[label] = true
+	}
+	if len(seen) > 256 || len(seen) < 200 {
+		t.Errorf("unexpected number of buckets: %d", len(seen))
+	}
+
+	for _, depth := range []int{0, 9} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fail()
+				}
+			}()
+			BucketLabel(id, depth)
+		}()
+	}
+}
+
+// Tests the exemplar labels and label name validation.
+func TestExemplar(t *testing.T) {
+	id := uuid25.MustParse("e7a1d63b-7117-4423-8988-afcf12161878")
+	labels := Exemplar("request_id", id)
+	if len(labels) != 1 || labels["request_id"] != "dpoadk8izg9y4tte7vy1xt94o" {
+		t.Fail()
+	}
+
+	for _, name := range []string{"", "__name__", "0id", "request-id", "idé"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Exemplar(%q) must panic", name)
+				}
+			}()
+			Exemplar(name, id)
+		}()
+	}
+}
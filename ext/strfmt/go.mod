@@ -0,0 +1,16 @@
+module github.com/uuid25/go-uuid25/ext/strfmt
+
+go 1.19
+
+require (
+	github.com/go-openapi/strfmt v0.21.7
+	github.com/uuid25/go-uuid25 v0.4.0
+)
+
+require (
+	github.com/asaskevich/govalidator v0.0.0-20230301143203-a9d515a09cc2 // indirect
+	github.com/go-openapi/errors v0.20.3 // indirect
+	github.com/mitchellh/mapstructure v1.5.0 // indirect
+	github.com/oklog/ulid v1.3.1 // indirect
+	go.mongodb.org/mongo-driver v1.11.3 // indirect
+)
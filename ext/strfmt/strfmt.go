@@ -0,0 +1,55 @@
+// Extension that registers the Uuid25 string format with go-openapi/strfmt
+//
+// Importing this package registers a `uuid25` string format with
+// strfmt.Default, so that servers and clients generated by go-swagger validate
+// `format: uuid25` properties, and such properties can be mapped to the
+// uuid25.Uuid25 type, which already implements the text marshalers, JSON
+// encoding, and database/sql support expected from strfmt types:
+//
+//	import _ "github.com/uuid25/go-uuid25/ext/strfmt"
+//
+// In a Swagger or OpenAPI 2.0 specification, declare the type as follows:
+//
+//	type: string
+//	format: uuid25
+//	x-go-type:
+//	  type: Uuid25
+//	  import:
+//	    package: github.com/uuid25/go-uuid25
+//
+// Note that strfmt.Registry.MapStructureHookFunc() does not support formats
+// registered by third parties; use uuid25config.StringToUuid25HookFunc() to
+// decode such values with mapstructure.
+//
+// This package is a separate module so that the dependencies of
+// go-openapi/strfmt do not apply to users of the core package.
+package uuid25strfmt
+
+import (
+	"github.com/go-openapi/strfmt"
+	"github.com/uuid25/go-uuid25"
+)
+
+// The name of the string format registered by this package.
+const FormatName = "uuid25"
+
+func init() {
+	Register(strfmt.Default)
+}
+
+// Registers the Uuid25 string format with `registry` under FormatName.
+//
+// This function is called for strfmt.Default when this package is imported and
+// needs to be called explicitly only for custom registries.
+func Register(registry strfmt.Registry) {
+	registry.Add(FormatName, new(uuid25.Uuid25), IsUuid25)
+}
+
+// Reports whether a string is in the 25-digit Base36 Uuid25 format.
+//
+// This function is the validator of the registered format. Like ParseUuid25(),
+// it accepts uppercase letters.
+func IsUuid25(s string) bool {
+	_, err := uuid25.ParseUuid25(s)
+	return err == nil
+}
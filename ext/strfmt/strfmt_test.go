@@ -0,0 +1,37 @@
+package uuid25strfmt
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests the registration with the default registry.
+func TestRegister(t *testing.T) {
+	if !strfmt.Default.ContainsName(FormatName) {
+		t.Fatal("format must be registered on import")
+	}
+	if tpe, ok := strfmt.Default.GetType(FormatName); !ok || tpe != reflect.TypeOf(uuid25.Uuid25("")) {
+		t.Fail()
+	}
+
+	if !strfmt.Default.Validates(FormatName, "dpoadk8izg9y4tte7vy1xt94o") ||
+		!strfmt.Default.Validates(FormatName, "DPOADK8IZG9Y4TTE7VY1XT94O") ||
+		strfmt.Default.Validates(FormatName, "e7a1d63b-7117-4423-8988-afcf12161878") ||
+		strfmt.Default.Validates(FormatName, "f5lxx1zz5pnorynqglhzmsp34") {
+		t.Fail()
+	}
+
+	v, err := strfmt.Default.Parse(FormatName, "DPOADK8IZG9Y4TTE7VY1XT94O")
+	if x, ok := v.(*uuid25.Uuid25); err != nil || !ok || *x != "dpoadk8izg9y4tte7vy1xt94o" {
+		t.Fail()
+	}
+
+	registry := strfmt.NewFormats()
+	Register(registry)
+	if !registry.Validates(FormatName, "dpoadk8izg9y4tte7vy1xt94o") {
+		t.Fail()
+	}
+}
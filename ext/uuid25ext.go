@@ -26,6 +26,11 @@ func NewV4() uuid25.Uuid25 {
 	return FromUUID(uuid.New())
 }
 
+// Generates a time-ordered UUID (UUIDv7) value encoded in the Uuid25 format.
+func NewV7() uuid25.Uuid25 {
+	return FromUUID(uuid.Must(uuid.NewV7()))
+}
+
 // Equivalent to [uuid25.FromBytes], re-exported for convenience.
 func FromBytes(uuidBytes []byte) uuid25.Uuid25 {
 	return uuid25.FromBytes(uuidBytes)
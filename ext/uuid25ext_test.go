@@ -19,6 +19,21 @@ func TestFromToUUID(t *testing.T) {
 	}
 }
 
+// Tests generation of time-ordered UUIDs.
+func TestNewV7(t *testing.T) {
+	prev := NewV7()
+	for i := 0; i < 100; i += 1 {
+		x := NewV7()
+		if b := x.ToBytes(); b[6]>>4 != 7 || b[8]>>6 != 2 {
+			t.Fail()
+		}
+		if x <= prev {
+			t.Fail()
+		}
+		prev = x
+	}
+}
+
 var testCases = []struct {
 	uuid25     string
 	hex        string
@@ -0,0 +1,62 @@
+// Extension that converts Uuid25 values for ASN.1 and X.509 tooling
+//
+// The helpers in this package encode a Uuid25 value as a DER ASN.1 OCTET STRING
+// and convert it from/to a positive integer usable as the serial number of an
+// X.509 certificate.
+package uuid25x509
+
+import (
+	"encoding/asn1"
+	"errors"
+	"math/big"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Encodes a Uuid25 value as a DER ASN.1 OCTET STRING holding the 16-byte
+// binary representation.
+func MarshalASN1(id uuid25.Uuid25) ([]byte, error) {
+	b := id.ToBytes()
+	return asn1.Marshal(b[:])
+}
+
+// Decodes a Uuid25 value from a DER ASN.1 OCTET STRING holding the 16-byte
+// binary representation.
+func UnmarshalASN1(der []byte) (uuid25.Uuid25, error) {
+	var b []byte
+	rest, err := asn1.Unmarshal(der, &b)
+	if err != nil {
+		return "", err
+	} else if len(rest) > 0 {
+		return "", errors.New("trailing data after ASN.1 OCTET STRING")
+	} else if len(b) != 16 {
+		return "", errors.New("ASN.1 OCTET STRING is not 16 bytes long")
+	}
+	return uuid25.FromBytes(b), nil
+}
+
+// Returns the 128-bit value of a Uuid25 as a positive integer suitable for
+// `x509.Certificate.SerialNumber`.
+//
+// This function returns an error for the Nil UUID because RFC 5280 requires
+// serial numbers to be positive. The DER encoding of the result never exceeds
+// the 20-octet limit of RFC 5280.
+func SerialNumber(id uuid25.Uuid25) (*big.Int, error) {
+	b := id.ToBytes()
+	n := new(big.Int).SetBytes(b[:])
+	if n.Sign() == 0 {
+		return nil, errors.New("Nil UUID cannot be used as serial number")
+	}
+	return n, nil
+}
+
+// Converts a certificate serial number created by SerialNumber() back into a
+// Uuid25 value.
+func FromSerialNumber(serial *big.Int) (uuid25.Uuid25, error) {
+	if serial == nil || serial.Sign() <= 0 || serial.BitLen() > 128 {
+		return "", errors.New("serial number out of UUID range")
+	}
+	var b [16]byte
+	serial.FillBytes(b[:])
+	return uuid25.FromBytes(b[:]), nil
+}
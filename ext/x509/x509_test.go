@@ -0,0 +1,57 @@
+package uuid25x509
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests conversion from/to ASN.1 OCTET STRING.
+func TestASN1(t *testing.T) {
+	id, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	der, err := MarshalASN1(id)
+	expected := []byte{0x04, 0x10, 231, 161, 214, 59, 113, 23, 68, 35, 137, 136, 175, 207, 18, 22, 24, 120}
+	if !bytes.Equal(der, expected) || err != nil {
+		t.Fail()
+	}
+	if x, err := UnmarshalASN1(der); x != id || err != nil {
+		t.Fail()
+	}
+
+	if _, err := UnmarshalASN1(append(der, 0)); err == nil {
+		t.Fail()
+	}
+	if _, err := UnmarshalASN1([]byte{0x04, 0x02, 0, 0}); err == nil {
+		t.Fail()
+	}
+	if _, err := UnmarshalASN1([]byte{0x02, 0x01, 1}); err == nil {
+		t.Fail()
+	}
+}
+
+// Tests conversion from/to certificate serial numbers.
+func TestSerialNumber(t *testing.T) {
+	id, _ := uuid25.Parse("ffffffff-ffff-ffff-ffff-ffffffffffff")
+	n, err := SerialNumber(id)
+	if err != nil || n.Sign() <= 0 || n.BitLen() != 128 {
+		t.Fail()
+	}
+	if x, err := FromSerialNumber(n); x != id || err != nil {
+		t.Fail()
+	}
+
+	if _, err := SerialNumber("0000000000000000000000000"); err == nil {
+		t.Fail()
+	}
+	if x, err := FromSerialNumber(big.NewInt(1)); x != "0000000000000000000000001" || err != nil {
+		t.Fail()
+	}
+	if _, err := FromSerialNumber(new(big.Int).Lsh(big.NewInt(1), 128)); err == nil {
+		t.Fail()
+	}
+	if _, err := FromSerialNumber(big.NewInt(-1)); err == nil {
+		t.Fail()
+	}
+}
@@ -0,0 +1,33 @@
+package uuid25
+
+// Returns a fan-out prefix of `depth` levels of two lowercase hexadecimal
+// digits separated by slashes, like `a2/72`, for sharding directories or
+// buckets by ID.
+//
+// The prefix is derived from Uint128.Hash64() of the 128-bit value, and the
+// levels are the bytes of the hash from the most significant one. The prefix
+// is therefore stable across versions of this package and reproducible in
+// other languages. Because the hash mixes all 128 bits, each level is close to
+// uniformly distributed over its 256 values for any set of distinct IDs,
+// including sequential values and UUIDv7 values sharing the same timestamp,
+// and the levels are independent of each other.
+//
+// The result has the fixed length of `3*depth - 1` characters, or is empty if
+// `depth` is zero. This function panics if `depth` is negative or greater than
+// 8.
+func FanoutPrefix(u Uuid25, depth int) string {
+	if depth < 0 || depth > 8 {
+		panic("depth out of range")
+	} else if depth == 0 {
+		return ""
+	}
+	h := u.toUint128().Hash64()
+	var buffer [3 * 8]byte
+	for i := 0; i < depth; i += 1 {
+		c := byte(h >> (56 - 8*i))
+		buffer[3*i] = hexDigits[c>>4]
+		buffer[3*i+1] = hexDigits[c&15]
+		buffer[3*i+2] = '/'
+	}
+	return string(buffer[:3*depth-1])
+}
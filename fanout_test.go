@@ -0,0 +1,29 @@
+package uuid25
+
+import "testing"
+
+// Tests FanoutPrefix against fixed values and its distribution.
+func TestFanoutPrefix(t *testing.T) {
+	u, _ := Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	if FanoutPrefix(u, 2) != "a2/72" || FanoutPrefix(u, 0) != "" || len(FanoutPrefix(u, 8)) != 23 {
+		t.Fail()
+	}
+	if FanoutPrefix(u, 8)[:5] != "a2/72" {
+		t.Fail()
+	}
+
+	counts := make(map[string]int)
+	for i := 0; i < 65536; i += 1 {
+		x := FromUint128(Uint128{Hi: 0x018c_c820_d888_7abc, Lo: 0x8000_0000_0000_0000 | uint64(i)})
+		counts[FanoutPrefix(x, 1)] += 1
+	}
+	if len(counts) != 256 {
+		t.Fail()
+	}
+	for _, e := range counts {
+		if e < 256*8/10 || e > 256*12/10 {
+			t.Errorf("counts = %v", counts)
+			break
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package uuid25
+
+// A UUID string found in a larger string.
+type Match struct {
+	// The byte offset of the first character of the UUID string.
+	Start int
+
+	// The byte offset just after the last character of the UUID string, so
+	// that the UUID string is `s[Start:End]`.
+	End int
+
+	// The format of the UUID string.
+	Format Format
+
+	// The parsed UUID value.
+	Value Uuid25
+}
+
+// Returns the UUID strings embedded in `s`, such as a URL or a log line, in
+// the order of appearance.
+//
+// A UUID string in any supported format is recognized only if it is not
+// immediately preceded or followed by an ASCII letter or digit, so that parts
+// of longer tokens are not reported. Note that any isolated 25-digit
+// alphanumeric token that is a valid Uuid25 string is reported as a UUID. This
+// function scans `s` in a single pass without regular expressions and returns
+// nil if no UUID string is found.
+func FindAll(s string) []Match {
+	var matches []Match
+	for i := 0; i < len(s); {
+		if m, ok := matchAt(s, i); ok {
+			matches = append(matches, m)
+			i = m.End
+		} else if isAlnum(s[i]) {
+			for i += 1; i < len(s) && isAlnum(s[i]); i += 1 {
+			}
+		} else {
+			i += 1
+		}
+	}
+	return matches
+}
+
+// Returns the UUID string beginning at `s[i]`, which must not be preceded by
+// an alphanumeric character.
+func matchAt(s string, i int) (Match, bool) {
+	rest := s[i:]
+	switch c := rest[0]; {
+	case c == '{':
+		if x, err := decodeBraced(prefixOf(rest, 38)); err == nil {
+			return Match{i, i + 38, FormatBraced, FromUint128(x)}, true
+		}
+		return Match{}, false
+	case c == 'u' || c == 'U':
+		if x, err := decodeUrn(prefixOf(rest, 45)); err == nil && isBoundary(s, i+45) {
+			return Match{i, i + 45, FormatUrn, FromUint128(x)}, true
+		}
+	}
+	if x, err := decodeHyphenated(prefixOf(rest, 36)); err == nil && isBoundary(s, i+36) {
+		return Match{i, i + 36, FormatHyphenated, FromUint128(x)}, true
+	}
+
+	n := 0
+	for n < len(rest) && isAlnum(rest[n]) {
+		n += 1
+	}
+	switch n {
+	case 25:
+		if x, err := decodeBase36(rest[:n]); err == nil {
+			return Match{i, i + n, FormatUuid25, FromUint128(x)}, true
+		}
+	case 32:
+		if x, err := decodeHex(rest[:n]); err == nil {
+			return Match{i, i + n, FormatHex, FromUint128(x)}, true
+		}
+	}
+	return Match{}, false
+}
+
+// Returns the first `n` bytes of `s`, or an empty string if `s` is shorter.
+func prefixOf(s string, n int) string {
+	if len(s) < n {
+		return ""
+	}
+	return s[:n]
+}
+
+// Reports whether `s[i]` is not an alphanumeric character or is out of range.
+func isBoundary(s string, i int) bool {
+	return i >= len(s) || !isAlnum(s[i])
+}
+
+// Reports whether `c` is an ASCII letter or digit.
+func isAlnum(c byte) bool {
+	return ('0' <= c && c <= '9') || ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z')
+}
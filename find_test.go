@@ -0,0 +1,47 @@
+package uuid25
+
+import "testing"
+
+// Tests FindAll with UUIDs in every format and tokens that must not match.
+func TestFindAll(t *testing.T) {
+	s := "/users/e7a1d63b-7117-4423-8988-afcf12161878/x?a={40EB9860-CF3E-45E2-A90E-B82236AC806C}" +
+		"&b=URN:UUID:e7a1d63b-7117-4423-8988-afcf12161878 3ud3gtvgolimgu9lah6aie99o," +
+		"e7a1d63b711744238988afcf12161878 xe7a1d63b711744238988afcf12161878 " +
+		"e7a1d63b-7117-4423-8988-afcf121618789 zzzzzzzzzzzzzzzzzzzzzzzzz"
+	want := []Match{
+		{7, 43, FormatHyphenated, "dpoadk8izg9y4tte7vy1xt94o"},
+		{48, 86, FormatBraced, "3ud3gtvgolimgu9lah6aie99o"},
+		{89, 134, FormatUrn, "dpoadk8izg9y4tte7vy1xt94o"},
+		{135, 160, FormatUuid25, "3ud3gtvgolimgu9lah6aie99o"},
+		{161, 193, FormatHex, "dpoadk8izg9y4tte7vy1xt94o"},
+	}
+	got := FindAll(s)
+	if len(got) != len(want) {
+		t.Fatalf("got %v", got)
+	}
+	for i, e := range want {
+		if got[i] != e {
+			t.Errorf("got %v, want %v", got[i], e)
+		}
+	}
+
+	for _, e := range testCases {
+		for _, s := range []string{e.uuid25, e.hex, e.hyphenated, e.braced, e.urn} {
+			m := FindAll("(" + s + ")")
+			if len(m) != 1 || m[0].Start != 1 || m[0].End != len(s)+1 || m[0].Value != Uuid25(e.uuid25) {
+				t.Fail()
+			}
+		}
+	}
+	if FindAll("") != nil || FindAll("{}") != nil {
+		t.Fail()
+	}
+}
+
+// Benchmarks FindAll on a typical log line.
+func BenchmarkFindAll(b *testing.B) {
+	s := `2024-01-02T03:04:05Z INFO request_id=e7a1d63b-7117-4423-8988-afcf12161878 path=/users/3ud3gtvgolimgu9lah6aie99o status=200`
+	for i := 0; i < b.N; i += 1 {
+		FindAll(s)
+	}
+}
@@ -0,0 +1,177 @@
+package uuid25
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Generates a random UUID (UUIDv4) value encoded in the Uuid25 format.
+//
+// This function reads 122 random bits from crypto/rand and panics if the
+// random number generator fails, which does not happen on supported
+// platforms.
+func NewV4() Uuid25 {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = 0x40 | b[6]&0x0f
+	b[8] = 0x80 | b[8]&0x3f
+	return FromBytes(b[:])
+}
+
+// The maximum value of the 42-bit counter of UUIDv7.
+const maxCounterV7 = 1<<42 - 1
+
+// The maximum clock rollback in milliseconds that V7Generator tolerates by
+// reusing the previous timestamp.
+const rollbackAllowanceV7 = 10_000
+
+// A generator of time-ordered UUIDv7 values that are monotonically increasing
+// within the generator.
+//
+// The generator implements the method of RFC 9562 that uses a dedicated
+// counter: each value consists of a 48-bit Unix timestamp in milliseconds, a
+// 42-bit counter, and 32 random bits, besides the version and variant bits. The
+// counter is reset to a random number whose most significant bit is zero when
+// the timestamp advances and is incremented otherwise, so values generated in
+// the same millisecond are still ordered. If the counter overflows, the
+// timestamp is advanced by one millisecond. If the system clock goes back by
+// less than 10 seconds, the generator keeps using the previous timestamp to
+// preserve the order; otherwise, it resets its state and the order is broken.
+//
+// A generator is safe for concurrent use. The zero value is ready to use.
+type V7Generator struct {
+	mu        sync.Mutex
+	timestamp uint64
+	counter   uint64
+	clock     uint64
+	stats     V7Stats
+}
+
+// A snapshot of the activity of a V7Generator for operational visibility.
+type V7Stats struct {
+	// The number of values generated.
+	Generated uint64 `json:"generated"`
+
+	// The timestamp of the last generated value in milliseconds since the Unix
+	// epoch, or zero if no value has been generated.
+	LastTimestamp uint64 `json:"last_timestamp"`
+
+	// The number of times the system clock was observed going back.
+	ClockRollbacks uint64 `json:"clock_rollbacks"`
+
+	// The number of times the counter overflowed and the timestamp was
+	// advanced ahead of the system clock.
+	CounterOverflows uint64 `json:"counter_overflows"`
+}
+
+// Returns the JSON representation of the snapshot, which implements the
+// expvar.Var interface.
+func (s V7Stats) String() string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// Returns a snapshot of the activity of the generator.
+func (g *V7Generator) Stats() V7Stats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.stats
+}
+
+// Returns a live view of Stats() that implements the expvar.Var interface, so
+// that the activity can be published without this package depending on
+// expvar:
+//
+//	expvar.Publish("uuid25_v7", generator.StatsVar())
+func (g *V7Generator) StatsVar() fmt.Stringer {
+	return statsVar{g}
+}
+
+// An expvar.Var that reports the current stats of a generator.
+type statsVar struct{ g *V7Generator }
+
+// Implements the expvar.Var interface.
+func (v statsVar) String() string {
+	return v.g.Stats().String()
+}
+
+// The generator used by NewV7().
+var defaultV7Generator V7Generator
+
+// Returns a live view of the stats of the generator used by NewV7(), which
+// implements the expvar.Var interface:
+//
+//	expvar.Publish("uuid25_v7", uuid25.NewV7StatsVar())
+//
+// See V7Generator.StatsVar() for details.
+func NewV7StatsVar() fmt.Stringer {
+	return defaultV7Generator.StatsVar()
+}
+
+// Generates a time-ordered UUID (UUIDv7) value encoded in the Uuid25 format.
+//
+// The values generated by this function in a process are monotonically
+// increasing, which also makes their Uuid25 strings sortable. See V7Generator
+// for details. This function panics if the random number generator fails.
+func NewV7() Uuid25 {
+	return defaultV7Generator.Generate()
+}
+
+// Generates a new UUIDv7 value with the current time.
+func (g *V7Generator) Generate() Uuid25 {
+	return g.generateAt(uint64(time.Now().UnixMilli()))
+}
+
+// Generates a new UUIDv7 value with a timestamp in milliseconds.
+func (g *V7Generator) generateAt(unixMs uint64) Uuid25 {
+	var r [10]byte
+	if _, err := rand.Read(r[:]); err != nil {
+		panic(err)
+	}
+	random := func() uint64 {
+		return (uint64(r[4])<<40 | uint64(r[5])<<32 | uint64(r[6])<<24 |
+			uint64(r[7])<<16 | uint64(r[8])<<8 | uint64(r[9])) & (maxCounterV7 >> 1)
+	}
+
+	g.mu.Lock()
+	if unixMs < g.clock {
+		g.stats.ClockRollbacks += 1
+	}
+	g.clock = unixMs
+	if unixMs > g.timestamp || unixMs+rollbackAllowanceV7 <= g.timestamp {
+		g.timestamp = unixMs
+		g.counter = random()
+	} else {
+		g.counter += 1
+		if g.counter > maxCounterV7 {
+			g.timestamp += 1
+			g.counter = random()
+			g.stats.CounterOverflows += 1
+		}
+	}
+	ts, counter := g.timestamp, g.counter
+	g.stats.Generated += 1
+	g.stats.LastTimestamp = ts
+	g.mu.Unlock()
+
+	var b [16]byte
+	b[0] = byte(ts >> 40)
+	b[1] = byte(ts >> 32)
+	b[2] = byte(ts >> 24)
+	b[3] = byte(ts >> 16)
+	b[4] = byte(ts >> 8)
+	b[5] = byte(ts)
+	b[6] = 0x70 | byte(counter>>38)
+	b[7] = byte(counter >> 30)
+	b[8] = 0x80 | byte(counter>>24)&0x3f
+	b[9] = byte(counter >> 16)
+	b[10] = byte(counter >> 8)
+	b[11] = byte(counter)
+	copy(b[12:], r[:4])
+	return FromBytes(b[:])
+}
@@ -0,0 +1,105 @@
+package uuid25
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+	"time"
+)
+
+// Tests that NewV4 generates unique values with the correct version and
+// variant.
+func TestNewV4(t *testing.T) {
+	seen := make(map[Uuid25]bool)
+	for i := 0; i < 1000; i += 1 {
+		x := NewV4()
+		b := x.ToBytes()
+		if b[6]>>4 != 4 || b[8]>>6 != 0b10 || seen[x] || len(x) != 25 {
+			t.Fail()
+		}
+		seen[x] = true
+	}
+}
+
+// Tests that NewV7 generates increasing values with the current time.
+func TestNewV7(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	prev := NewV7()
+	for i := 0; i < 10_000; i += 1 {
+		x := NewV7()
+		if b := x.ToBytes(); b[6]>>4 != 7 || b[8]>>6 != 0b10 || x <= prev {
+			t.Fatal("must be increasing UUIDv7")
+		}
+		prev = x
+	}
+	ts, err := prev.Time()
+	if err != nil || ts.Before(before) || ts.After(time.Now()) {
+		t.Fail()
+	}
+}
+
+// Tests the counter and clock rollback handling of V7Generator.
+func TestV7Generator(t *testing.T) {
+	var g V7Generator
+	const ms = 1_700_000_000_000
+	prev := g.generateAt(ms)
+	for _, e := range []uint64{ms, ms, ms - 9_999, ms + 1} {
+		x := g.generateAt(e)
+		if x <= prev {
+			t.Fail()
+		}
+		prev = x
+	}
+	if ts, _ := prev.Time(); ts.UnixMilli() != ms+1 {
+		t.Fail()
+	}
+
+	g.counter = maxCounterV7
+	x := g.generateAt(ms + 1)
+	if ts, _ := x.Time(); x <= prev || ts.UnixMilli() != ms+2 {
+		t.Fail()
+	}
+
+	x = g.generateAt(ms - 10_000)
+	if ts, _ := x.Time(); x >= prev || ts.UnixMilli() != ms-10_000 {
+		t.Fail()
+	}
+}
+
+// Tests the stats of V7Generator and their expvar representation.
+func TestV7GeneratorStats(t *testing.T) {
+	var g V7Generator
+	if g.Stats() != (V7Stats{}) {
+		t.Fail()
+	}
+	const ms = 1_700_000_000_000
+	for _, e := range []uint64{ms, ms, ms - 9_999, ms + 1} {
+		g.generateAt(e)
+	}
+	g.counter = maxCounterV7
+	g.generateAt(ms + 1)
+	g.generateAt(ms - 10_000)
+
+	expected := V7Stats{Generated: 6, LastTimestamp: ms - 10_000, ClockRollbacks: 2, CounterOverflows: 1}
+	if g.Stats() != expected {
+		t.Errorf("unexpected stats: %+v", g.Stats())
+	}
+
+	v := g.StatsVar()
+	const text = `{"generated":6,"last_timestamp":1699999990000,"clock_rollbacks":2,"counter_overflows":1}`
+	if v.String() != text {
+		t.Errorf("unexpected text: %s", v)
+	}
+	g.generateAt(ms)
+	var decoded V7Stats
+	if json.Unmarshal([]byte(v.String()), &decoded) != nil || decoded.Generated != 7 {
+		t.Fail()
+	}
+
+	var _ expvar.Var = v
+	before := defaultV7Generator.Stats().Generated
+	NewV7()
+	if err := json.Unmarshal([]byte(NewV7StatsVar().String()), &decoded); err != nil || decoded.Generated != before+1 {
+		t.Fail()
+	}
+}
@@ -2,4 +2,4 @@ module github.com/uuid25/go-uuid25
 
 go 1.19
 
-require github.com/google/uuid v1.3.0
+require github.com/google/uuid v1.6.0
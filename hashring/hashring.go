@@ -0,0 +1,139 @@
+// Consistent-hash ring routing Uuid25 values to nodes
+//
+// A Ring places a number of virtual nodes for each node on a 64-bit hash
+// circle and routes an entity ID to the first virtual node at or after the
+// hash of the ID, so that adding or removing a node moves only about 1/n of
+// the IDs. The hash of an ID is computed from its 128-bit value, which gives
+// the same result for any textual format of the ID and spreads time-ordered
+// UUIDs such as UUIDv7 evenly over the circle.
+package hashring
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// The number of virtual nodes per node used by New.
+const DefaultReplicas = 160
+
+// A point on the hash circle.
+type point struct {
+	hash uint64
+	node string
+}
+
+// A consistent-hash ring of nodes.
+//
+// A ring is safe for concurrent use.
+type Ring struct {
+	replicas int
+	mu       sync.RWMutex
+	points   []point
+	nodes    map[string]struct{}
+}
+
+// Creates an empty ring with DefaultReplicas virtual nodes per node.
+func New() *Ring {
+	return NewWithReplicas(DefaultReplicas)
+}
+
+// Creates an empty ring with the specified number of virtual nodes per node.
+//
+// More virtual nodes distribute IDs more evenly at the cost of memory and
+// slower updates. This function panics if `replicas` is not positive.
+func NewWithReplicas(replicas int) *Ring {
+	if replicas <= 0 {
+		panic("replicas must be positive")
+	}
+	return &Ring{replicas: replicas, nodes: make(map[string]struct{})}
+}
+
+// Adds nodes, identified by names such as addresses, to the ring.
+//
+// Nodes already in the ring are ignored.
+func (r *Ring) Add(nodes ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, node := range nodes {
+		if _, ok := r.nodes[node]; ok {
+			continue
+		}
+		r.nodes[node] = struct{}{}
+		for i := 0; i < r.replicas; i += 1 {
+			r.points = append(r.points, point{nodeHash(node, i), node})
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool {
+		a, b := r.points[i], r.points[j]
+		return a.hash < b.hash || (a.hash == b.hash && a.node < b.node)
+	})
+}
+
+// Removes nodes from the ring.
+//
+// Nodes not in the ring are ignored.
+func (r *Ring) Remove(nodes ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	removed := false
+	for _, node := range nodes {
+		if _, ok := r.nodes[node]; ok {
+			delete(r.nodes, node)
+			removed = true
+		}
+	}
+	if !removed {
+		return
+	}
+	points := r.points[:0]
+	for _, e := range r.points {
+		if _, ok := r.nodes[e.node]; ok {
+			points = append(points, e)
+		}
+	}
+	r.points = points
+}
+
+// Returns the node responsible for an ID, or false if the ring is empty.
+func (r *Ring) Lookup(id uuid25.Uuid25) (string, bool) {
+	return r.LookupUint128(id.ToUint128())
+}
+
+// Returns the node responsible for an ID represented as a 128-bit integer, or
+// false if the ring is empty.
+func (r *Ring) LookupUint128(x uuid25.Uint128) (string, bool) {
+	h := x.Hash64()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.points) == 0 {
+		return "", false
+	}
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.points[i].node, true
+}
+
+// Returns the nodes in the ring in ascending order.
+func (r *Ring) Nodes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	nodes := make([]string, 0, len(r.nodes))
+	for e := range r.nodes {
+		nodes = append(nodes, e)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// Returns the position of the `i`-th virtual node of a node on the circle.
+func nodeHash(node string, i int) uint64 {
+	h := sha256.Sum256([]byte(node + "#" + strconv.Itoa(i)))
+	return binary.BigEndian.Uint64(h[:8])
+}
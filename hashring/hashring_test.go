@@ -0,0 +1,65 @@
+package hashring
+
+import (
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests Lookup on empty and populated rings.
+func TestLookup(t *testing.T) {
+	r := New()
+	id, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	if _, ok := r.Lookup(id); ok {
+		t.Fail()
+	}
+	r.Add("a", "b", "c", "a")
+	if nodes := r.Nodes(); len(nodes) != 3 || nodes[0] != "a" || nodes[2] != "c" {
+		t.Fail()
+	}
+	node, ok := r.Lookup(id)
+	if !ok {
+		t.Fail()
+	}
+	other, _ := uuid25.Parse("{E7A1D63B-7117-4423-8988-AFCF12161878}")
+	if x, _ := r.Lookup(other); x != node {
+		t.Fail()
+	}
+
+	s := New()
+	s.Add("c", "b", "a")
+	if x, _ := s.Lookup(id); x != node {
+		t.Fail()
+	}
+}
+
+// Tests that IDs are spread evenly and only the IDs of a removed node move.
+func TestRemove(t *testing.T) {
+	const n = 30_000
+	r := New()
+	r.Add("a", "b", "c", "d")
+	before := make([]string, n)
+	counts := map[string]int{}
+	for i := 0; i < n; i += 1 {
+		before[i], _ = r.LookupUint128(uuid25.Uint128{Hi: 0x018c_0000_7000_0000, Lo: uint64(i)})
+		counts[before[i]] += 1
+	}
+	for _, e := range counts {
+		if e < n/4*8/10 || e > n/4*12/10 {
+			t.Errorf("counts = %v", counts)
+			break
+		}
+	}
+
+	r.Remove("b", "x")
+	if len(r.Nodes()) != 3 {
+		t.Fail()
+	}
+	for i := 0; i < n; i += 1 {
+		after, _ := r.LookupUint128(uuid25.Uint128{Hi: 0x018c_0000_7000_0000, Lo: uint64(i)})
+		if after == "b" || (before[i] != "b" && after != before[i]) {
+			t.Fail()
+			break
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// HyperLogLog cardinality estimation of Uuid25 values
+//
+// This package estimates the number of distinct UUIDs in a stream using a
+// fixed amount of memory. Unlike general-purpose implementations that hash
+// the string form of each input, a Sketch decodes the 128-bit value and mixes
+// it with a few multiplications, which is both faster and independent of the
+// textual format the IDs arrived in. The mixing also spreads UUIDs whose
+// leading bits are a timestamp, such as UUIDv7, evenly over the registers.
+//
+// With precision p, a sketch uses 2^p bytes and has a standard error of about
+// 1.04/sqrt(2^p): 0.81% with the default precision of 14.
+package hll
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// The precision used by New.
+const DefaultPrecision = 14
+
+// The minimum and maximum precision accepted by NewWithPrecision.
+const (
+	MinPrecision = 4
+	MaxPrecision = 18
+)
+
+// An error returned when merging or decoding sketches of different precision.
+var ErrPrecisionMismatch = errors.New("precision mismatch")
+
+// A HyperLogLog sketch of a set of UUIDs.
+//
+// A sketch is not safe for concurrent use.
+type Sketch struct {
+	p         uint8
+	registers []uint8
+}
+
+// Creates an empty sketch with the default precision.
+func New() *Sketch {
+	return NewWithPrecision(DefaultPrecision)
+}
+
+// Creates an empty sketch with 2^p registers.
+//
+// This function panics if `p` is out of the range from MinPrecision to
+// MaxPrecision.
+func NewWithPrecision(p int) *Sketch {
+	if p < MinPrecision || p > MaxPrecision {
+		panic("precision out of range")
+	}
+	return &Sketch{p: uint8(p), registers: make([]uint8, 1<<p)}
+}
+
+// Adds a UUID to the sketch.
+func (s *Sketch) Add(id uuid25.Uuid25) {
+	s.AddUint128(id.ToUint128())
+}
+
+// Adds a UUID represented as a 128-bit integer to the sketch.
+func (s *Sketch) AddUint128(x uuid25.Uint128) {
+	h := x.Hash64()
+	idx := h >> (64 - s.p)
+	rank := uint8(bits.LeadingZeros64(h<<s.p|1<<(s.p-1))) + 1
+	if rank > s.registers[idx] {
+		s.registers[idx] = rank
+	}
+}
+
+// Returns the estimated number of distinct UUIDs added to the sketch.
+func (s *Sketch) Count() uint64 {
+	m := float64(len(s.registers))
+	sum := 0.0
+	zeros := 0
+	for _, e := range s.registers {
+		sum += math.Ldexp(1, -int(e))
+		if e == 0 {
+			zeros += 1
+		}
+	}
+	estimate := alpha(len(s.registers)) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// Merges another sketch into this sketch, so that this sketch estimates the
+// cardinality of the union of both sets.
+//
+// This method returns ErrPrecisionMismatch if the sketches have different
+// precision.
+func (s *Sketch) Merge(other *Sketch) error {
+	if s.p != other.p {
+		return ErrPrecisionMismatch
+	}
+	for i, e := range other.registers {
+		if e > s.registers[i] {
+			s.registers[i] = e
+		}
+	}
+	return nil
+}
+
+// Removes all elements from the sketch.
+func (s *Sketch) Reset() {
+	for i := range s.registers {
+		s.registers[i] = 0
+	}
+}
+
+// Implements the encoding.BinaryMarshaler interface.
+//
+// The result is the 1-byte precision followed by the registers.
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 1+len(s.registers))
+	b[0] = s.p
+	copy(b[1:], s.registers)
+	return b, nil
+}
+
+// Implements the encoding.BinaryUnmarshaler interface.
+//
+// The precision of the sketch is replaced by the decoded one.
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	if s == nil {
+		return errors.New("nil receiver")
+	}
+	if len(data) == 0 || data[0] < MinPrecision || data[0] > MaxPrecision ||
+		len(data) != 1+1<<data[0] {
+		return errors.New("invalid sketch")
+	}
+	p := data[0]
+	for _, e := range data[1:] {
+		if e > 65-p {
+			return errors.New("invalid sketch")
+		}
+	}
+	s.p = p
+	s.registers = append(s.registers[:0], data[1:]...)
+	return nil
+}
+
+// Returns the bias correction constant for `m` registers.
+func alpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
@@ -0,0 +1,76 @@
+package hll
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests the estimates for sequential and random UUIDs of various cardinality.
+func TestCount(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 100, 10_000, 200_000} {
+		seq := New()
+		random := New()
+		for i := 0; i < n; i += 1 {
+			seq.AddUint128(uuid25.Uint128{Hi: 0x018c_0000_7000_0000, Lo: uint64(i)})
+			random.AddUint128(uuid25.Uint128{Hi: rng.Uint64(), Lo: rng.Uint64()})
+		}
+		for _, s := range []*Sketch{seq, random} {
+			got := float64(s.Count())
+			if got < float64(n)*0.97-1 || got > float64(n)*1.03+1 {
+				t.Errorf("n = %d, got %v", n, got)
+			}
+		}
+	}
+}
+
+// Tests that duplicates and different formats are counted once.
+func TestAdd(t *testing.T) {
+	s := New()
+	a, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	b, _ := uuid25.Parse("{E7A1D63B-7117-4423-8988-AFCF12161878}")
+	for i := 0; i < 10; i += 1 {
+		s.Add(a)
+		s.Add(b)
+	}
+	if s.Count() != 1 {
+		t.Fail()
+	}
+	s.Reset()
+	if s.Count() != 0 {
+		t.Fail()
+	}
+}
+
+// Tests Merge and the binary encoding.
+func TestMerge(t *testing.T) {
+	a := NewWithPrecision(12)
+	b := NewWithPrecision(12)
+	for i := 0; i < 3000; i += 1 {
+		a.AddUint128(uuid25.Uint128From64(uint64(i)))
+		b.AddUint128(uuid25.Uint128From64(uint64(i + 2000)))
+	}
+	if a.Merge(b) != nil {
+		t.Fail()
+	}
+	if got := a.Count(); got < 4700 || got > 5300 {
+		t.Errorf("got %v", got)
+	}
+	if a.Merge(New()) != ErrPrecisionMismatch {
+		t.Fail()
+	}
+
+	data, err := a.MarshalBinary()
+	if err != nil || len(data) != 1+4096 {
+		t.Fail()
+	}
+	c := New()
+	if c.UnmarshalBinary(data) != nil || c.Count() != a.Count() || c.Merge(a) != nil {
+		t.Fail()
+	}
+	if c.UnmarshalBinary(data[1:]) == nil || c.UnmarshalBinary(nil) == nil {
+		t.Fail()
+	}
+}
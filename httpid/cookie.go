@@ -0,0 +1,128 @@
+package httpid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// An error returned when a signed cookie has an invalid signature.
+var ErrInvalidSignature = errors.New("invalid cookie signature")
+
+// The length of a cookie signature in bytes before encoding.
+const cookieMacLen = 16
+
+// A codec that writes and reads a Uuid25 value in a cookie, such as an
+// anonymous session ID.
+//
+// The exported fields set the attributes of the cookies written by Set and can
+// be modified after creating a codec with NewCookieCodec.
+type CookieCodec struct {
+	// The name of the cookie.
+	Name string
+
+	// The attributes of the cookie; see http.Cookie.
+	Path     string
+	Domain   string
+	MaxAge   int
+	Secure   bool
+	HttpOnly bool
+	SameSite http.SameSite
+
+	key []byte
+}
+
+// Creates a codec for the cookie `name`.
+//
+// If `key` is not nil, cookie values are signed with HMAC-SHA256 under the key
+// as `<uuid25>.<signature>`, and values with an invalid signature are
+// rejected; otherwise, the value is the plain Uuid25 string. The cookies are
+// by default scoped to path `/`, marked Secure and HttpOnly, and sent with
+// SameSite=Lax. This function panics if `key` is not nil and shorter than 16
+// bytes.
+func NewCookieCodec(name string, key []byte) *CookieCodec {
+	if key != nil && len(key) < 16 {
+		panic("key must be at least 16 bytes long")
+	}
+	return &CookieCodec{
+		Name:     name,
+		Path:     "/",
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		key:      key,
+	}
+}
+
+// Returns a cookie holding `id` with the attributes of the codec.
+func (c *CookieCodec) Cookie(id uuid25.Uuid25) *http.Cookie {
+	value := id.String()
+	if c.key != nil {
+		value += "." + c.sign(value)
+	}
+	return c.cookie(value, c.MaxAge)
+}
+
+// Adds a Set-Cookie header holding `id` to the response.
+func (c *CookieCodec) Set(w http.ResponseWriter, id uuid25.Uuid25) {
+	http.SetCookie(w, c.Cookie(id))
+}
+
+// Adds a Set-Cookie header that deletes the cookie to the response.
+func (c *CookieCodec) Clear(w http.ResponseWriter) {
+	cookie := c.cookie("", -1)
+	cookie.Expires = time.Unix(1, 0)
+	http.SetCookie(w, cookie)
+}
+
+// Reads the ID from the cookie of the request.
+//
+// This method returns http.ErrNoCookie if the cookie is not present,
+// ErrInvalidSignature if the codec has a key and the signature is missing or
+// invalid, or a parse error if the value is not a valid Uuid25 string.
+func (c *CookieCodec) Get(r *http.Request) (uuid25.Uuid25, error) {
+	cookie, err := r.Cookie(c.Name)
+	if err != nil {
+		return "", err
+	}
+	value := cookie.Value
+	if c.key != nil {
+		var mac string
+		var ok bool
+		value, mac, ok = strings.Cut(value, ".")
+		if !ok || !hmac.Equal([]byte(mac), []byte(c.sign(value))) {
+			return "", ErrInvalidSignature
+		}
+	}
+	return uuid25.ParseUuid25(value)
+}
+
+// Returns a cookie with the attributes of the codec.
+func (c *CookieCodec) cookie(value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     c.Name,
+		Value:    value,
+		Path:     c.Path,
+		Domain:   c.Domain,
+		MaxAge:   maxAge,
+		Secure:   c.Secure,
+		HttpOnly: c.HttpOnly,
+		SameSite: c.SameSite,
+	}
+}
+
+// Returns the signature of a cookie value, which also covers the cookie name
+// so that a value cannot be moved to another cookie signed with the same key.
+func (c *CookieCodec) sign(value string) string {
+	h := hmac.New(sha256.New, c.key)
+	h.Write([]byte(c.Name))
+	h.Write([]byte{0})
+	h.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:cookieMacLen])
+}
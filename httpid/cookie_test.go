@@ -0,0 +1,75 @@
+package httpid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Returns a request carrying the cookies set in a recorded response.
+func requestWithCookies(w *httptest.ResponseRecorder) *http.Request {
+	r := httptest.NewRequest("GET", "/", nil)
+	for _, e := range w.Result().Cookies() {
+		r.AddCookie(e)
+	}
+	return r
+}
+
+// Tests unsigned cookies and their attributes.
+func TestCookieCodec(t *testing.T) {
+	codec := NewCookieCodec("sid", nil)
+	codec.SameSite = http.SameSiteStrictMode
+	w := httptest.NewRecorder()
+	codec.Set(w, "dpoadk8izg9y4tte7vy1xt94o")
+	header := w.Header().Get("Set-Cookie")
+	if !strings.HasPrefix(header, "sid=dpoadk8izg9y4tte7vy1xt94o; Path=/;") ||
+		!strings.Contains(header, "HttpOnly") || !strings.Contains(header, "Secure") ||
+		!strings.Contains(header, "SameSite=Strict") {
+		t.Errorf("got %q", header)
+	}
+	if x, err := codec.Get(requestWithCookies(w)); x != "dpoadk8izg9y4tte7vy1xt94o" || err != nil {
+		t.Fail()
+	}
+	if _, err := codec.Get(httptest.NewRequest("GET", "/", nil)); err != http.ErrNoCookie {
+		t.Fail()
+	}
+
+	w = httptest.NewRecorder()
+	codec.Clear(w)
+	if c := w.Result().Cookies()[0]; c.Name != "sid" || c.MaxAge != -1 || c.Value != "" {
+		t.Fail()
+	}
+}
+
+// Tests signed cookies and tampering.
+func TestCookieCodecSigned(t *testing.T) {
+	key := []byte("0123456789abcdef")
+	codec := NewCookieCodec("sid", key)
+	w := httptest.NewRecorder()
+	codec.Set(w, "dpoadk8izg9y4tte7vy1xt94o")
+	r := requestWithCookies(w)
+	if x, err := codec.Get(r); x != "dpoadk8izg9y4tte7vy1xt94o" || err != nil {
+		t.Fail()
+	}
+	value, _ := r.Cookie("sid")
+
+	for _, e := range []string{
+		"dpoadk8izg9y4tte7vy1xt94o",
+		"3ud3gtvgolimgu9lah6aie99o" + value.Value[25:],
+		value.Value[:len(value.Value)-1] + "A",
+	} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.AddCookie(&http.Cookie{Name: "sid", Value: e})
+		if _, err := codec.Get(r); err != ErrInvalidSignature {
+			t.Fail()
+		}
+	}
+
+	other := NewCookieCodec("other", key)
+	r = httptest.NewRequest("GET", "/", nil)
+	r.AddCookie(&http.Cookie{Name: "other", Value: value.Value})
+	if _, err := other.Get(r); err != ErrInvalidSignature {
+		t.Fail()
+	}
+}
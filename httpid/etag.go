@@ -0,0 +1,72 @@
+package httpid
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// An error returned when an entity tag is not built by ETagFor or WeakETagFor.
+var ErrInvalidETag = errors.New("invalid entity tag")
+
+// Returns a strong entity tag, `"<uuid25>-<version>"`, derived from an entity
+// ID and its version number, such as a revision counter incremented on each
+// update.
+//
+// The version is written in decimal. This function panics if `u` is not
+// constructed properly.
+func ETagFor(u uuid25.Uuid25, version uint64) string {
+	return `"` + u.String() + "-" + strconv.FormatUint(version, 10) + `"`
+}
+
+// Returns the weak counterpart, `W/"<uuid25>-<version>"`, of the entity tag
+// returned by ETagFor.
+func WeakETagFor(u uuid25.Uuid25, version uint64) string {
+	return "W/" + ETagFor(u, version)
+}
+
+// Parses an entity tag built by ETagFor or WeakETagFor and returns the entity
+// ID, the version number, and whether the tag is weak.
+func ParseETag(etag string) (u uuid25.Uuid25, version uint64, weak bool, err error) {
+	weak = strings.HasPrefix(etag, "W/")
+	if weak {
+		etag = etag[2:]
+	}
+	if len(etag) < 29 || etag[0] != '"' || etag[len(etag)-1] != '"' || etag[26] != '-' {
+		return "", 0, false, ErrInvalidETag
+	}
+	u, err = uuid25.ParseUuid25(etag[1:26])
+	if err != nil {
+		return "", 0, false, ErrInvalidETag
+	}
+	digits := etag[27 : len(etag)-1]
+	if len(digits) > 1 && digits[0] == '0' {
+		return "", 0, false, ErrInvalidETag
+	}
+	version, err = strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return "", 0, false, ErrInvalidETag
+	}
+	return u, version, weak, nil
+}
+
+// Reports whether a list of entity tags in an If-None-Match or If-Match header
+// value matches the entity tag of an entity ID and a version.
+//
+// Tags are compared using the weak comparison of RFC 9110, which ignores the
+// weakness indicator, and `*` matches any entity. Tags not built by this
+// package never match.
+func Matches(header string, u uuid25.Uuid25, version uint64) bool {
+	for _, e := range strings.Split(header, ",") {
+		e = strings.TrimSpace(e)
+		if e == "*" {
+			return true
+		}
+		if x, v, _, err := ParseETag(e); err == nil && x == u && v == version {
+			return true
+		}
+	}
+	return false
+}
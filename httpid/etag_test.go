@@ -0,0 +1,37 @@
+package httpid
+
+import "testing"
+
+// Tests building and parsing entity tags.
+func TestETag(t *testing.T) {
+	const id = "dpoadk8izg9y4tte7vy1xt94o"
+	if ETagFor(id, 42) != `"dpoadk8izg9y4tte7vy1xt94o-42"` || WeakETagFor(id, 0) != `W/"dpoadk8izg9y4tte7vy1xt94o-0"` {
+		t.Fail()
+	}
+	if u, v, weak, err := ParseETag(`"DPOADK8IZG9Y4TTE7VY1XT94O-42"`); u != id || v != 42 || weak || err != nil {
+		t.Fail()
+	}
+	if u, v, weak, err := ParseETag(WeakETagFor(id, 18446744073709551615)); u != id || v != 18446744073709551615 || !weak || err != nil {
+		t.Fail()
+	}
+	for _, e := range []string{
+		"", `""`, `"dpoadk8izg9y4tte7vy1xt94o"`, `"dpoadk8izg9y4tte7vy1xt94o-"`, `dpoadk8izg9y4tte7vy1xt94o-1`,
+		`"dpoadk8izg9y4tte7vy1xt94o-01"`, `"dpoadk8izg9y4tte7vy1xt94o-x"`, `"zzzzzzzzzzzzzzzzzzzzzzzzz-1"`,
+		`"dpoadk8izg9y4tte7vy1xt94o-18446744073709551616"`, `w/"dpoadk8izg9y4tte7vy1xt94o-1"`,
+	} {
+		if _, _, _, err := ParseETag(e); err != ErrInvalidETag {
+			t.Errorf("%q", e)
+		}
+	}
+}
+
+// Tests Matches with lists of entity tags.
+func TestMatches(t *testing.T) {
+	const id = "dpoadk8izg9y4tte7vy1xt94o"
+	if !Matches(`"abc", W/"dpoadk8izg9y4tte7vy1xt94o-3"`, id, 3) || !Matches(ETagFor(id, 3), id, 3) || !Matches("*", id, 0) {
+		t.Fail()
+	}
+	if Matches(ETagFor(id, 3), id, 4) || Matches(ETagFor("3ud3gtvgolimgu9lah6aie99o", 3), id, 3) || Matches("", id, 0) {
+		t.Fail()
+	}
+}
@@ -0,0 +1,61 @@
+// Helpers for Uuid25 values in HTTP requests and responses
+//
+// This package extracts and validates UUIDs in URL paths, reads and writes
+// them in optionally signed cookies, and derives entity tags from them for
+// plain net/http handlers. IDs in paths are accepted in any supported format
+// and returned as Uuid25 values.
+//
+// FromPathValue and Handler, available with Go 1.22 or later, work with the
+// wildcards of http.ServeMux patterns. Note that the patterns are enabled only
+// in programs whose main module declares Go 1.22 or later.
+package httpid
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// An error returned when a path segment or path value is not present.
+var ErrNoSegment = errors.New("path segment not found")
+
+// Parses the path segment at `position` of the request URL path as a UUID.
+//
+// Segments are separated by slashes and numbered from 0, ignoring the leading
+// slash, so that position 1 of `/users/3ud3gtvgolimgu9lah6aie99o/orders` is
+// the user ID. A negative position counts from the end: -1 is the last
+// segment. This function returns ErrNoSegment if the path has no such
+// segment, or an error if the segment is not a valid UUID.
+func FromPathSegment(r *http.Request, position int) (uuid25.Uuid25, error) {
+	segments := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if position < 0 {
+		position += len(segments)
+	}
+	if position < 0 || position >= len(segments) {
+		return "", ErrNoSegment
+	}
+	return parseSegment(segments[position])
+}
+
+// Parses a path segment, annotating errors with the segment.
+func parseSegment(s string) (uuid25.Uuid25, error) {
+	if s == "" {
+		return "", ErrNoSegment
+	}
+	id, err := uuid25.Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("path segment %q: %w", s, err)
+	}
+	return id, nil
+}
+
+// Writes the status text of 404 Not Found.
+//
+// A path containing an invalid ID refers to no resource, so it is answered as
+// such rather than with 400 Bad Request.
+func notFound(w http.ResponseWriter) {
+	http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+}
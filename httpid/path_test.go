@@ -0,0 +1,31 @@
+package httpid
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+// Tests FromPathSegment with positive and negative positions.
+func TestFromPathSegment(t *testing.T) {
+	r := httptest.NewRequest("GET", "/users/E7A1D63B-7117-4423-8988-AFCF12161878/orders/3ud3gtvgolimgu9lah6aie99o", nil)
+	if x, err := FromPathSegment(r, 1); x != "dpoadk8izg9y4tte7vy1xt94o" || err != nil {
+		t.Fail()
+	}
+	if x, err := FromPathSegment(r, -1); x != "3ud3gtvgolimgu9lah6aie99o" || err != nil {
+		t.Fail()
+	}
+	if _, err := FromPathSegment(r, 0); err == nil || err == ErrNoSegment {
+		t.Fail()
+	}
+	if _, err := FromPathSegment(r, 4); err != ErrNoSegment {
+		t.Fail()
+	}
+	if _, err := FromPathSegment(r, -5); err != ErrNoSegment {
+		t.Fail()
+	}
+
+	r = httptest.NewRequest("GET", "/users/", nil)
+	if _, err := FromPathSegment(r, 1); err != ErrNoSegment {
+		t.Fail()
+	}
+}
@@ -0,0 +1,36 @@
+//go:build go1.22
+
+package httpid
+
+import (
+	"net/http"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Parses the wildcard `name` matched by an http.ServeMux pattern, such as
+// `{id}` in `GET /users/{id}`, as a UUID.
+//
+// This function returns ErrNoSegment if the wildcard is absent or empty, or an
+// error if the value is not a valid UUID.
+func FromPathValue(r *http.Request, name string) (uuid25.Uuid25, error) {
+	return parseSegment(r.PathValue(name))
+}
+
+// Returns a handler that parses the wildcard `name` as a UUID and calls
+// `handler` with it, or responds with 404 Not Found if the value is not a
+// valid UUID.
+//
+// For example:
+//
+//	mux.Handle("GET /users/{id}", httpid.Handler("id", getUser))
+func Handler(name string, handler func(w http.ResponseWriter, r *http.Request, id uuid25.Uuid25)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := FromPathValue(r, name)
+		if err != nil {
+			notFound(w)
+			return
+		}
+		handler(w, r, id)
+	})
+}
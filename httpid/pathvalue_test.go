@@ -0,0 +1,41 @@
+//go:build go1.22
+
+// Enables the patterns of http.ServeMux, which are disabled by default for
+// modules declaring a Go version older than 1.22.
+//
+//go:debug httpmuxgo121=0
+
+package httpid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests Handler and FromPathValue through http.ServeMux.
+func TestHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /users/{id}", Handler("id", func(w http.ResponseWriter, r *http.Request, id uuid25.Uuid25) {
+		w.Write([]byte(id.String()))
+	}))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/users/e7a1d63b-7117-4423-8988-afcf12161878", nil))
+	if w.Code != 200 || w.Body.String() != "dpoadk8izg9y4tte7vy1xt94o" {
+		t.Fail()
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/users/foo", nil))
+	if w.Code != 404 {
+		t.Fail()
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	if _, err := FromPathValue(r, "id"); err != ErrNoSegment {
+		t.Fail()
+	}
+}
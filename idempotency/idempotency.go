@@ -0,0 +1,140 @@
+// Helpers for idempotency keys in UUID formats
+//
+// This package validates and normalizes idempotency keys supplied by clients,
+// typically in an `Idempotency-Key` HTTP header, in any UUID format accepted by
+// uuid25.Parse, so that the same key sent as `3ud3gtvgolimgu9lah6aie99o` and
+// as `40EB9860-CF3E-45E2-A90E-B82236AC806C` is recognized as a duplicate. Seen
+// keys are recorded in a pluggable Store with a time-to-live.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// An error returned when an idempotency key is not a valid UUID string.
+var ErrInvalidKey = errors.New("invalid idempotency key")
+
+// Parses an idempotency key in any supported UUID format, ignoring
+// surrounding whitespace, and returns it as a Uuid25 value.
+func Normalize(key string) (uuid25.Uuid25, error) {
+	id, err := uuid25.Parse(strings.TrimSpace(key))
+	if err != nil {
+		return "", ErrInvalidKey
+	}
+	return id, nil
+}
+
+// Returns ErrInvalidKey if an idempotency key is not a valid UUID string in
+// any supported format.
+func Validate(key string) error {
+	_, err := Normalize(key)
+	return err
+}
+
+// Returns the storage key of an idempotency key within a scope.
+//
+// The scope, such as a client ID or an endpoint name, separates the keys of
+// different clients so that they do not collide. The result is the
+// hexadecimal SHA-256 hash of the scope and the key, which keeps raw keys out
+// of the store and has a fixed length regardless of the scope.
+func Hash(scope string, key uuid25.Uuid25) string {
+	h := sha256.New()
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], uint64(len(scope)))
+	h.Write(n[:])
+	h.Write([]byte(scope))
+	b := key.ToBytes()
+	h.Write(b[:])
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// A storage of seen idempotency keys.
+//
+// Implementations backed by Redis (`SET key 1 NX PX ttl`), SQL databases, or
+// other shared storages must perform the check and the insertion atomically.
+type Store interface {
+	// Records a key for the duration of `ttl` and reports whether the key was
+	// newly added, that is, has not been recorded or has expired.
+	Add(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// Normalizes an idempotency key, records it in a store within a scope, and
+// returns the normalized key and whether this is its first use.
+//
+// This function returns ErrInvalidKey if the key is invalid, or the error
+// returned by the store.
+func Claim(ctx context.Context, store Store, scope string, key string, ttl time.Duration) (uuid25.Uuid25, bool, error) {
+	id, err := Normalize(key)
+	if err != nil {
+		return "", false, err
+	}
+	added, err := store.Add(ctx, Hash(scope, id), ttl)
+	if err != nil {
+		return "", false, err
+	}
+	return id, added, nil
+}
+
+// The number of additions between sweeps of expired entries in MemoryStore.
+const sweepInterval = 1024
+
+// An in-memory Store for tests and single-process services.
+//
+// The zero value is not usable; use NewMemoryStore to create a store. A store
+// is safe for concurrent use.
+type MemoryStore struct {
+	// Returns the current time. It defaults to time.Now and can be replaced in
+	// tests before the store is used.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	expires map[string]time.Time
+	adds    int
+}
+
+// Creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{Now: time.Now, expires: make(map[string]time.Time)}
+}
+
+// Implements the Store interface.
+//
+// Expired entries are removed periodically as new keys are added.
+func (s *MemoryStore) Add(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	now := s.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if exp, ok := s.expires[key]; ok && now.Before(exp) {
+		return false, nil
+	}
+	s.expires[key] = now.Add(ttl)
+	s.adds += 1
+	if s.adds%sweepInterval == 0 {
+		for k, exp := range s.expires {
+			if !now.Before(exp) {
+				delete(s.expires, k)
+			}
+		}
+	}
+	return true, nil
+}
+
+// Returns the number of entries in the store, including expired entries that
+// have not been removed yet.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.expires)
+}
@@ -0,0 +1,66 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Tests that keys in different formats normalize to the same value.
+func TestNormalize(t *testing.T) {
+	a, err := Normalize("3ud3gtvgolimgu9lah6aie99o")
+	if err != nil {
+		t.Fail()
+	}
+	b, err := Normalize(" 40EB9860-CF3E-45E2-A90E-B82236AC806C\t")
+	if err != nil || a != b {
+		t.Fail()
+	}
+	if _, err := Normalize("not-a-uuid"); err != ErrInvalidKey {
+		t.Fail()
+	}
+	if Validate("{40eb9860-cf3e-45e2-a90e-b82236ac806c}") != nil || Validate("") != ErrInvalidKey {
+		t.Fail()
+	}
+}
+
+// Tests that Hash separates scopes.
+func TestHash(t *testing.T) {
+	key, _ := Normalize("3ud3gtvgolimgu9lah6aie99o")
+	if len(Hash("a", key)) != 64 || Hash("a", key) != Hash("a", key) {
+		t.Fail()
+	}
+	if Hash("a", key) == Hash("b", key) || Hash("ab", key) == Hash("a", key) {
+		t.Fail()
+	}
+}
+
+// Tests Claim with MemoryStore and key expiry.
+func TestClaim(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryStore()
+	store.Now = func() time.Time { return now }
+
+	key, first, err := Claim(ctx, store, "client-1", "40eb9860-cf3e-45e2-a90e-b82236ac806c", time.Minute)
+	if err != nil || !first || key != "3ud3gtvgolimgu9lah6aie99o" {
+		t.Fail()
+	}
+	if _, first, _ := Claim(ctx, store, "client-1", "3UD3GTVGOLIMGU9LAH6AIE99O", time.Minute); first {
+		t.Fail()
+	}
+	if _, first, _ := Claim(ctx, store, "client-2", "3ud3gtvgolimgu9lah6aie99o", time.Minute); !first {
+		t.Fail()
+	}
+	if _, _, err := Claim(ctx, store, "client-1", "bogus", time.Minute); err != ErrInvalidKey {
+		t.Fail()
+	}
+
+	now = now.Add(time.Minute)
+	if _, first, _ := Claim(ctx, store, "client-1", "3ud3gtvgolimgu9lah6aie99o", time.Minute); !first {
+		t.Fail()
+	}
+	if store.Len() != 2 {
+		t.Fail()
+	}
+}
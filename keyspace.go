@@ -0,0 +1,76 @@
+package uuid25
+
+import (
+	"errors"
+	"math"
+)
+
+// Returns `n` keys evenly spaced across the entire 128-bit UUID space in
+// ascending order, starting at the Nil UUID.
+//
+// The keys are the lower bounds of the ranges returned by PartitionRange(n), so
+// they can serve as split points for pre-splitting tables or as probe points
+// for spot-checking a UUID-keyed store. This function panics if `n` is not
+// positive.
+func SampleKeyspace(n int) []Uuid25 {
+	ranges := PartitionRange(n)
+	keys := make([]Uuid25, n)
+	for i, r := range ranges {
+		keys[i] = r.Lo
+	}
+	return keys
+}
+
+// Returns the number of UUIDs in the range as a fraction of the entire 128-bit
+// UUID space, which is within (0, 1].
+//
+// An inverted range, whose Hi is less than Lo, is treated as empty, and this
+// method returns 0 for it.
+func (r Range) Fraction() float64 {
+	lo, hi := r.Lo.ToUint128(), r.Hi.ToUint128()
+	if hi.Cmp(lo) < 0 {
+		return 0
+	}
+	size := hi.Sub(lo).Inc()
+	if size.IsZero() {
+		return 1 // the entire space wrapped around to zero
+	}
+	return math.Ldexp(float64(size.Hi), -64) + math.Ldexp(float64(size.Lo), -128)
+}
+
+// Estimates the total number of keys in a store from the number of keys found
+// within a range.
+//
+// The estimate assumes that keys are distributed uniformly across the UUID
+// space, which holds for random (version 4) and hash-based UUIDs but not for
+// time-ordered ones such as version 7. It returns 0 if the range is inverted.
+func EstimateTotal(r Range, count uint64) float64 {
+	f := r.Fraction()
+	if f == 0 {
+		return 0
+	}
+	return float64(count) / f
+}
+
+// An error returned when too few keys are given for an estimate.
+var ErrTooFewKeys = errors.New("too few keys for estimation")
+
+// Estimates the total number of keys in a store from a run of consecutive keys,
+// such as those returned by a `LIMIT k` scan starting at an arbitrary key.
+//
+// The keys must be sorted in ascending order without duplicates. Because the
+// run spans `len(keys) - 1` gaps between adjacent keys, the estimate is
+// `(len(keys) - 1)` divided by the fraction of the space spanned by the run.
+// The same uniformity assumption as EstimateTotal() applies. This function
+// returns ErrTooFewKeys if fewer than two keys are given.
+func EstimateTotalFromRun(keys []Uuid25) (float64, error) {
+	if len(keys) < 2 {
+		return 0, ErrTooFewKeys
+	}
+	first, last := keys[0].ToUint128(), keys[len(keys)-1].ToUint128()
+	if last.Cmp(first) <= 0 {
+		return 0, errors.New("keys not sorted in ascending order")
+	}
+	span := Range{FromUint128(first), FromUint128(last.Dec())}.Fraction()
+	return float64(len(keys)-1) / span, nil
+}
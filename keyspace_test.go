@@ -0,0 +1,73 @@
+package uuid25
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// Tests evenly spaced key sampling.
+func TestSampleKeyspace(t *testing.T) {
+	keys := SampleKeyspace(4)
+	expected := []string{
+		"00000000000000000000000000000000",
+		"40000000000000000000000000000000",
+		"80000000000000000000000000000000",
+		"c0000000000000000000000000000000",
+	}
+	for i, e := range expected {
+		if keys[i].ToHex() != e {
+			t.Fail()
+		}
+	}
+	if keys := SampleKeyspace(1); len(keys) != 1 || keys[0] != Nil {
+		t.Fail()
+	}
+}
+
+// Tests range fractions and cardinality estimation.
+func TestEstimateTotal(t *testing.T) {
+	ranges := PartitionRange(4)
+	if ranges[0].Fraction() != 0.25 || (Range{Nil, "f5lxx1zz5pnorynqglhzmsp33"}).Fraction() != 1 {
+		t.Fail()
+	}
+	if (Range{Nil, Nil}).Fraction() != math.Ldexp(1, -128) {
+		t.Fail()
+	}
+	if (Range{"0000000000000000000000001", Nil}).Fraction() != 0 {
+		t.Fail()
+	}
+	if EstimateTotal(ranges[1], 250) != 1000 {
+		t.Fail()
+	}
+
+	// generate uniformly distributed keys and estimate from a consecutive run
+	r := rand.New(rand.NewSource(1))
+	const total = 100000
+	keys := make([]Uuid25, total)
+	for i := range keys {
+		keys[i] = FromUint128(Uint128{r.Uint64(), r.Uint64()})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	count := 0
+	for _, e := range keys {
+		if ranges[2].Contains(e) {
+			count += 1
+		}
+	}
+	if est := EstimateTotal(ranges[2], uint64(count)); math.Abs(est-total)/total > 0.05 {
+		t.Errorf("unexpected estimate from range: %v", est)
+	}
+	if est, err := EstimateTotalFromRun(keys[30000:40000]); err != nil || math.Abs(est-total)/total > 0.05 {
+		t.Errorf("unexpected estimate from run: %v", est)
+	}
+
+	if _, err := EstimateTotalFromRun(keys[:1]); err != ErrTooFewKeys {
+		t.Fail()
+	}
+	if _, err := EstimateTotalFromRun([]Uuid25{keys[1], keys[0]}); err == nil {
+		t.Fail()
+	}
+}
@@ -0,0 +1,149 @@
+package uuid25
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync/atomic"
+)
+
+// A shim for migrating a column or JSON field from one UUID string format to
+// another without downtime.
+//
+// During a migration window, a column may hold values in both the old format,
+// such as the hyphenated format, and the new one, such as the Uuid25 format.
+// The scanners returned by Scanner() read both, while the values returned by
+// Wrap() are written to the database and JSON in the current write format,
+// which can be switched at runtime, for example when a configuration is
+// reloaded after all instances have been deployed with the shim. Switching
+// back is equally possible for rolling back or migrating in the opposite
+// direction. JSON fields need the adapter only for writing because Uuid25
+// itself reads every supported format from JSON.
+//
+// The adapter counts the values read in a format other than the write format,
+// so that the end of the migration can be detected. An adapter is safe for
+// concurrent use.
+//
+//	var idColumn = uuid25.NewLegacyAdapter(uuid25.FormatHyphenated)
+//
+//	err := row.Scan(idColumn.Scanner(&id))
+//	_, err = db.Exec(query, idColumn.Wrap(id))
+//
+//	// after the deployment
+//	idColumn.SetWriteFormat(uuid25.FormatUuid25)
+type LegacyAdapter struct {
+	format      atomic.Int32
+	legacyReads atomic.Uint64
+}
+
+// Creates an adapter that writes values in the format `write`.
+//
+// This function panics if `write` is not a valid format.
+func NewLegacyAdapter(write Format) *LegacyAdapter {
+	a := &LegacyAdapter{}
+	a.SetWriteFormat(write)
+	return a
+}
+
+// Returns the current write format.
+func (a *LegacyAdapter) WriteFormat() Format {
+	return Format(a.format.Load())
+}
+
+// Changes the write format.
+//
+// This method panics if `f` is not a valid format.
+func (a *LegacyAdapter) SetWriteFormat(f Format) {
+	if f <= FormatInvalid || int(f) >= len(formatNames) {
+		panic("invalid format")
+	}
+	a.format.Store(int32(f))
+}
+
+// Returns the number of values read by the scanners of this adapter in a
+// format other than the write format at the time of reading, including binary
+// values and non-canonical strings.
+func (a *LegacyAdapter) LegacyReads() uint64 {
+	return a.legacyReads.Load()
+}
+
+// Returns a scanner that reads a value in any supported format into `dst`.
+//
+// The scanner accepts the same types as Uuid25.Scan().
+func (a *LegacyAdapter) Scanner(dst *Uuid25) sql.Scanner {
+	return &legacyScanner{a, dst}
+}
+
+// Returns a value that is written in the current write format.
+//
+// The write format is fixed when this method is called.
+func (a *LegacyAdapter) Wrap(u Uuid25) LegacyValue {
+	return LegacyValue{u, a.WriteFormat()}
+}
+
+// A scanner returned by LegacyAdapter.Scanner().
+type legacyScanner struct {
+	adapter *LegacyAdapter
+	dst     *Uuid25
+}
+
+// Implements the sql.Scanner interface.
+func (s *legacyScanner) Scan(src any) error {
+	if s.dst == nil {
+		return errors.New("nil destination")
+	}
+	if err := s.dst.Scan(src); err != nil {
+		return err
+	}
+	var text string
+	switch src := src.(type) {
+	case string:
+		text = src
+	case []byte:
+		text = string(src)
+	}
+	f, _, canonical := detect(text)
+	if f != s.adapter.WriteFormat() || !canonical {
+		s.adapter.legacyReads.Add(1)
+	}
+	return nil
+}
+
+// A Uuid25 value written in a format chosen by LegacyAdapter.
+type LegacyValue struct {
+	id     Uuid25
+	format Format
+}
+
+// Returns the representation of the value in its format.
+//
+// This method panics if the value is not constructed properly.
+func (v LegacyValue) String() string {
+	var buffer [MaxInputLen]byte
+	return string(v.id.AppendFormat(buffer[:0], v.format))
+}
+
+// Implements the driver.Valuer interface.
+func (v LegacyValue) Value() (driver.Value, error) {
+	text, err := v.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// Implements the encoding.TextMarshaler interface.
+func (v LegacyValue) MarshalText() ([]byte, error) {
+	if len(v.id) != 25 {
+		return nil, errImproperValue
+	}
+	return v.id.AppendFormat(nil, v.format), nil
+}
+
+// Implements the json.Marshaler interface.
+func (v LegacyValue) MarshalJSON() ([]byte, error) {
+	if len(v.id) != 25 {
+		return nil, errImproperValue
+	}
+	return append(v.id.AppendFormat([]byte{'"'}, v.format), '"'), nil
+}
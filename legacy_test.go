@@ -0,0 +1,62 @@
+package uuid25
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// Tests reading and writing through LegacyAdapter while switching formats.
+func TestLegacyAdapter(t *testing.T) {
+	a := NewLegacyAdapter(FormatHyphenated)
+	const id = Uuid25("dpoadk8izg9y4tte7vy1xt94o")
+
+	var x Uuid25
+	if a.Scanner(&x).Scan("e7a1d63b-7117-4423-8988-afcf12161878") != nil || x != id || a.LegacyReads() != 0 {
+		t.Fail()
+	}
+	if a.Scanner(&x).Scan([]byte("dpoadk8izg9y4tte7vy1xt94o")) != nil || x != id || a.LegacyReads() != 1 {
+		t.Fail()
+	}
+	if v, err := a.Wrap(id).Value(); v != "e7a1d63b-7117-4423-8988-afcf12161878" || err != nil {
+		t.Fail()
+	}
+
+	a.SetWriteFormat(FormatUuid25)
+	if a.WriteFormat() != FormatUuid25 {
+		t.Fail()
+	}
+	if v, err := a.Wrap(id).Value(); v != string(id) || err != nil {
+		t.Fail()
+	}
+	if a.Scanner(&x).Scan("e7a1d63b-7117-4423-8988-afcf12161878") != nil || a.LegacyReads() != 2 {
+		t.Fail()
+	}
+	if a.Scanner(&x).Scan("DPOADK8IZG9Y4TTE7VY1XT94O") != nil || a.LegacyReads() != 3 {
+		t.Fail()
+	}
+	if a.Scanner(&x).Scan("foo") == nil || a.Scanner(&x).Scan(42) != ErrUnsupportedType || a.LegacyReads() != 3 {
+		t.Fail()
+	}
+}
+
+// Tests the JSON and text encodings of LegacyValue.
+func TestLegacyValueJSON(t *testing.T) {
+	a := NewLegacyAdapter(FormatBraced)
+	data, err := json.Marshal(map[string]any{"id": a.Wrap("dpoadk8izg9y4tte7vy1xt94o")})
+	if err != nil || string(data) != `{"id":"{e7a1d63b-7117-4423-8988-afcf12161878}"}` {
+		t.Fail()
+	}
+	var decoded struct{ ID Uuid25 }
+	if json.Unmarshal(data, &decoded) != nil || decoded.ID != "dpoadk8izg9y4tte7vy1xt94o" {
+		t.Fail()
+	}
+	if a.Wrap("dpoadk8izg9y4tte7vy1xt94o").String() != "{e7a1d63b-7117-4423-8988-afcf12161878}" {
+		t.Fail()
+	}
+	if _, err := json.Marshal(a.Wrap("")); err == nil {
+		t.Fail()
+	}
+	if _, err := a.Wrap("").Value(); err == nil {
+		t.Fail()
+	}
+}
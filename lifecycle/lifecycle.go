@@ -0,0 +1,186 @@
+// State tracking for pre-allocated IDs
+//
+// This package tracks the lifecycle of IDs that are allocated before the
+// records owning them exist, such as IDs handed out to clients for uploads or
+// offline edits. An ID is first reserved, then committed once the owning record
+// is stored, and finally tombstoned when the record is deleted or the
+// reservation is abandoned:
+//
+//	reserved → committed → tombstoned
+//	reserved → tombstoned
+//
+// Tombstoned IDs are never reserved again. States are persisted in a pluggable
+// Store so that reservations survive restarts and are shared among processes.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// The lifecycle state of an ID.
+type State int
+
+const (
+	// The state of an ID that has not been recorded.
+	Unknown State = iota
+
+	// The state of an ID allocated for a record that does not exist yet.
+	Reserved
+
+	// The state of an ID whose owning record exists.
+	Committed
+
+	// The terminal state of an ID whose record has been deleted or whose
+	// reservation has been abandoned.
+	Tombstoned
+)
+
+var stateNames = [...]string{"unknown", "reserved", "committed", "tombstoned"}
+
+// Returns the name of the state, such as "reserved".
+func (s State) String() string {
+	if s < 0 || int(s) >= len(stateNames) {
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+	return stateNames[s]
+}
+
+// An error returned when a transition is not allowed from the current state
+// of an ID.
+var ErrInvalidTransition = errors.New("invalid state transition")
+
+// Reports whether an ID can transition from state `from` to state `to`.
+func CanTransition(from, to State) bool {
+	switch to {
+	case Reserved:
+		return from == Unknown
+	case Committed:
+		return from == Reserved
+	case Tombstoned:
+		return from == Reserved || from == Committed
+	default:
+		return false
+	}
+}
+
+// A persistent storage of ID states.
+//
+// Implementations backed by SQL databases (`UPDATE ... WHERE id = ? AND
+// state = ?`), Redis, or other shared storages must perform the comparison and
+// the update atomically.
+type Store interface {
+	// Returns the state of an ID, or Unknown if the ID has not been recorded.
+	Load(ctx context.Context, id uuid25.Uuid25) (State, error)
+
+	// Sets the state of an ID to `to` if its current state is `from` and
+	// reports whether the state was updated. The current state of an ID that
+	// has not been recorded is Unknown.
+	CompareAndSwap(ctx context.Context, id uuid25.Uuid25, from, to State) (bool, error)
+}
+
+// Generates a new UUIDv7 and records it as reserved.
+func ReserveNew(ctx context.Context, store Store) (uuid25.Uuid25, error) {
+	for {
+		id := uuid25.NewV7()
+		ok, err := store.CompareAndSwap(ctx, id, Unknown, Reserved)
+		if err != nil {
+			return "", err
+		} else if ok {
+			return id, nil
+		}
+	}
+}
+
+// Records an ID supplied by the caller as reserved.
+//
+// This function returns an error wrapping ErrInvalidTransition if the ID has
+// already been recorded.
+func Reserve(ctx context.Context, store Store, id uuid25.Uuid25) error {
+	return transition(ctx, store, id, Reserved)
+}
+
+// Marks a reserved ID as committed.
+//
+// This function returns an error wrapping ErrInvalidTransition if the ID is
+// not reserved.
+func Commit(ctx context.Context, store Store, id uuid25.Uuid25) error {
+	return transition(ctx, store, id, Committed)
+}
+
+// Marks a reserved or committed ID as tombstoned.
+//
+// This function returns an error wrapping ErrInvalidTransition if the ID is
+// neither reserved nor committed.
+func Tombstone(ctx context.Context, store Store, id uuid25.Uuid25) error {
+	return transition(ctx, store, id, Tombstoned)
+}
+
+// Moves an ID to state `to`, retrying if the state is changed concurrently.
+func transition(ctx context.Context, store Store, id uuid25.Uuid25, to State) error {
+	for {
+		from, err := store.Load(ctx, id)
+		if err != nil {
+			return err
+		} else if !CanTransition(from, to) {
+			return fmt.Errorf("%w: %s from %v to %v", ErrInvalidTransition, id, from, to)
+		}
+		ok, err := store.CompareAndSwap(ctx, id, from, to)
+		if err != nil {
+			return err
+		} else if ok {
+			return nil
+		}
+	}
+}
+
+// An in-memory Store for tests and single-process services.
+//
+// The zero value is an empty store ready to use. A store is safe for
+// concurrent use.
+type MemoryStore struct {
+	mu     sync.Mutex
+	states map[uuid25.Uuid25]State
+}
+
+// Implements the Store interface.
+func (s *MemoryStore) Load(ctx context.Context, id uuid25.Uuid25) (State, error) {
+	if err := ctx.Err(); err != nil {
+		return Unknown, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.states[id], nil
+}
+
+// Implements the Store interface.
+func (s *MemoryStore) CompareAndSwap(ctx context.Context, id uuid25.Uuid25, from, to State) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.states[id] != from {
+		return false, nil
+	}
+	if s.states == nil {
+		s.states = make(map[uuid25.Uuid25]State)
+	}
+	s.states[id] = to
+	return true, nil
+}
+
+// Returns the number of recorded IDs in each state.
+func (s *MemoryStore) Count() map[State]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make(map[State]int)
+	for _, state := range s.states {
+		counts[state] += 1
+	}
+	return counts
+}
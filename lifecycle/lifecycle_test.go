@@ -0,0 +1,92 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests the allowed and rejected transitions with MemoryStore.
+func TestTransitions(t *testing.T) {
+	ctx := context.Background()
+	store := &MemoryStore{}
+
+	id, err := ReserveNew(ctx, store)
+	if err != nil || id.Version() != 7 {
+		t.Fail()
+	}
+	if state, _ := store.Load(ctx, id); state != Reserved {
+		t.Fail()
+	}
+	if !errors.Is(Reserve(ctx, store, id), ErrInvalidTransition) {
+		t.Fail()
+	}
+	if Commit(ctx, store, id) != nil || !errors.Is(Commit(ctx, store, id), ErrInvalidTransition) {
+		t.Fail()
+	}
+	if Tombstone(ctx, store, id) != nil {
+		t.Fail()
+	}
+	if !errors.Is(Reserve(ctx, store, id), ErrInvalidTransition) ||
+		!errors.Is(Tombstone(ctx, store, id), ErrInvalidTransition) {
+		t.Fail()
+	}
+
+	abandoned := uuid25.Const("3ud3gtvgolimgu9lah6aie99o")
+	if !errors.Is(Commit(ctx, store, abandoned), ErrInvalidTransition) {
+		t.Fail()
+	}
+	if Reserve(ctx, store, abandoned) != nil || Tombstone(ctx, store, abandoned) != nil {
+		t.Fail()
+	}
+
+	counts := store.Count()
+	if len(counts) != 1 || counts[Tombstoned] != 2 {
+		t.Fail()
+	}
+	if Reserved.String() != "reserved" || State(9).String() != "State(9)" {
+		t.Fail()
+	}
+}
+
+// Tests that exactly one of concurrent transitions succeeds.
+func TestConcurrentCommit(t *testing.T) {
+	ctx := context.Background()
+	store := &MemoryStore{}
+	id, _ := ReserveNew(ctx, store)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < 16; i += 1 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if Commit(ctx, store, id) == nil {
+				mu.Lock()
+				succeeded += 1
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if succeeded != 1 {
+		t.Fail()
+	}
+}
+
+// Tests that store errors are returned as they are.
+func TestContextError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	store := &MemoryStore{}
+	if _, err := ReserveNew(ctx, store); err != context.Canceled {
+		t.Fail()
+	}
+	if err := Commit(ctx, store, uuid25.NewV4()); err != context.Canceled {
+		t.Fail()
+	}
+}
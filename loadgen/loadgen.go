@@ -0,0 +1,170 @@
+// Generator of UUID strings in configurable mixes for load tests
+//
+// This package emits streams of UUID strings that mix random (version 4) and
+// time-ordered (version 7) UUIDs, several string formats, and malformed strings
+// at configurable rates, so that parsers, validation layers, and database
+// indexes can be exercised under realistic traffic shapes. The output is
+// reproducible for a given seed and clock.
+package loadgen
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// The composition of the generated stream. Weights are relative to each other
+// and need not sum to one.
+type Mix struct {
+	// The relative weight of version 4 UUIDs.
+	V4 float64
+
+	// The relative weight of version 7 UUIDs.
+	V7 float64
+
+	// The fraction, within 0 to 1, of malformed strings in the stream.
+	Malformed float64
+
+	// The relative weights of the output formats. FormatInvalid is ignored. If
+	// empty, all valid IDs are emitted in the Uuid25 format.
+	Formats map[uuid25.Format]float64
+
+	// The clock for the timestamps of version 7 UUIDs. The default is
+	// time.Now.
+	Now func() time.Time
+}
+
+// An element of the generated stream.
+type Sample struct {
+	// The generated string.
+	Text string
+
+	// The UUID represented by Text, or the zero value if Malformed is true.
+	ID uuid25.Uuid25
+
+	// The version of the UUID, or 0 if Malformed is true.
+	Version int
+
+	// Whether Text is a deliberately malformed string.
+	Malformed bool
+}
+
+// A generator of a UUID stream. It is not safe for concurrent use; create one
+// generator per goroutine with distinct seeds instead.
+type Generator struct {
+	rand    *rand.Rand
+	mix     Mix
+	formats []uuid25.Format
+	weights []float64
+}
+
+// Creates a generator that emits a stream of the mix, seeded with `seed`.
+//
+// This function returns an error if a weight is negative, both version weights
+// are zero, or the malformed fraction is out of range.
+func NewGenerator(mix Mix, seed int64) (*Generator, error) {
+	if mix.V4 < 0 || mix.V7 < 0 || mix.V4+mix.V7 == 0 {
+		return nil, errors.New("invalid version weights")
+	} else if mix.Malformed < 0 || mix.Malformed > 1 {
+		return nil, errors.New("malformed fraction out of range")
+	}
+	if mix.Now == nil {
+		mix.Now = time.Now
+	}
+
+	g := &Generator{rand: rand.New(rand.NewSource(seed)), mix: mix}
+	for f := uuid25.FormatUuid25; f <= uuid25.FormatUrn; f += 1 {
+		if w := mix.Formats[f]; w < 0 {
+			return nil, errors.New("invalid format weight")
+		} else if w > 0 {
+			g.formats = append(g.formats, f)
+			g.weights = append(g.weights, w)
+		}
+	}
+	if len(g.formats) == 0 {
+		g.formats, g.weights = []uuid25.Format{uuid25.FormatUuid25}, []float64{1}
+	}
+	return g, nil
+}
+
+// Returns the next element of the stream.
+func (g *Generator) Next() Sample {
+	if g.rand.Float64() < g.mix.Malformed {
+		return Sample{Text: g.malformed(), Malformed: true}
+	}
+
+	var b [16]byte
+	g.rand.Read(b[:])
+	version := 4
+	if g.rand.Float64()*(g.mix.V4+g.mix.V7) >= g.mix.V4 {
+		version = 7
+		ms := uint64(g.mix.Now().UnixMilli())
+		for i := 0; i < 6; i += 1 {
+			b[i] = byte(ms >> (40 - 8*i))
+		}
+	}
+	b[6] = byte(version<<4) | b[6]&0x0f
+	b[8] = 0x80 | b[8]&0x3f
+	id := uuid25.FromBytes(b[:])
+
+	var text string
+	switch g.formats[g.pick(g.weights)] {
+	case uuid25.FormatHex:
+		text = id.ToHex()
+	case uuid25.FormatHyphenated:
+		text = id.ToHyphenated()
+	case uuid25.FormatBraced:
+		text = id.ToBraced()
+	case uuid25.FormatUrn:
+		text = id.ToUrn()
+	default:
+		text = id.String()
+	}
+	return Sample{Text: text, ID: id, Version: version}
+}
+
+// Returns an index chosen randomly in proportion to the weights.
+func (g *Generator) pick(weights []float64) int {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	x := g.rand.Float64() * total
+	for i, w := range weights {
+		if x < w {
+			return i
+		}
+		x -= w
+	}
+	return len(weights) - 1
+}
+
+// Returns a malformed string that resembles a UUID.
+func (g *Generator) malformed() string {
+	valid := []byte(uuid25.FromBytes(g.randomBytes()).ToHyphenated())
+	switch g.rand.Intn(6) {
+	case 0: // truncated
+		return string(valid[:g.rand.Intn(len(valid))])
+	case 1: // invalid character
+		valid[g.rand.Intn(len(valid))] = "gxz!_ "[g.rand.Intn(6)]
+		return string(valid)
+	case 2: // misplaced hyphen
+		i := g.rand.Intn(8)
+		valid[i], valid[8] = valid[8], valid[i]
+		return string(valid)
+	case 3: // Base36 value exceeding 128 bits
+		return "zzzzzzzzzzzzzzzzzzzzzzzzz"
+	case 4: // extra trailing character
+		return string(valid) + "0"
+	default: // unsupported format
+		return "0x" + uuid25.FromBytes(g.randomBytes()).ToHex()
+	}
+}
+
+func (g *Generator) randomBytes() []byte {
+	b := make([]byte, 16)
+	g.rand.Read(b)
+	return b
+}
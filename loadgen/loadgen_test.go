@@ -0,0 +1,96 @@
+package loadgen
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests that the stream follows the configured mix.
+func TestGenerator(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	mix := Mix{
+		V4:        1,
+		V7:        3,
+		Malformed: 0.1,
+		Formats:   map[uuid25.Format]float64{uuid25.FormatUuid25: 1, uuid25.FormatHyphenated: 1},
+		Now:       func() time.Time { return now },
+	}
+	g, err := NewGenerator(mix, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 20000
+	counts := map[string]int{}
+	for i := 0; i < n; i += 1 {
+		s := g.Next()
+		if s.Malformed {
+			counts["malformed"] += 1
+			if _, err := uuid25.Parse(s.Text); err == nil {
+				t.Fatalf("malformed sample must not parse: %q", s.Text)
+			}
+			continue
+		}
+
+		id, err := uuid25.Parse(s.Text)
+		if err != nil || id != s.ID {
+			t.Fatalf("invalid sample: %+v", s)
+		}
+		b := id.ToBytes()
+		if int(b[6]>>4) != s.Version || b[8]>>6 != 2 {
+			t.Fatalf("invalid version or variant: %+v", s)
+		}
+		if s.Version == 7 {
+			if tm, err := id.Time(); err != nil || !tm.Equal(now) {
+				t.Fatalf("invalid timestamp: %+v", s)
+			}
+		}
+		counts[uuid25.DetectFormat(s.Text).String()] += 1
+		if s.Version == 4 {
+			counts["v4"] += 1
+		} else {
+			counts["v7"] += 1
+		}
+	}
+
+	approx := func(key string, expected float64) {
+		if math.Abs(float64(counts[key])-expected)/expected > 0.05 {
+			t.Errorf("unexpected count of %s: %d", key, counts[key])
+		}
+	}
+	approx("malformed", n*0.1)
+	approx("v4", n*0.9*0.25)
+	approx("v7", n*0.9*0.75)
+	approx("uuid25", n*0.9*0.5)
+	approx("hyphenated", n*0.9*0.5)
+
+	// the same seed must reproduce the same stream
+	g1, _ := NewGenerator(mix, 42)
+	g2, _ := NewGenerator(mix, 42)
+	for i := 0; i < 100; i += 1 {
+		if g1.Next() != g2.Next() {
+			t.Fatal("stream must be reproducible")
+		}
+	}
+}
+
+// Tests validation of mixes.
+func TestNewGeneratorErr(t *testing.T) {
+	cases := []Mix{
+		{},
+		{V4: -1, V7: 2},
+		{V4: 1, Malformed: 1.5},
+		{V4: 1, Formats: map[uuid25.Format]float64{uuid25.FormatHex: -1}},
+	}
+	for _, e := range cases {
+		if _, err := NewGenerator(e, 1); err == nil {
+			t.Errorf("mix must be rejected: %+v", e)
+		}
+	}
+	if g, err := NewGenerator(Mix{V4: 1}, 1); err != nil || uuid25.DetectFormat(g.Next().Text) != uuid25.FormatUuid25 {
+		t.Fail()
+	}
+}
@@ -0,0 +1,42 @@
+package uuid25
+
+// Returns the keys of a map in unspecified order.
+func KeysOf[T any](m map[Uuid25]T) []Uuid25 {
+	keys := make([]Uuid25, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Returns the IDs extracted from items by `key` in the order of the items.
+func IDsOf[T any](items []T, key func(T) Uuid25) []Uuid25 {
+	ids := make([]Uuid25, len(items))
+	for i, e := range items {
+		ids[i] = key(e)
+	}
+	return ids
+}
+
+// Returns a map from the ID of each item, as extracted by `key`, to the item.
+//
+// The map is allocated with the capacity for all items to avoid rehashing
+// while it grows. If multiple items share an ID, the last one wins.
+func IndexBy[T any](items []T, key func(T) Uuid25) map[Uuid25]T {
+	m := make(map[Uuid25]T, len(items))
+	for _, e := range items {
+		m[key(e)] = e
+	}
+	return m
+}
+
+// Returns a map from each ID, as extracted by `key`, to the items sharing the
+// ID in the order of the items.
+func GroupBy[T any](items []T, key func(T) Uuid25) map[Uuid25][]T {
+	m := make(map[Uuid25][]T)
+	for _, e := range items {
+		k := key(e)
+		m[k] = append(m[k], e)
+	}
+	return m
+}
@@ -0,0 +1,55 @@
+package uuid25
+
+import (
+	"sort"
+	"testing"
+)
+
+// Tests the generic map helpers.
+func TestMapHelpers(t *testing.T) {
+	type item struct {
+		id    Uuid25
+		value int
+	}
+	var items []item
+	for i, e := range testCases {
+		x, _ := Parse(e.uuid25)
+		items = append(items, item{x, i}, item{x, -i})
+	}
+	id := func(e item) Uuid25 { return e.id }
+
+	index := IndexBy(items, id)
+	if len(index) != len(testCases) {
+		t.Fatal("IndexBy must map each ID once")
+	}
+	for i, e := range testCases {
+		if x := index[Uuid25(e.uuid25)]; x.value != -i {
+			t.Fail()
+		}
+	}
+
+	groups := GroupBy(items, id)
+	for i, e := range testCases {
+		if g := groups[Uuid25(e.uuid25)]; len(g) != 2 || g[0].value != i || g[1].value != -i {
+			t.Fail()
+		}
+	}
+
+	ids := IDsOf(items, id)
+	if len(ids) != len(items) || ids[0] != items[0].id || ids[len(ids)-1] != items[len(items)-1].id {
+		t.Fail()
+	}
+
+	keys := KeysOf(index)
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	expected := Uuid25Slice(ids).Dedup()
+	expected.Sort()
+	if len(keys) != len(expected) {
+		t.Fatal("KeysOf must return all keys")
+	}
+	for i := range keys {
+		if keys[i] != expected[i] {
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package uuid25
+
+import "crypto/sha1"
+
+// Creates a name-based UUIDv5 from the SHA-1 hash of `namespace` followed by
+// `name`, as specified in RFC 9562.
+func newV5(namespace Uuid25, name []byte) Uuid25 {
+	h := sha1.New()
+	ns := namespace.ToBytes()
+	h.Write(ns[:])
+	h.Write(name)
+	var b [20]byte
+	sum := h.Sum(b[:0])
+	sum[6] = 0x50 | sum[6]&0x0f
+	sum[8] = 0x80 | sum[8]&0x3f
+	return FromBytes(sum[:16])
+}
+
+// Returns the namespace of IDs of `kind`, such as `"invoice"`, owned by
+// `tenant`.
+//
+// The result is a UUIDv5 that uses the tenant ID as the namespace and the UTF-8
+// bytes of `kind` as the name. Passing it as the namespace of further UUIDv5
+// derivations yields deterministic IDs that are unique across tenants and
+// kinds even if the same names are used.
+func NamespaceFor(tenant Uuid25, kind string) Uuid25 {
+	return newV5(tenant, []byte(kind))
+}
@@ -0,0 +1,28 @@
+package uuid25
+
+import "testing"
+
+// Tests newV5 against the RFC 9562 example of the DNS namespace.
+func TestNewV5(t *testing.T) {
+	dns, _ := Parse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	x := newV5(dns, []byte("www.example.com"))
+	if x.ToHyphenated() != "2ed6657d-e927-568b-95e1-2665a8aea6a2" {
+		t.Fail()
+	}
+}
+
+// Tests that NamespaceFor separates tenants and kinds.
+func TestNamespaceFor(t *testing.T) {
+	a, _ := Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	b, _ := Parse("40eb9860-cf3e-45e2-a90e-b82236ac806c")
+	x := NamespaceFor(a, "invoice")
+	if x != newV5(a, []byte("invoice")) || x != NamespaceFor(a, "invoice") {
+		t.Fail()
+	}
+	if x == NamespaceFor(b, "invoice") || x == NamespaceFor(a, "order") {
+		t.Fail()
+	}
+	if b := x.ToBytes(); b[6]>>4 != 5 || b[8]>>6 != 0b10 {
+		t.Fail()
+	}
+}
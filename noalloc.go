@@ -0,0 +1,58 @@
+package uuid25
+
+import "encoding/binary"
+
+// Parses a UUID string in any format accepted by Parse() into the 16-byte binary
+// representation without allocating.
+//
+// This function performs the same validation as Parse() and is intended for
+// hot paths, such as request routers and storage engines, that only need the
+// binary representation, as well as for benchmarks that measure the cost of
+// parsing alone.
+func ParseNoAlloc(uuidString string) ([16]byte, error) {
+	var uuidBytes [16]byte
+	x, err := decodeAny(uuidString)
+	if err != nil {
+		return uuidBytes, err
+	}
+	binary.BigEndian.PutUint64(uuidBytes[:8], x.Hi)
+	binary.BigEndian.PutUint64(uuidBytes[8:], x.Lo)
+	return uuidBytes, nil
+}
+
+// Appends the representation of this type in the format `f` to `dst` and
+// returns the extended buffer.
+//
+// This method does not allocate if `dst` has enough capacity (MaxInputLen bytes
+// suffice for every format). It panics if `f` is not a valid format or the
+// receiver is not constructed properly.
+func (uuid25 Uuid25) AppendFormat(dst []byte, f Format) []byte {
+	if f == FormatUuid25 {
+		return append(dst, uuid25.String()...)
+	}
+	x := uuid25.toUint128()
+	var hex [32]byte
+	putHex(hex[:16], x.Hi)
+	putHex(hex[16:], x.Lo)
+	switch f {
+	case FormatHex:
+		return append(dst, hex[:]...)
+	case FormatHyphenated:
+		return appendHyphenated(dst, &hex)
+	case FormatBraced:
+		return append(appendHyphenated(append(dst, '{'), &hex), '}')
+	case FormatUrn:
+		return appendHyphenated(append(dst, "urn:uuid:"...), &hex)
+	default:
+		panic("invalid format")
+	}
+}
+
+// Appends 32 hexadecimal digits in the 8-4-4-4-12 hyphenated format.
+func appendHyphenated(dst []byte, hex *[32]byte) []byte {
+	dst = append(dst, hex[:8]...)
+	dst = append(append(dst, '-'), hex[8:12]...)
+	dst = append(append(dst, '-'), hex[12:16]...)
+	dst = append(append(dst, '-'), hex[16:20]...)
+	return append(append(dst, '-'), hex[20:]...)
+}
@@ -0,0 +1,70 @@
+package uuid25
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// Tests parsing without allocation.
+func TestParseNoAlloc(t *testing.T) {
+	for _, e := range testCases {
+		for _, s := range []string{e.uuid25, e.hex, e.hyphenated, e.braced, e.urn} {
+			if b, err := ParseNoAlloc(s); err != nil || !bytes.Equal(b[:], e.bytes) {
+				t.Errorf("unexpected result for %s", s)
+			}
+		}
+	}
+	for _, s := range []string{"", "f5lxx1zz5pnorynqglhzmsp34", "urn:uuid:40eb9860-cf3e-45e2-a90e-b82236ac806"} {
+		if _, err := ParseNoAlloc(s); !errors.Is(err, ErrParse) {
+			t.Errorf("%q must be rejected", s)
+		}
+	}
+
+	s := testCases[0].hyphenated
+	if n := testing.AllocsPerRun(100, func() { ParseNoAlloc(s) }); n != 0 {
+		t.Errorf("unexpected allocations: %v", n)
+	}
+}
+
+// Tests appending formatted representations.
+func TestAppendFormat(t *testing.T) {
+	for _, e := range testCases {
+		x := Uuid25(e.uuid25)
+		expected := map[Format]string{
+			FormatUuid25:     e.uuid25,
+			FormatHex:        e.hex,
+			FormatHyphenated: e.hyphenated,
+			FormatBraced:     e.braced,
+			FormatUrn:        e.urn,
+		}
+		for f, s := range expected {
+			if got := x.AppendFormat([]byte("x"), f); string(got) != "x"+s {
+				t.Errorf("unexpected %v of %s: %s", f, e.uuid25, got)
+			}
+		}
+	}
+
+	x := Uuid25(testCases[0].uuid25)
+	buffer := make([]byte, 0, MaxInputLen)
+	if n := testing.AllocsPerRun(100, func() { x.AppendFormat(buffer, FormatUrn) }); n != 0 {
+		t.Errorf("unexpected allocations: %v", n)
+	}
+}
+
+// Benchmarks ParseNoAlloc() with the hyphenated format.
+func BenchmarkParseNoAlloc(b *testing.B) {
+	s := testCases[0].hyphenated
+	for i := 0; i < b.N; i += 1 {
+		ParseNoAlloc(s)
+	}
+}
+
+// Benchmarks AppendFormat() with the hyphenated format.
+func BenchmarkAppendFormat(b *testing.B) {
+	x := Uuid25(testCases[0].uuid25)
+	buffer := make([]byte, 0, MaxInputLen)
+	for i := 0; i < b.N; i += 1 {
+		x.AppendFormat(buffer, FormatHyphenated)
+	}
+}
@@ -0,0 +1,120 @@
+package uuid25
+
+import (
+	"database/sql/driver"
+	"errors"
+)
+
+// A Uuid25 variant that represents SQL NULL with the Nil UUID.
+//
+// This type implements the sql.Scanner and driver.Valuer interfaces so that the
+// Nil UUID (and the zero value) is written as NULL and NULL is read as the Nil
+// UUID, which suits schemas that use NULL for absent references while the
+// application uses the Nil UUID. Convert between this type and Uuid25 with a
+// plain type conversion:
+//
+//	var ref uuid25.Uuid25
+//	err := row.Scan((*uuid25.NilAsNull)(&ref))
+//	_, err = db.Exec(query, uuid25.NilAsNull(ref))
+type NilAsNull Uuid25
+
+// Implements the sql.Scanner interface.
+//
+// This method accepts the same types as Uuid25.Scan() as well as nil.
+func (n *NilAsNull) Scan(src any) error {
+	if n == nil {
+		return errors.New("nil receiver")
+	} else if src == nil {
+		*n = NilAsNull(Nil)
+		return nil
+	}
+	return (*Uuid25)(n).Scan(src)
+}
+
+// Implements the driver.Valuer interface.
+func (n NilAsNull) Value() (driver.Value, error) {
+	if n == "" || n == NilAsNull(Nil) {
+		return nil, nil
+	}
+	return Uuid25(n).Value()
+}
+
+// A Uuid25 value that may be null, for nullable database columns and optional
+// JSON fields.
+//
+// This type is modeled after sql.NullString: Valid is false if the value is SQL
+// NULL or JSON null, in which case Uuid25 is the zero value. Unlike NilAsNull,
+// it keeps the Nil UUID and NULL distinct.
+type NullUuid25 struct {
+	Uuid25 Uuid25
+	Valid  bool
+}
+
+// Implements the sql.Scanner interface.
+//
+// This method accepts the same types as Uuid25.Scan() as well as nil.
+func (n *NullUuid25) Scan(src any) error {
+	if n == nil {
+		return errors.New("nil receiver")
+	} else if src == nil {
+		*n = NullUuid25{}
+		return nil
+	}
+	err := n.Uuid25.Scan(src)
+	n.Valid = err == nil
+	return err
+}
+
+// Implements the driver.Valuer interface.
+func (n NullUuid25) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Uuid25.Value()
+}
+
+// Implements the encoding.TextMarshaler interface, encoding a null value as
+// an empty text.
+func (n NullUuid25) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	return n.Uuid25.MarshalText()
+}
+
+// Implements the encoding.TextUnmarshaler interface, decoding an empty text as
+// a null value.
+func (n *NullUuid25) UnmarshalText(text []byte) error {
+	if n == nil {
+		return errors.New("nil receiver")
+	} else if len(text) == 0 {
+		*n = NullUuid25{}
+		return nil
+	}
+	err := n.Uuid25.UnmarshalText(text)
+	n.Valid = err == nil
+	return err
+}
+
+// Implements the json.Marshaler interface, encoding a null value as a JSON
+// null.
+func (n NullUuid25) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return marshalJSONString(n.Uuid25)
+}
+
+// Implements the json.Unmarshaler interface, decoding a JSON null as a null
+// value.
+func (n *NullUuid25) UnmarshalJSON(data []byte) error {
+	if n == nil {
+		return errors.New("nil receiver")
+	} else if string(data) == "null" {
+		*n = NullUuid25{}
+		return nil
+	}
+	err := n.Uuid25.unmarshalJSONString(data)
+	n.Valid = err == nil
+	return err
+}
@@ -0,0 +1,116 @@
+package uuid25
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Tests the mapping between the Nil UUID and SQL NULL.
+func TestNilAsNull(t *testing.T) {
+	var x NilAsNull
+	if x.Scan(nil) != nil || Uuid25(x) != Nil {
+		t.Fail()
+	}
+	if v, err := x.Value(); v != nil || err != nil {
+		t.Fail()
+	}
+	if v, err := NilAsNull("").Value(); v != nil || err != nil {
+		t.Fail()
+	}
+
+	for _, e := range testCases {
+		if x.Scan(e.hyphenated) != nil || string(x) != e.uuid25 {
+			t.Fail()
+		}
+		v, err := x.Value()
+		if err != nil || (e.uuid25 == string(Nil)) != (v == nil) {
+			t.Fail()
+		}
+	}
+
+	var ref Uuid25
+	if (*NilAsNull)(&ref).Scan(nil) != nil || ref != Nil {
+		t.Fail()
+	}
+	if x.Scan(42) == nil {
+		t.Fail()
+	}
+
+	var _ sql.Scanner = &x
+	var _ driver.Valuer = x
+}
+
+// Tests the SQL, text, and JSON round trips of NullUuid25.
+func TestNullUuid25(t *testing.T) {
+	var x NullUuid25
+	if x.Scan(nil) != nil || x.Valid {
+		t.Fail()
+	}
+	if v, err := x.Value(); v != nil || err != nil {
+		t.Fail()
+	}
+	for _, e := range testCases {
+		if x.Scan(e.hyphenated) != nil || !x.Valid || string(x.Uuid25) != e.uuid25 {
+			t.Fail()
+		}
+		if v, err := x.Value(); err != nil || v != e.uuid25 {
+			t.Fail()
+		}
+	}
+	if x.Scan("bogus") == nil || x.Valid {
+		t.Fail()
+	}
+
+	type record struct {
+		ID  NullUuid25  `json:"id"`
+		Ref NullUuid25  `json:"ref"`
+		Opt *NullUuid25 `json:"opt,omitempty"`
+	}
+	nilID := NullUuid25{Nil, true}
+	data, err := json.Marshal(record{ID: nilID})
+	if err != nil || string(data) != `{"id":"0000000000000000000000000","ref":null}` {
+		t.Errorf("unexpected JSON: %s %v", data, err)
+	}
+	var r record
+	err = json.Unmarshal([]byte(`{"id":"{00000000-0000-0000-0000-000000000000}","ref":null}`), &r)
+	if err != nil || r.ID != nilID || r.Ref.Valid {
+		t.Fail()
+	}
+	if json.Unmarshal([]byte(`{"id":"bogus"}`), &r) == nil || json.Unmarshal([]byte(`{"id":1}`), &r) == nil {
+		t.Fail()
+	}
+
+	if text, err := (NullUuid25{}).MarshalText(); err != nil || len(text) != 0 {
+		t.Fail()
+	}
+	if text, err := nilID.MarshalText(); err != nil || string(text) != string(Nil) {
+		t.Fail()
+	}
+	if x.UnmarshalText([]byte("")) != nil || x.Valid {
+		t.Fail()
+	}
+	if x.UnmarshalText([]byte("3ud3gtvgolimgu9lah6aie99o")) != nil || !x.Valid {
+		t.Fail()
+	}
+	if _, err := (NullUuid25{Valid: true}).MarshalJSON(); err == nil {
+		t.Fail()
+	}
+	escaped := `"\u0033ud3gtvgolimgu9lah6aie99o"`
+	if x.UnmarshalJSON([]byte(escaped)) != nil || !x.Valid || x.Uuid25 != "3ud3gtvgolimgu9lah6aie99o" {
+		t.Fail()
+	}
+	long := `"` + strings.Repeat(" ", 6*MaxInputLen+1) + `"`
+	if !errors.Is(x.UnmarshalJSON([]byte(long)), ErrInputTooLong) || x.Valid {
+		t.Fail()
+	}
+
+	var _ sql.Scanner = &x
+	var _ driver.Valuer = x
+	var _ encoding.TextMarshaler = x
+	var _ json.Unmarshaler = &x
+}
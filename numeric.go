@@ -0,0 +1,45 @@
+package uuid25
+
+import "math"
+
+// Returns the 128-bit value of the UUID as a float64, rounded to the nearest
+// representable value.
+//
+// The result ranges from 0 to 2^128 and preserves the order of UUIDs except
+// that UUIDs differing only in the low-order bits may map to the same value,
+// since a float64 holds only 53 significant bits. It is intended for
+// monitoring systems that accept only floating-point samples, for example to
+// plot the distribution of IDs; divide it by 2^128 (math.Ldexp(f, -128)) for a
+// fraction in [0, 1]. Do not use the result as an identifier.
+//
+// This method panics if the receiver is not constructed properly.
+func (uuid25 Uuid25) ToFloat64Lossy() float64 {
+	x := uuid25.toUint128()
+	if x.Hi == 0 {
+		return float64(x.Lo)
+	}
+	// normalize to 64 significant bits, keeping a sticky bit for the discarded
+	// bits so that the conversion below rounds correctly
+	n := x.LeadingZeros()
+	y := x.Lsh(uint(n))
+	top := y.Hi
+	if y.Lo != 0 {
+		top |= 1
+	}
+	return math.Ldexp(float64(top), 64-n)
+}
+
+// Returns the least significant 64 bits of the 128-bit value of the UUID.
+//
+// For UUIDv4, the result consists of the two variant bits `0b10` followed by
+// 62 random bits. For UUIDv7 values generated by NewV7() and V7Generator, the
+// variant bits are followed by the low 30 bits of the counter, which is
+// incremented within a millisecond, and 32 random bits, so the upper bits of
+// the results of IDs created in a burst are sequential. To spread IDs across
+// hash buckets and shards, use Uint128.Hash64() or FanoutPrefix() instead. The
+// truncation loses information, so distinct UUIDs may map to the same value.
+//
+// This method panics if the receiver is not constructed properly.
+func (uuid25 Uuid25) ToUint64Truncated() uint64 {
+	return uuid25.toUint128().Lo
+}
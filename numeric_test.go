@@ -0,0 +1,49 @@
+package uuid25
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// Tests ToFloat64Lossy() against the correctly rounded conversion of math/big.
+func TestToFloat64Lossy(t *testing.T) {
+	ids := []Uuid25{
+		Nil,
+		MustParse("00000000-0000-0000-ffff-ffffffffffff"),
+		MustParse("00000000-0000-0001-0000-000000000000"),
+		MustParse("00000000-0000-0001-0000-000000000001"),
+		MustParse("00000000-0000-0000-0020-000000000001"),
+		MustParse("00000000-0020-0000-0000-000000000001"),
+		MustParse("00000000-0030-0000-0000-000000000000"),
+		MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff"),
+	}
+	for _, e := range testCases {
+		ids = append(ids, Uuid25(e.uuid25))
+	}
+	for i := 0; i < 256; i += 1 {
+		ids = append(ids, NewV4(), NewV7())
+	}
+
+	for _, id := range ids {
+		b := id.ToBytes()
+		x := new(big.Int).SetBytes(b[:])
+		expected, _ := new(big.Float).SetInt(x).Float64()
+		if got := id.ToFloat64Lossy(); got != expected {
+			t.Errorf("unexpected result for %s: %g != %g", id.ToHex(), got, expected)
+		}
+	}
+	if MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff").ToFloat64Lossy() != math.Ldexp(1, 128) {
+		t.Fail()
+	}
+}
+
+// Tests that ToUint64Truncated() returns the least significant 64 bits.
+func TestToUint64Truncated(t *testing.T) {
+	if MustParse("01809424-3e59-7c05-9219-566f82fff672").ToUint64Truncated() != 0x9219566f82fff672 {
+		t.Fail()
+	}
+	if Nil.ToUint64Truncated() != 0 {
+		t.Fail()
+	}
+}
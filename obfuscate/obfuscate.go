@@ -0,0 +1,197 @@
+// Obfuscated public IDs with key rotation
+//
+// This package hides internal Uuid25 values behind public IDs by encrypting
+// the 128-bit value with AES, which is a permutation of the 128-bit space and
+// thus maps each UUID to a distinct, random-looking 128-bit value. A public ID
+// consists of a one-character key ID (`kid`) followed by the 25-digit Uuid25
+// representation of the encrypted value, e.g., `1dpoadk8izg9y4tte7vy1xt94o`.
+//
+// Because the key ID is embedded, public IDs encrypted under older keys keep
+// decoding after a new primary key is introduced, as long as the old keys stay
+// in the codec.
+//
+// Obfuscation conceals the internal values and their ordering from clients but
+// does not authenticate public IDs; any well-formed public ID decodes to some
+// UUID.
+//
+// For exports where the original values must not be recoverable at all, this
+// package also provides a one-way keyed mapping through Pseudonymize().
+package obfuscate
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// An error returned when a public ID is malformed.
+var ErrMalformed = errors.New("malformed public ID")
+
+// An error returned when a public ID refers to an unknown key.
+var ErrUnknownKey = errors.New("unknown key ID")
+
+// An error returned when a codec has no primary key.
+var ErrNoPrimaryKey = errors.New("no primary key")
+
+// A keyring-aware encoder and decoder of public IDs.
+//
+// The zero value is a codec with no keys. A Codec is safe for concurrent use.
+type Codec struct {
+	mu      sync.RWMutex
+	blocks  [36]cipher.Block
+	primary int // index of the primary key, valid only if blocks[primary] != nil
+}
+
+// Creates an empty codec.
+func NewCodec() *Codec {
+	return &Codec{}
+}
+
+// Registers an AES key under a key ID, which must be a single Base36 digit
+// character (`0`-`9` or `a`-`z`, case-insensitive).
+//
+// The first key added becomes the primary key. This method returns an error if
+// the key ID is invalid or already in use or the key is not a valid AES key.
+func (codec *Codec) AddKey(kid byte, key []byte) error {
+	index, ok := kidIndex(kid)
+	if !ok {
+		return fmt.Errorf("invalid key ID %q", kid)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+
+	codec.mu.Lock()
+	defer codec.mu.Unlock()
+	if codec.blocks[index] != nil {
+		return fmt.Errorf("key ID %q already in use", kid)
+	}
+	codec.blocks[index] = block
+	if codec.blocks[codec.primary] == nil {
+		codec.primary = index
+	}
+	return nil
+}
+
+// Sets the key used by Encode() to a registered key.
+func (codec *Codec) SetPrimary(kid byte) error {
+	index, ok := kidIndex(kid)
+	if !ok {
+		return fmt.Errorf("invalid key ID %q", kid)
+	}
+	codec.mu.Lock()
+	defer codec.mu.Unlock()
+	if codec.blocks[index] == nil {
+		return ErrUnknownKey
+	}
+	codec.primary = index
+	return nil
+}
+
+// Encodes a Uuid25 value into a public ID under the primary key.
+func (codec *Codec) Encode(id uuid25.Uuid25) (string, error) {
+	codec.mu.RLock()
+	defer codec.mu.RUnlock()
+	if codec.blocks[codec.primary] == nil {
+		return "", ErrNoPrimaryKey
+	}
+	src := id.ToBytes()
+	var dst [16]byte
+	codec.blocks[codec.primary].Encrypt(dst[:], src[:])
+	return string(kidChars[codec.primary]) + uuid25.FromBytes(dst[:]).String(), nil
+}
+
+// Decodes a public ID into the Uuid25 value using the key it refers to.
+func (codec *Codec) Decode(publicID string) (uuid25.Uuid25, error) {
+	if len(publicID) != 26 {
+		return "", ErrMalformed
+	}
+	index, ok := kidIndex(publicID[0])
+	if !ok {
+		return "", ErrMalformed
+	}
+	encrypted, err := uuid25.ParseUuid25(publicID[1:])
+	if err != nil {
+		return "", ErrMalformed
+	}
+
+	codec.mu.RLock()
+	block := codec.blocks[index]
+	codec.mu.RUnlock()
+	if block == nil {
+		return "", ErrUnknownKey
+	}
+	src := encrypted.ToBytes()
+	var dst [16]byte
+	block.Decrypt(dst[:], src[:])
+	return uuid25.FromBytes(dst[:]), nil
+}
+
+// The key ID characters in index order.
+const kidChars = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// Returns the index of a key ID character.
+func kidIndex(kid byte) (int, bool) {
+	switch {
+	case '0' <= kid && kid <= '9':
+		return int(kid - '0'), true
+	case 'a' <= kid && kid <= 'z':
+		return int(kid-'a') + 10, true
+	case 'A' <= kid && kid <= 'Z':
+		return int(kid-'A') + 10, true
+	default:
+		return 0, false
+	}
+}
+
+// The codec used by the PublicID type.
+var DefaultCodec = NewCodec()
+
+// A Uuid25 value that is marshaled as a public ID through DefaultCodec.
+//
+// Use this type for fields of request and response structs so that internal
+// IDs never leave the process in plain form.
+type PublicID uuid25.Uuid25
+
+// Implements the encoding.TextMarshaler interface.
+func (id PublicID) MarshalText() (text []byte, err error) {
+	s, err := DefaultCodec.Encode(uuid25.Uuid25(id))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// Implements the encoding.TextUnmarshaler interface.
+func (id *PublicID) UnmarshalText(text []byte) error {
+	if id == nil {
+		return errors.New("nil receiver")
+	}
+	result, err := DefaultCodec.Decode(string(text))
+	*id = PublicID(result)
+	return err
+}
+
+// Maps a Uuid25 value to a pseudonym through a one-way keyed function.
+//
+// The pseudonym is derived from the HMAC-SHA256 of the 16-byte representation
+// and formatted as a UUIDv8 (RFC 9562) so that it passes UUID validation in
+// downstream systems. The mapping is stable for the same key, so joins on
+// pseudonymized columns still work, but the original value cannot be recovered
+// from the pseudonym without brute-forcing the key.
+func Pseudonymize(key []byte, id uuid25.Uuid25) uuid25.Uuid25 {
+	src := id.ToBytes()
+	mac := hmac.New(sha256.New, key)
+	mac.Write(src[:])
+	sum := mac.Sum(nil)
+	sum[6] = 0x80 | sum[6]&0x0f // version 8
+	sum[8] = 0x80 | sum[8]&0x3f // variant 10
+	return uuid25.FromBytes(sum[:16])
+}
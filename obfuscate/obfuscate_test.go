@@ -0,0 +1,98 @@
+package obfuscate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+var key1 = []byte("0123456789abcdef")
+var key2 = []byte("fedcba9876543210fedcba9876543210")
+
+// Tests encoding and decoding public IDs across key rotation.
+func TestCodec(t *testing.T) {
+	id, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	codec := NewCodec()
+	if _, err := codec.Encode(id); err != ErrNoPrimaryKey {
+		t.Fail()
+	}
+	if codec.AddKey('1', key1) != nil {
+		t.Fail()
+	}
+
+	old, err := codec.Encode(id)
+	if len(old) != 26 || old[0] != '1' || old[1:] == id.String() || err != nil {
+		t.Errorf("unexpected public ID: %q", old)
+	}
+	if x, err := codec.Decode(old); x != id || err != nil {
+		t.Fail()
+	}
+
+	// rotate keys
+	if codec.AddKey('A', key2) != nil || codec.SetPrimary('a') != nil {
+		t.Fail()
+	}
+	current, _ := codec.Encode(id)
+	if current[0] != 'a' || current == old {
+		t.Errorf("unexpected public ID: %q", current)
+	}
+	if x, err := codec.Decode(current); x != id || err != nil {
+		t.Fail()
+	}
+	if x, err := codec.Decode(old); x != id || err != nil {
+		t.Fail()
+	}
+
+	if codec.AddKey('1', key2) == nil || codec.AddKey('-', key2) == nil || codec.AddKey('2', []byte("short")) == nil {
+		t.Fail()
+	}
+	if codec.SetPrimary('z') != ErrUnknownKey {
+		t.Fail()
+	}
+	if _, err := codec.Decode("z" + current[1:]); err != ErrUnknownKey {
+		t.Fail()
+	}
+	for _, e := range []string{"", current[1:], "-" + current[1:], "1zzzzzzzzzzzzzzzzzzzzzzzzz"} {
+		if _, err := codec.Decode(e); err != ErrMalformed {
+			t.Errorf("%q must be malformed", e)
+		}
+	}
+}
+
+// Tests marshaling PublicID through DefaultCodec.
+func TestPublicID(t *testing.T) {
+	DefaultCodec = NewCodec()
+	DefaultCodec.AddKey('k', key1)
+
+	id, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	publicID, _ := DefaultCodec.Encode(id)
+	data, err := json.Marshal(struct{ ID PublicID }{PublicID(id)})
+	if string(data) != `{"ID":"`+publicID+`"}` || err != nil {
+		t.Fail()
+	}
+
+	var decoded struct{ ID PublicID }
+	if json.Unmarshal(data, &decoded) != nil || decoded.ID != PublicID(id) {
+		t.Fail()
+	}
+	if json.Unmarshal([]byte(`{"ID":"foo"}`), &decoded) == nil {
+		t.Fail()
+	}
+}
+
+// Tests the stability and key dependence of pseudonyms.
+func TestPseudonymize(t *testing.T) {
+	x, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	y, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161879")
+	p := Pseudonymize(key1, x)
+	if p != Pseudonymize(key1, x) || p == x {
+		t.Fail()
+	}
+	if p == Pseudonymize(key2, x) || p == Pseudonymize(key1, y) {
+		t.Fail()
+	}
+	if bs := p.ToBytes(); bs[6]>>4 != 8 || bs[8]>>6 != 2 {
+		t.Fail()
+	}
+}
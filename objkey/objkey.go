@@ -0,0 +1,74 @@
+// Object key layouts for blob stores keyed by Uuid25 values
+//
+// The functions in this package build object keys, such as those of Amazon S3
+// and Google Cloud Storage, that embed a Uuid25 value in the last path segment
+// under a storage-friendly prefix, and recover the ID from such keys:
+//
+//   - Fanout spreads objects over `256^levels` prefixes derived from the ID,
+//     like `avatars/a2/72/dpoadk8izg9y4tte7vy1xt94o.png`, which avoids hot
+//     prefixes and keeps listings of local directory trees small.
+//   - ByDate partitions objects by the creation date embedded in time-based
+//     IDs such as UUIDv7, like `logs/2024/01/02/03ax4ryjgt9voq53ramspmupb`,
+//     which allows listing and expiring the objects of a day at once.
+package objkey
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// An error returned when an object key does not end with a Uuid25 value.
+var ErrInvalidKey = errors.New("invalid object key")
+
+// Returns the key `<prefix><xx>/.../<id><suffix>` with `levels` fan-out
+// segments of two hexadecimal digits each given by uuid25.FanoutPrefix().
+//
+// The fan-out segments are derived from a hash of the 128-bit value, so they
+// are uniformly distributed even for IDs whose leading bits are a timestamp.
+// The prefix, such as `avatars/`, and the suffix, such as `.png`, are used as
+// is. This function panics if `levels` is negative or greater than 8.
+func Fanout(prefix string, id uuid25.Uuid25, levels int, suffix string) string {
+	fanout := uuid25.FanoutPrefix(id, levels)
+	if levels > 0 {
+		fanout += "/"
+	}
+	return prefix + fanout + id.String() + suffix
+}
+
+// Returns the key `<prefix><yyyy>/<mm>/<dd>/<id><suffix>` with the UTC creation
+// date of a time-based ID.
+//
+// This function returns uuid25.ErrNotTimeBased if the ID does not embed a
+// timestamp.
+func ByDate(prefix string, id uuid25.Uuid25, suffix string) (string, error) {
+	t, err := id.Time()
+	if err != nil {
+		return "", err
+	}
+	return prefix + t.UTC().Format("2006/01/02/") + id.String() + suffix, nil
+}
+
+// Recovers the ID from a key built by Fanout or ByDate.
+//
+// The ID is read from the first 25 characters of the last path segment, which
+// may be followed by a suffix beginning with a non-alphanumeric character,
+// such as a file extension. This function returns ErrInvalidKey if the last
+// segment does not begin with a Uuid25 string.
+func ParseKey(key string) (uuid25.Uuid25, error) {
+	last := key[strings.LastIndexByte(key, '/')+1:]
+	if len(last) < 25 || (len(last) > 25 && isAlnum(last[25])) {
+		return "", ErrInvalidKey
+	}
+	id, err := uuid25.ParseUuid25(last[:25])
+	if err != nil {
+		return "", ErrInvalidKey
+	}
+	return id, nil
+}
+
+// Reports whether `c` is an ASCII letter or digit.
+func isAlnum(c byte) bool {
+	return ('0' <= c && c <= '9') || ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z')
+}
@@ -0,0 +1,70 @@
+package objkey
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests Fanout and parsing its keys.
+func TestFanout(t *testing.T) {
+	id, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	key := Fanout("avatars/", id, 2, ".png")
+	if len(key) != len("avatars/xx/yy/.png")+25 || !strings.HasPrefix(key, "avatars/") || !strings.HasSuffix(key, "/dpoadk8izg9y4tte7vy1xt94o.png") {
+		t.Errorf("got %q", key)
+	}
+	if key != Fanout("avatars/", id, 2, ".png") {
+		t.Fail()
+	}
+	if x, err := ParseKey(key); x != id || err != nil {
+		t.Fail()
+	}
+	if Fanout("", id, 0, "") != "dpoadk8izg9y4tte7vy1xt94o" {
+		t.Fail()
+	}
+
+	counts := map[string]int{}
+	for i := 0; i < 16_000; i += 1 {
+		x := uuid25.FromUint128(uuid25.Uint128{Hi: 0x018c_c820_d888_7abc, Lo: uint64(i)})
+		counts[Fanout("", x, 1, "")[:2]] += 1
+	}
+	if len(counts) != 256 {
+		t.Fail()
+	}
+	for _, e := range counts {
+		if e < 30 || e > 100 {
+			t.Errorf("counts = %v", counts)
+			break
+		}
+	}
+}
+
+// Tests ByDate and parsing its keys.
+func TestByDate(t *testing.T) {
+	id, _ := uuid25.Parse("018cc820-d888-7abc-8123-456789abcdef")
+	key, err := ByDate("logs/", id, "")
+	if err != nil || key != "logs/2024/01/02/"+id.String() {
+		t.Errorf("got %q", key)
+	}
+	if x, err := ParseKey(key); x != id || err != nil {
+		t.Fail()
+	}
+
+	v4, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	if _, err := ByDate("logs/", v4, ""); err != uuid25.ErrNotTimeBased {
+		t.Fail()
+	}
+}
+
+// Tests ParseKey with invalid keys.
+func TestParseKey(t *testing.T) {
+	for _, e := range []string{"", "a/", "dpoadk8izg9y4tte7vy1xt94", "a/dpoadk8izg9y4tte7vy1xt94ox", "a/zzzzzzzzzzzzzzzzzzzzzzzzz"} {
+		if _, err := ParseKey(e); err != ErrInvalidKey {
+			t.Fail()
+		}
+	}
+	if x, err := ParseKey("DPOADK8IZG9Y4TTE7VY1XT94O.tar.gz"); x != "dpoadk8izg9y4tte7vy1xt94o" || err != nil {
+		t.Fail()
+	}
+}
@@ -0,0 +1,56 @@
+package uuid25
+
+import "unsafe"
+
+// Creates an instance from a UUID string representation in a byte slice.
+//
+// This function accepts the same formats as Parse() and returns the same
+// errors, but it reads `text` in place instead of converting it into a string,
+// so it does not allocate except for the result. It is intended for decoders of
+// high-volume JSON and wire payloads that hold input in byte slices. The result
+// does not refer to the memory of `text`.
+func ParseBytes(text []byte) (Uuid25, error) {
+	return Parse(bytesView(text))
+}
+
+// Creates an instance from the 25-digit Base36 Uuid25 format in a byte slice.
+// See ParseBytes() and ParseUuid25() for details.
+func ParseUuid25Bytes(text []byte) (Uuid25, error) {
+	return ParseUuid25(bytesView(text))
+}
+
+// Creates an instance from the 32-digit hexadecimal format in a byte slice.
+// See ParseBytes() and ParseHex() for details.
+func ParseHexBytes(text []byte) (Uuid25, error) {
+	return ParseHex(bytesView(text))
+}
+
+// Creates an instance from the 8-4-4-4-12 hyphenated format in a byte slice.
+// See ParseBytes() and ParseHyphenated() for details.
+func ParseHyphenatedBytes(text []byte) (Uuid25, error) {
+	return ParseHyphenated(bytesView(text))
+}
+
+// Creates an instance from the braced hyphenated format in a byte slice. See
+// ParseBytes() and ParseBraced() for details.
+func ParseBracedBytes(text []byte) (Uuid25, error) {
+	return ParseBraced(bytesView(text))
+}
+
+// Creates an instance from the RFC 4122 URN format in a byte slice. See
+// ParseBytes() and ParseUrn() for details.
+func ParseUrnBytes(text []byte) (Uuid25, error) {
+	return ParseUrn(bytesView(text))
+}
+
+// Returns a string that shares memory with `b` without copying it.
+//
+// The parse functions never retain their argument: they either re-encode the
+// value or copy the input into a new buffer, and parse errors carry only the
+// offset. Thus the view is safe to pass to them even if `b` is modified later.
+func bytesView(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return *(*string)(unsafe.Pointer(&b))
+}
@@ -0,0 +1,66 @@
+package uuid25
+
+import (
+	"errors"
+	"testing"
+)
+
+// Tests that the byte slice parsers agree with the string parsers.
+func TestParseBytes(t *testing.T) {
+	for _, e := range testCases {
+		cases := []struct {
+			input string
+			fn    func([]byte) (Uuid25, error)
+		}{
+			{e.uuid25, ParseUuid25Bytes},
+			{e.hex, ParseHexBytes},
+			{e.hyphenated, ParseHyphenatedBytes},
+			{e.braced, ParseBracedBytes},
+			{e.urn, ParseUrnBytes},
+		}
+		for _, c := range cases {
+			buffer := []byte(c.input)
+			x, err := c.fn(buffer)
+			if err != nil || x != Uuid25(e.uuid25) {
+				t.Errorf("unexpected result for %q: %s %v", c.input, x, err)
+			}
+			if y, err := ParseBytes(buffer); err != nil || y != x {
+				t.Fail()
+			}
+
+			// the result must not share memory with the input
+			for i := range buffer {
+				buffer[i] = 'x'
+			}
+			if x != Uuid25(e.uuid25) {
+				t.Fail()
+			}
+		}
+	}
+
+	for _, e := range []string{"", "40eb9860-cf3e-45e2-a90e-b82236ac8o6c", "f5lxx1zz5pnorynqglhzmsp34"} {
+		_, want := Parse(e)
+		_, err := ParseBytes([]byte(e))
+		if err == nil || err.Error() != want.Error() || !errors.Is(err, ErrParse) {
+			t.Errorf("unexpected error for %q: %v", e, err)
+		}
+	}
+	if _, err := ParseHexBytes([]byte("40eb9860-cf3e-45e2-a90e-b82236ac806c")); !errors.Is(err, ErrWrongFormat) {
+		t.Fail()
+	}
+	if _, err := ParseBytes(nil); !errors.Is(err, ErrInvalidLength) {
+		t.Fail()
+	}
+}
+
+// Tests that ParseBytes() allocates only the result.
+func TestParseBytesAllocs(t *testing.T) {
+	text := []byte("urn:uuid:40eb9860-cf3e-45e2-a90e-b82236ac806c")
+	if n := testing.AllocsPerRun(100, func() { ParseBytes(text) }); n > 1 {
+		t.Errorf("ParseBytes must allocate only the result: %v", n)
+	}
+	var x Uuid25
+	if n := testing.AllocsPerRun(100, func() { x.UnmarshalText(text) }); n > 1 {
+		t.Errorf("UnmarshalText must allocate only the result: %v", n)
+	}
+}
@@ -0,0 +1,135 @@
+package uuid25
+
+import "fmt"
+
+// An error returned by a format-specific parse function, such as ParseHex(),
+// when a string has the length of another supported format. This error wraps
+// ErrInvalidLength.
+var ErrWrongFormat = fmt.Errorf("%w: wrong format", ErrInvalidLength)
+
+// An error parsing a UUID string, which describes where the parse failed.
+//
+// The parse functions return this type wrapping one of ErrInvalidLength,
+// ErrInvalidDigit, ErrOverflow, and ErrWrongFormat, so callers can classify the
+// failure with errors.Is() and obtain the details with errors.As():
+//
+//	var pe *uuid25.ParseError
+//	if errors.As(err, &pe) && pe.Offset >= 0 {
+//		msg = fmt.Sprintf("invalid character at position %d", pe.Offset+1)
+//	}
+//
+// ParseNoAlloc() and FromBytesUnsafe() return the bare error values instead to
+// avoid allocation.
+type ParseError struct {
+	// The cause of the failure: ErrInvalidLength, ErrInvalidDigit,
+	// ErrOverflow, or ErrWrongFormat.
+	Err error
+
+	// The format in which the input was parsed, determined by the parse
+	// function and the length of the input, or FormatInvalid if the length
+	// matches no supported format.
+	Format Format
+
+	// The byte offset of the first invalid character in the input if Err is
+	// ErrInvalidDigit, or -1 otherwise.
+	Offset int
+}
+
+// Returns the error message, including the format and the offset if known.
+func (e *ParseError) Error() string {
+	switch {
+	case e.Offset >= 0:
+		return fmt.Sprintf("%v at offset %d in %v format", e.Err, e.Offset, e.Format)
+	case e.Format != FormatInvalid:
+		return fmt.Sprintf("%v (%v format)", e.Err, e.Format)
+	default:
+		return e.Err.Error()
+	}
+}
+
+// Returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Wraps an error returned by a decode function for the format `f` into a
+// ParseError, locating the first invalid character in `s` if necessary.
+func newParseError(err error, s string, f Format) error {
+	e := &ParseError{err, f, -1}
+	if err == ErrInvalidDigit {
+		e.Offset = invalidOffset(s, f)
+	} else if err == ErrInvalidLength && f != FormatInvalid {
+		if g := formatOfLength(len(s)); g != FormatInvalid {
+			e.Err, e.Format = ErrWrongFormat, g
+		}
+	}
+	return e
+}
+
+// Returns the format whose strings have the length `n`, or FormatInvalid.
+func formatOfLength(n int) Format {
+	switch n {
+	case 25:
+		return FormatUuid25
+	case 32:
+		return FormatHex
+	case 36:
+		return FormatHyphenated
+	case 38:
+		return FormatBraced
+	case 45:
+		return FormatUrn
+	default:
+		return FormatInvalid
+	}
+}
+
+// Returns the offset of the first character of `s` not allowed at its position
+// in the format `f`, or -1 if there is none. The length of `s` must match the
+// format.
+func invalidOffset(s string, f Format) int {
+	switch f {
+	case FormatUuid25:
+		for i := 0; i < len(s); i += 1 {
+			if digitValues[s[i]] >= 36 {
+				return i
+			}
+		}
+	case FormatHex:
+		for i := 0; i < len(s); i += 1 {
+			if digitValues[s[i]] >= 16 {
+				return i
+			}
+		}
+	case FormatHyphenated:
+		for i := 0; i < len(s); i += 1 {
+			if i == 8 || i == 13 || i == 18 || i == 23 {
+				if s[i] != '-' {
+					return i
+				}
+			} else if digitValues[s[i]] >= 16 {
+				return i
+			}
+		}
+	case FormatBraced:
+		if s[0] != '{' {
+			return 0
+		} else if i := invalidOffset(s[1:37], FormatHyphenated); i >= 0 {
+			return i + 1
+		} else if s[37] != '}' {
+			return 37
+		}
+	case FormatUrn:
+		const prefix = "urn:uuid:"
+		for i := 0; i < len(prefix); i += 1 {
+			c, p := s[i], prefix[i]
+			if c != p && (p < 'a' || p > 'z' || c+('a'-'A') != p) {
+				return i
+			}
+		}
+		if i := invalidOffset(s[9:], FormatHyphenated); i >= 0 {
+			return i + 9
+		}
+	}
+	return -1
+}
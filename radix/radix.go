@@ -0,0 +1,289 @@
+// Immutable radix tree keyed by UUIDs and UUID prefixes
+//
+// This package provides a persistent binary radix tree (PATRICIA trie) keyed by
+// the 128-bit value of UUIDs or by bit prefixes of that value. It supports
+// exact lookups, longest-prefix matches, and ordered range iteration, which
+// suits in-memory routing and ownership tables over millions of IDs.
+//
+// Trees are immutable: Insert() and Delete() return a new tree that shares
+// unchanged nodes with the original, so any number of goroutines can read a
+// tree while another goroutine derives updated versions from it.
+package radix
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// A UUID prefix consisting of the leading `Len()` bits of a 128-bit value.
+type Prefix struct {
+	bits   [16]byte
+	length int
+}
+
+// Creates a prefix from the leading `length` bits of a UUID.
+//
+// This function panics if `length` is not within 0 to 128.
+func PrefixFrom(id uuid25.Uuid25, length int) Prefix {
+	return prefixFromBytes(id.ToBytes(), length)
+}
+
+// Creates a prefix from the leading `length` bits of a 16-byte value, clearing
+// the remaining bits.
+func prefixFromBytes(b [16]byte, length int) Prefix {
+	if length < 0 || length > 128 {
+		panic("prefix length out of range")
+	}
+	for i := length; i < 128; i += 1 {
+		b[i/8] &^= 0x80 >> (i % 8)
+	}
+	return Prefix{b, length}
+}
+
+// Returns the number of bits of the prefix.
+func (p Prefix) Len() int {
+	return p.length
+}
+
+// Returns the smallest UUID starting with the prefix.
+func (p Prefix) First() uuid25.Uuid25 {
+	return uuid25.FromBytes(p.bits[:])
+}
+
+// Returns the largest UUID starting with the prefix.
+func (p Prefix) Last() uuid25.Uuid25 {
+	b := p.last()
+	return uuid25.FromBytes(b[:])
+}
+
+func (p Prefix) last() [16]byte {
+	b := p.bits
+	for i := p.length; i < 128; i += 1 {
+		b[i/8] |= 0x80 >> (i % 8)
+	}
+	return b
+}
+
+// Reports whether a UUID starts with the prefix.
+func (p Prefix) Contains(id uuid25.Uuid25) bool {
+	b := id.ToBytes()
+	return commonLen(p.bits, b, p.length) == p.length
+}
+
+// Returns the prefix in the form of `<first UUID in Uuid25>/<length>`.
+func (p Prefix) String() string {
+	return fmt.Sprintf("%s/%d", p.First(), p.length)
+}
+
+// Returns the bit at position `i` of a 16-byte value.
+func bitAt(b *[16]byte, i int) int {
+	return int(b[i/8]>>(7-i%8)) & 1
+}
+
+// Returns the length of the common leading bits of two values, up to `limit`.
+func commonLen(a [16]byte, b [16]byte, limit int) int {
+	for i := 0; i < 16 && i*8 < limit; i += 1 {
+		if x := a[i] ^ b[i]; x != 0 {
+			n := i * 8
+			for x&0x80 == 0 {
+				n += 1
+				x <<= 1
+			}
+			if n > limit {
+				return limit
+			}
+			return n
+		}
+	}
+	return limit
+}
+
+// A node of the tree. The children, if any, have longer prefixes that extend
+// the prefix of this node with bit 0 and 1, respectively.
+type node[V any] struct {
+	prefix   Prefix
+	hasValue bool
+	value    V
+	children [2]*node[V]
+}
+
+// An immutable radix tree mapping UUID prefixes to values.
+//
+// The zero value is an empty tree.
+type Tree[V any] struct {
+	root *node[V]
+	size int
+}
+
+// Returns the number of entries in the tree.
+func (t Tree[V]) Len() int {
+	return t.size
+}
+
+// Returns a tree in which `p` is mapped to `value`.
+func (t Tree[V]) Insert(p Prefix, value V) Tree[V] {
+	root, added := insert(t.root, p, value)
+	if added {
+		return Tree[V]{root, t.size + 1}
+	}
+	return Tree[V]{root, t.size}
+}
+
+// Returns a tree in which the full 128-bit value of `id` is mapped to `value`.
+func (t Tree[V]) Put(id uuid25.Uuid25, value V) Tree[V] {
+	return t.Insert(PrefixFrom(id, 128), value)
+}
+
+func insert[V any](n *node[V], p Prefix, value V) (*node[V], bool) {
+	if n == nil {
+		return &node[V]{prefix: p, hasValue: true, value: value}, true
+	}
+
+	limit := n.prefix.length
+	if p.length < limit {
+		limit = p.length
+	}
+	common := commonLen(n.prefix.bits, p.bits, limit)
+
+	if common == n.prefix.length && common == p.length {
+		copied := *n
+		copied.hasValue, copied.value = true, value
+		return &copied, !n.hasValue
+	} else if common == n.prefix.length {
+		// `p` extends `n`
+		copied := *n
+		b := bitAt(&p.bits, common)
+		var added bool
+		copied.children[b], added = insert(n.children[b], p, value)
+		return &copied, added
+	} else if common == p.length {
+		// `p` is a prefix of `n`
+		parent := &node[V]{prefix: p, hasValue: true, value: value}
+		parent.children[bitAt(&n.prefix.bits, common)] = n
+		return parent, true
+	} else {
+		// `p` and `n` diverge at bit `common`
+		branch := &node[V]{prefix: prefixFromBytes(p.bits, common)}
+		branch.children[bitAt(&p.bits, common)] = &node[V]{prefix: p, hasValue: true, value: value}
+		branch.children[bitAt(&n.prefix.bits, common)] = n
+		return branch, true
+	}
+}
+
+// Returns a tree in which `p` is not mapped to any value.
+func (t Tree[V]) Delete(p Prefix) Tree[V] {
+	root, deleted := remove(t.root, p)
+	if deleted {
+		return Tree[V]{root, t.size - 1}
+	}
+	return t
+}
+
+func remove[V any](n *node[V], p Prefix) (*node[V], bool) {
+	if n == nil || p.length < n.prefix.length ||
+		commonLen(n.prefix.bits, p.bits, n.prefix.length) < n.prefix.length {
+		return n, false
+	}
+
+	copied := *n
+	if p.length == n.prefix.length {
+		if !n.hasValue {
+			return n, false
+		}
+		var zero V
+		copied.hasValue, copied.value = false, zero
+	} else {
+		b := bitAt(&p.bits, n.prefix.length)
+		child, deleted := remove(n.children[b], p)
+		if !deleted {
+			return n, false
+		}
+		copied.children[b] = child
+	}
+
+	// collapse nodes that no longer carry a value or a branch
+	if !copied.hasValue {
+		if copied.children[0] == nil {
+			return copied.children[1], true
+		} else if copied.children[1] == nil {
+			return copied.children[0], true
+		}
+	}
+	return &copied, true
+}
+
+// Returns the value mapped to exactly `p`.
+func (t Tree[V]) Get(p Prefix) (V, bool) {
+	for n := t.root; n != nil && n.prefix.length <= p.length; {
+		if commonLen(n.prefix.bits, p.bits, n.prefix.length) < n.prefix.length {
+			break
+		}
+		if n.prefix.length == p.length {
+			return n.value, n.hasValue
+		}
+		n = n.children[bitAt(&p.bits, n.prefix.length)]
+	}
+	var zero V
+	return zero, false
+}
+
+// Returns the value mapped to the full 128-bit value of `id`.
+func (t Tree[V]) Lookup(id uuid25.Uuid25) (V, bool) {
+	return t.Get(PrefixFrom(id, 128))
+}
+
+// Returns the longest prefix in the tree that `id` starts with and its value.
+func (t Tree[V]) LongestPrefix(id uuid25.Uuid25) (Prefix, V, bool) {
+	b := id.ToBytes()
+	var match *node[V]
+	for n := t.root; n != nil; {
+		if commonLen(n.prefix.bits, b, n.prefix.length) < n.prefix.length {
+			break
+		}
+		if n.hasValue {
+			match = n
+		}
+		if n.prefix.length == 128 {
+			break
+		}
+		n = n.children[bitAt(&b, n.prefix.length)]
+	}
+	if match == nil {
+		var zero V
+		return Prefix{}, zero, false
+	}
+	return match.prefix, match.value, true
+}
+
+// Calls `fn` for each entry whose prefix starts with a UUID within `lo` to `hi`
+// (inclusive) in ascending order until `fn` returns false.
+//
+// Entries are ordered by the first UUID of their prefixes, and a prefix comes
+// before the longer prefixes that extend it.
+func (t Tree[V]) Ascend(lo uuid25.Uuid25, hi uuid25.Uuid25, fn func(p Prefix, value V) bool) {
+	ascend(t.root, lo.ToBytes(), hi.ToBytes(), fn)
+}
+
+// Calls `fn` for each entry in ascending order until `fn` returns false.
+func (t Tree[V]) Walk(fn func(p Prefix, value V) bool) {
+	ascend(t.root, [16]byte{}, prefixFromBytes([16]byte{}, 0).last(), fn)
+}
+
+func ascend[V any](n *node[V], lo [16]byte, hi [16]byte, fn func(p Prefix, value V) bool) bool {
+	if n == nil {
+		return true
+	}
+	// skip subtrees outside the range
+	last := n.prefix.last()
+	if bytes.Compare(last[:], lo[:]) < 0 || bytes.Compare(n.prefix.bits[:], hi[:]) > 0 {
+		return true
+	}
+	if n.hasValue && bytes.Compare(n.prefix.bits[:], lo[:]) >= 0 {
+		if !fn(n.prefix, n.value) {
+			return false
+		}
+	}
+	return ascend(n.children[0], lo, hi, fn) && ascend(n.children[1], lo, hi, fn)
+}
@@ -0,0 +1,155 @@
+package radix
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Generates a random UUID from a deterministic source.
+func randomUuid25(r *rand.Rand) uuid25.Uuid25 {
+	var b [16]byte
+	r.Read(b[:])
+	return uuid25.FromBytes(b[:])
+}
+
+// Tests insertion, lookup, deletion, and immutability against a map.
+func TestTree(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var tree Tree[int]
+	expected := map[uuid25.Uuid25]int{}
+	var ids []uuid25.Uuid25
+	for i := 0; i < 2000; i += 1 {
+		id := randomUuid25(r)
+		ids = append(ids, id)
+		expected[id] = i
+		tree = tree.Put(id, i)
+	}
+	tree = tree.Put(ids[0], -1)
+	expected[ids[0]] = -1
+	if tree.Len() != len(expected) {
+		t.Fatalf("unexpected length: %d", tree.Len())
+	}
+
+	snapshot := tree
+	for i, id := range ids {
+		if v, ok := tree.Lookup(id); !ok || v != expected[id] {
+			t.Fatalf("unexpected value for %s: %d", id, v)
+		}
+		if i%2 == 0 {
+			tree = tree.Delete(PrefixFrom(id, 128))
+			delete(expected, id)
+		}
+	}
+	if tree.Len() != len(expected) || snapshot.Len() != len(ids) {
+		t.Fatalf("unexpected length: %d", tree.Len())
+	}
+	for i, id := range ids {
+		if _, ok := tree.Lookup(id); ok != (i%2 == 1) {
+			t.Fatalf("unexpected existence of %s", id)
+		}
+		if _, ok := snapshot.Lookup(id); !ok {
+			t.Fatalf("snapshot must not be modified")
+		}
+	}
+	if _, ok := tree.Lookup(randomUuid25(r)); ok {
+		t.Fail()
+	}
+	if tree.Delete(PrefixFrom(ids[0], 128)).Len() != tree.Len() {
+		t.Fail()
+	}
+}
+
+// Tests longest-prefix matches.
+func TestLongestPrefix(t *testing.T) {
+	id, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	var tree Tree[string]
+	tree = tree.Insert(PrefixFrom(id, 0), "default")
+	tree = tree.Insert(PrefixFrom(id, 4), "e/4")
+	tree = tree.Insert(PrefixFrom(id, 16), "e7a1/16")
+	tree = tree.Insert(PrefixFrom(id, 17), "e7a1/17")
+	tree = tree.Put(id, "exact")
+
+	if p, v, ok := tree.LongestPrefix(id); !ok || v != "exact" || p.Len() != 128 {
+		t.Fail()
+	}
+	other, _ := uuid25.Parse("e7a10000-0000-0000-0000-000000000000")
+	if p, v, ok := tree.LongestPrefix(other); !ok || v != "e7a1/16" || p.Len() != 16 || !p.Contains(other) {
+		t.Fail()
+	}
+	other, _ = uuid25.Parse("e0000000-0000-0000-0000-000000000000")
+	if _, v, _ := tree.LongestPrefix(other); v != "e/4" {
+		t.Fail()
+	}
+	other, _ = uuid25.Parse("00000000-0000-0000-0000-000000000000")
+	if _, v, _ := tree.LongestPrefix(other); v != "default" {
+		t.Fail()
+	}
+
+	tree = tree.Delete(PrefixFrom(id, 0))
+	if _, _, ok := tree.LongestPrefix(other); ok {
+		t.Fail()
+	}
+	if v, ok := tree.Get(PrefixFrom(other, 4)); ok || v != "" {
+		t.Fail()
+	}
+	if v, ok := tree.Get(PrefixFrom(id, 4)); !ok || v != "e/4" {
+		t.Fail()
+	}
+
+	p := PrefixFrom(id, 16)
+	if p.First().ToHex() != "e7a10000000000000000000000000000" ||
+		p.Last().ToHex() != "e7a1ffffffffffffffffffffffffffff" ||
+		p.String() != p.First().String()+"/16" {
+		t.Fail()
+	}
+}
+
+// Tests ordered range iteration against a sorted slice.
+func TestAscend(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	var tree Tree[int]
+	var keys [][16]byte
+	for i := 0; i < 1000; i += 1 {
+		id := randomUuid25(r)
+		tree = tree.Put(id, i)
+		keys = append(keys, id.ToBytes())
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i][:], keys[j][:]) < 0 })
+
+	var walked []uuid25.Uuid25
+	tree.Walk(func(p Prefix, v int) bool {
+		walked = append(walked, p.First())
+		return true
+	})
+	if len(walked) != len(keys) {
+		t.Fatal("Walk must visit all entries")
+	}
+	for i, e := range keys {
+		if walked[i] != uuid25.FromBytes(e[:]) {
+			t.Fatal("Walk must visit entries in ascending order")
+		}
+	}
+
+	lo, hi := uuid25.FromBytes(keys[100][:]), uuid25.FromBytes(keys[199][:])
+	var visited []uuid25.Uuid25
+	tree.Ascend(lo, hi, func(p Prefix, v int) bool {
+		visited = append(visited, p.First())
+		return true
+	})
+	if len(visited) != 100 || visited[0] != lo || visited[99] != hi {
+		t.Errorf("unexpected range iteration: %d entries", len(visited))
+	}
+
+	n := 0
+	tree.Ascend(lo, hi, func(p Prefix, v int) bool {
+		n += 1
+		return n < 10
+	})
+	if n != 10 {
+		t.Error("Ascend must stop when fn returns false")
+	}
+}
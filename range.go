@@ -0,0 +1,128 @@
+package uuid25
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// A contiguous range of UUIDs between two inclusive bounds.
+//
+// Because the 25-digit Uuid25 representation has a fixed length and its digits
+// are ordered as in ASCII, Uuid25 strings compare in the same order as the
+// 128-bit values they represent, and thus ranges can be checked by comparing
+// the strings directly.
+//
+// A range is marshaled into JSON as an object like `{"lo":"...","hi":"..."}`
+// with the bounds in the Uuid25 format, and unmarshaled from such an object
+// with the bounds in any supported format.
+type Range struct {
+	Lo Uuid25 `json:"lo"` // the inclusive lower bound
+	Hi Uuid25 `json:"hi"` // the inclusive upper bound
+}
+
+// Returns the inclusive lower bound of the range.
+func (r Range) Min() Uuid25 {
+	return r.Lo
+}
+
+// Returns the inclusive upper bound of the range.
+func (r Range) Max() Uuid25 {
+	return r.Hi
+}
+
+// Reports whether a UUID is within the range.
+func (r Range) Contains(uuid25 Uuid25) bool {
+	return r.Lo.String() <= uuid25.String() && uuid25.String() <= r.Hi.String()
+}
+
+// Reports whether two ranges share at least one UUID.
+func (r Range) Overlaps(other Range) bool {
+	return r.Lo.String() <= other.Hi.String() && other.Lo.String() <= r.Hi.String()
+}
+
+// Implements the json.Unmarshaler interface.
+//
+// This method returns an error if either bound is missing or invalid or the
+// lower bound is greater than the upper bound.
+func (r *Range) UnmarshalJSON(data []byte) error {
+	if r == nil {
+		return errors.New("nil receiver")
+	}
+	var bounds struct {
+		Lo *Uuid25 `json:"lo"`
+		Hi *Uuid25 `json:"hi"`
+	}
+	if err := json.Unmarshal(data, &bounds); err != nil {
+		return err
+	}
+	if bounds.Lo == nil || bounds.Hi == nil {
+		return errors.New("missing range bound")
+	}
+	if bounds.Lo.String() > bounds.Hi.String() {
+		return errors.New("lower bound greater than upper bound")
+	}
+	r.Lo, r.Hi = *bounds.Lo, *bounds.Hi
+	return nil
+}
+
+// An error returned when the successor of the Max UUID or the predecessor of
+// the Nil UUID is requested.
+var ErrOutOfRange = errors.New("UUID value out of range")
+
+// Returns the UUID whose 128-bit value is greater by one than this one.
+//
+// This method returns ErrOutOfRange if the receiver is the Max UUID. It is
+// useful to turn an inclusive bound into an exclusive one, and vice versa, in
+// keyset pagination over UUID-ordered indexes.
+func (uuid25 Uuid25) Next() (Uuid25, error) {
+	x, carry := uuid25.ToUint128().AddCarry(Uint128From64(1))
+	if carry != 0 {
+		return "", ErrOutOfRange
+	}
+	return FromUint128(x), nil
+}
+
+// Returns the UUID whose 128-bit value is less by one than this one.
+//
+// This method returns ErrOutOfRange if the receiver is the Nil UUID.
+func (uuid25 Uuid25) Prev() (Uuid25, error) {
+	x, borrow := uuid25.ToUint128().SubBorrow(Uint128From64(1))
+	if borrow != 0 {
+		return "", ErrOutOfRange
+	}
+	return FromUint128(x), nil
+}
+
+// Splits the entire 128-bit UUID space into `n` contiguous ranges of nearly
+// equal size in ascending order.
+//
+// The sizes of the ranges differ by at most one, and the first range starts at
+// the Nil UUID and the last ends at the Max UUID. This function is intended for
+// building shard maps and parallel table scans keyed by UUID. It panics if `n`
+// is not positive.
+func PartitionRange(n int) []Range {
+	if n <= 0 {
+		panic("number of partitions must be positive")
+	}
+
+	// compute size and remainder of 2^128 / n from (2^128 - 1) / n
+	divisor := uint64(n)
+	size, rem := Uint128{}.Not().DivMod64(divisor)
+	rem += 1
+	if rem == divisor {
+		size, rem = size.Inc(), 0
+	}
+
+	ranges := make([]Range, n)
+	lo := Uint128{}
+	for i := range ranges {
+		// the first `rem` ranges get one extra element
+		hi := lo.Add(size)
+		if uint64(i) >= rem {
+			hi = hi.Dec()
+		}
+		ranges[i] = Range{FromUint128(lo), FromUint128(hi)}
+		lo = hi.Inc()
+	}
+	return ranges
+}
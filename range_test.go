@@ -0,0 +1,144 @@
+package uuid25
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+)
+
+// Tests splitting the UUID space into contiguous ranges.
+func TestPartitionRange(t *testing.T) {
+	const Nil = "0000000000000000000000000"
+	const maxUuid25 = "f5lxx1zz5pnorynqglhzmsp33"
+
+	if r := PartitionRange(1); len(r) != 1 || r[0].Lo != Nil || r[0].Hi != maxUuid25 {
+		t.Fail()
+	}
+	if r := PartitionRange(2); len(r) != 2 ||
+		r[0].Hi.ToHex() != "7fffffffffffffffffffffffffffffff" ||
+		r[1].Lo.ToHex() != "80000000000000000000000000000000" {
+		t.Fail()
+	}
+	if r := PartitionRange(3); len(r) != 3 ||
+		r[0].Hi.ToHex() != "55555555555555555555555555555555" ||
+		r[1].Lo.ToHex() != "55555555555555555555555555555556" ||
+		r[1].Hi.ToHex() != "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa" ||
+		r[2].Lo.ToHex() != "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaab" {
+		t.Fail()
+	}
+
+	for _, n := range []int{1, 2, 3, 7, 16, 100, 1000} {
+		ranges := PartitionRange(n)
+		if len(ranges) != n || ranges[0].Min() != Nil || ranges[n-1].Max() != maxUuid25 {
+			t.Errorf("invalid bounds for n = %d", n)
+		}
+		for i := 1; i < n; i += 1 {
+			if u128FromHex(ranges[i-1].Hi).Inc() != u128FromHex(ranges[i].Lo) {
+				t.Errorf("ranges %d and %d are not contiguous for n = %d", i-1, i, n)
+			}
+		}
+	}
+}
+
+// Tests range containment checks.
+func TestRangeContains(t *testing.T) {
+	ranges := PartitionRange(7)
+	for _, e := range testCases {
+		x, _ := Parse(e.uuid25)
+		n := 0
+		for _, r := range ranges {
+			if r.Contains(x) {
+				n += 1
+			}
+		}
+		if n != 1 {
+			t.Errorf("%s must be contained in exactly one range", e.uuid25)
+		}
+	}
+}
+
+// Converts a Uuid25 value into a 128-bit integer through the hex format.
+func u128FromHex(uuid25 Uuid25) Uint128 {
+	var x Uint128
+	for _, c := range uuid25.ToHex() {
+		d, _ := strconv.ParseUint(string(c), 16, 64)
+		x = Uint128{x.Hi<<4 | x.Lo>>60, x.Lo<<4 | d}
+	}
+	return x
+}
+
+// Tests range overlap checks.
+func TestRangeOverlaps(t *testing.T) {
+	r := Range{"0000000000000000000000010", "0000000000000000000000020"}
+	cases := []struct {
+		other    Range
+		expected bool
+	}{
+		{Range{"0000000000000000000000000", "000000000000000000000000z"}, false},
+		{Range{"0000000000000000000000000", "0000000000000000000000010"}, true},
+		{Range{"0000000000000000000000015", "0000000000000000000000016"}, true},
+		{Range{"0000000000000000000000000", "f5lxx1zz5pnorynqglhzmsp33"}, true},
+		{Range{"0000000000000000000000020", "0000000000000000000000030"}, true},
+		{Range{"0000000000000000000000021", "0000000000000000000000030"}, false},
+	}
+	for _, e := range cases {
+		if r.Overlaps(e.other) != e.expected || e.other.Overlaps(r) != e.expected {
+			t.Errorf("unexpected overlap result for %v", e.other)
+		}
+	}
+}
+
+// Tests JSON marshaling of ranges.
+func TestRangeJSON(t *testing.T) {
+	r := PartitionRange(2)[1]
+	data, err := json.Marshal(r)
+	if string(data) != `{"lo":"7ksyyizzkutudzbv8aqztecjk","hi":"f5lxx1zz5pnorynqglhzmsp33"}` || err != nil {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	var x Range
+	if json.Unmarshal(data, &x) != nil || x != r {
+		t.Fail()
+	}
+	if json.Unmarshal([]byte(`{"lo":"80000000-0000-0000-0000-000000000000","hi":"ffffffffffffffffffffffffffffffff"}`), &x) != nil || x != r {
+		t.Fail()
+	}
+	for _, e := range []string{
+		`{"lo":"0000000000000000000000000"}`,
+		`{"lo":"0000000000000000000000001","hi":"0000000000000000000000000"}`,
+		`{"lo":"foo","hi":"0000000000000000000000000"}`,
+		`[]`,
+	} {
+		if json.Unmarshal([]byte(e), &x) == nil {
+			t.Errorf("%s must be rejected", e)
+		}
+	}
+}
+
+// Tests successor and predecessor computation.
+func TestNextPrev(t *testing.T) {
+	cases := []struct{ x, next string }{
+		{"00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-000000000001"},
+		{"00000000-0000-0000-ffff-ffffffffffff", "00000000-0000-0001-0000-000000000000"},
+		{"40eb9860-cf3e-45e2-a90e-b82236ac806c", "40eb9860-cf3e-45e2-a90e-b82236ac806d"},
+		{"7fffffff-ffff-ffff-ffff-ffffffffffff", "80000000-0000-0000-0000-000000000000"},
+		{"ffffffff-ffff-ffff-ffff-fffffffffffe", "ffffffff-ffff-ffff-ffff-ffffffffffff"},
+	}
+	for _, e := range cases {
+		x, _ := Parse(e.x)
+		next, _ := Parse(e.next)
+		if y, err := x.Next(); y != next || err != nil {
+			t.Errorf("unexpected Next() of %s: %s", e.x, y.ToHyphenated())
+		}
+		if y, err := next.Prev(); y != x || err != nil {
+			t.Errorf("unexpected Prev() of %s: %s", e.next, y.ToHyphenated())
+		}
+	}
+
+	if _, err := Uuid25("f5lxx1zz5pnorynqglhzmsp33").Next(); err != ErrOutOfRange {
+		t.Fail()
+	}
+	if _, err := Uuid25("0000000000000000000000000").Prev(); err != ErrOutOfRange {
+		t.Fail()
+	}
+}
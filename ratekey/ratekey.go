@@ -0,0 +1,52 @@
+// Rate-limiter keys derived from Uuid25 values
+//
+// The functions in this package turn an entity ID, such as a user or API
+// client ID, and a scope, such as `"login"` or `"api:search"`, into keys for
+// common rate-limiter libraries: strings for Redis-backed limiters, byte
+// slices for byte-keyed caches, and uint64 values for in-memory limiter maps.
+// Keys of the same ID in different scopes never coincide, so one throttle per
+// scope can share a single backing store.
+package ratekey
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Returns the readable key `<scope>:<uuid25>`, which is unique for each pair
+// of scope and ID as long as scopes do not contain a colon followed by 25
+// Base36 digits at the end.
+func String(scope string, id uuid25.Uuid25) string {
+	return scope + ":" + id.String()
+}
+
+// Returns a 16-byte key derived from the SHA-256 hash of the scope and the ID.
+//
+// Different pairs of scope and ID collide only with the probability of a
+// collision of random 128-bit values.
+func Bytes(scope string, id uuid25.Uuid25) []byte {
+	h := hash(scope, id)
+	return h[:16]
+}
+
+// Returns a 64-bit key derived from the SHA-256 hash of the scope and the ID.
+//
+// Different pairs of scope and ID collide with the probability of a collision
+// of random 64-bit values, which is negligible for up to millions of active
+// keys in a single limiter.
+func Uint64(scope string, id uuid25.Uuid25) uint64 {
+	h := hash(scope, id)
+	return binary.BigEndian.Uint64(h[:8])
+}
+
+// Returns the SHA-256 hash of the length-prefixed scope followed by the binary
+// representation of the ID.
+func hash(scope string, id uuid25.Uuid25) [sha256.Size]byte {
+	buf := make([]byte, 0, 8+len(scope)+16)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(len(scope)))
+	buf = append(buf, scope...)
+	buf = id.AppendWire(buf)
+	return sha256.Sum256(buf)
+}
@@ -0,0 +1,35 @@
+package ratekey
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests that keys are stable and separate scopes and IDs.
+func TestKeys(t *testing.T) {
+	a, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	b, _ := uuid25.Parse("40eb9860-cf3e-45e2-a90e-b82236ac806c")
+
+	if String("login", a) != "login:dpoadk8izg9y4tte7vy1xt94o" {
+		t.Fail()
+	}
+
+	if len(Bytes("login", a)) != 16 || !bytes.Equal(Bytes("login", a), Bytes("login", a)) {
+		t.Fail()
+	}
+	if bytes.Equal(Bytes("login", a), Bytes("login", b)) || bytes.Equal(Bytes("login", a), Bytes("logi", a)) {
+		t.Fail()
+	}
+
+	if Uint64("login", a) != Uint64("login", a) {
+		t.Fail()
+	}
+	if Uint64("login", a) == Uint64("search", a) || Uint64("login", a) == Uint64("login", b) {
+		t.Fail()
+	}
+	if Uint64("", a) == Uint64("", b) {
+		t.Fail()
+	}
+}
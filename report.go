@@ -0,0 +1,146 @@
+package uuid25
+
+import "errors"
+
+// A UUID string format.
+type Format int
+
+const (
+	// Not a valid UUID string.
+	FormatInvalid Format = iota
+
+	// 25-digit Base36 Uuid25 format: `3ud3gtvgolimgu9lah6aie99o`.
+	FormatUuid25
+
+	// 32-digit hexadecimal format without hyphens:
+	// `40eb9860cf3e45e2a90eb82236ac806c`.
+	FormatHex
+
+	// 8-4-4-4-12 hyphenated format: `40eb9860-cf3e-45e2-a90e-b82236ac806c`.
+	FormatHyphenated
+
+	// Hyphenated format with surrounding braces:
+	// `{40eb9860-cf3e-45e2-a90e-b82236ac806c}`.
+	FormatBraced
+
+	// RFC 4122 URN format: `urn:uuid:40eb9860-cf3e-45e2-a90e-b82236ac806c`.
+	FormatUrn
+)
+
+var formatNames = [...]string{"invalid", "uuid25", "hex", "hyphenated", "braced", "urn"}
+
+// Returns the name of the format, which is one of "invalid", "uuid25", "hex",
+// "hyphenated", "braced", and "urn".
+func (f Format) String() string {
+	if f < 0 || int(f) >= len(formatNames) {
+		return "unknown"
+	}
+	return formatNames[f]
+}
+
+// Implements the encoding.TextMarshaler interface.
+func (f Format) MarshalText() ([]byte, error) {
+	if f < 0 || int(f) >= len(formatNames) {
+		return nil, errors.New("unknown format")
+	}
+	return []byte(formatNames[f]), nil
+}
+
+// Implements the encoding.TextUnmarshaler interface.
+func (f *Format) UnmarshalText(text []byte) error {
+	if f == nil {
+		return errors.New("nil receiver")
+	}
+	for i, e := range formatNames {
+		if e == string(text) {
+			*f = Format(i)
+			return nil
+		}
+	}
+	return errors.New("unknown format")
+}
+
+// Returns the format of a UUID string, or FormatInvalid if the string is not
+// valid in any supported format.
+func DetectFormat(s string) Format {
+	f, _, _ := detect(s)
+	return f
+}
+
+// Returns the format of a UUID string, the parsed value, and whether the string
+// is in the canonical (lowercase) form of the format.
+func detect(s string) (Format, Uuid25, bool) {
+	uuid25, err := Parse(s)
+	if err != nil {
+		return FormatInvalid, "", false
+	}
+	switch len(s) {
+	case 25:
+		return FormatUuid25, uuid25, s == uuid25.String()
+	case 32:
+		return FormatHex, uuid25, s == uuid25.ToHex()
+	case 36:
+		return FormatHyphenated, uuid25, s == uuid25.ToHyphenated()
+	case 38:
+		return FormatBraced, uuid25, s == uuid25.ToBraced()
+	case 45:
+		return FormatUrn, uuid25, s == uuid25.ToUrn()
+	default:
+		panic("unreachable")
+	}
+}
+
+// The maximum number of invalid entries kept in Report.InvalidSamples.
+const ReportSampleLimit = 10
+
+// A summary of the formats of a dataset of UUID strings.
+//
+// The zero value is an empty report ready to use. A report is marshaled into
+// JSON with the format names as the keys of `formats`.
+type Report struct {
+	// The number of entries examined.
+	Total int `json:"total"`
+
+	// The number of valid entries per format.
+	Formats map[Format]int `json:"formats"`
+
+	// The number of valid entries not in the canonical form of their format,
+	// such as those with uppercase letters.
+	NonCanonical int `json:"non_canonical"`
+
+	// The number of invalid entries.
+	Invalid int `json:"invalid"`
+
+	// The first invalid entries, up to ReportSampleLimit.
+	InvalidSamples []string `json:"invalid_samples,omitempty"`
+}
+
+// Adds an entry to the report and returns its detected format.
+func (r *Report) Add(s string) Format {
+	f, _, canonical := detect(s)
+	r.Total += 1
+	if f == FormatInvalid {
+		r.Invalid += 1
+		if len(r.InvalidSamples) < ReportSampleLimit {
+			r.InvalidSamples = append(r.InvalidSamples, s)
+		}
+		return f
+	}
+	if r.Formats == nil {
+		r.Formats = make(map[Format]int)
+	}
+	r.Formats[f] += 1
+	if !canonical {
+		r.NonCanonical += 1
+	}
+	return f
+}
+
+// Builds a report from the strings returned by `next` until it returns false.
+func BuildReport(next func() (string, bool)) *Report {
+	r := &Report{}
+	for s, ok := next(); ok; s, ok = next() {
+		r.Add(s)
+	}
+	return r
+}
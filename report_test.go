@@ -0,0 +1,76 @@
+package uuid25
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// Tests format detection.
+func TestDetectFormat(t *testing.T) {
+	for _, e := range testCases {
+		if DetectFormat(e.uuid25) != FormatUuid25 ||
+			DetectFormat(e.hex) != FormatHex ||
+			DetectFormat(e.hyphenated) != FormatHyphenated ||
+			DetectFormat(e.braced) != FormatBraced ||
+			DetectFormat(e.urn) != FormatUrn {
+			t.Fail()
+		}
+	}
+	if DetectFormat("") != FormatInvalid || DetectFormat("zzzzzzzzzzzzzzzzzzzzzzzzz") != FormatInvalid {
+		t.Fail()
+	}
+
+	for f := FormatInvalid; f <= FormatUrn; f += 1 {
+		var g Format
+		text, err := f.MarshalText()
+		if err != nil || g.UnmarshalText(text) != nil || g != f || string(text) != f.String() {
+			t.Fail()
+		}
+	}
+	if _, err := Format(6).MarshalText(); err == nil {
+		t.Fail()
+	}
+}
+
+// Tests building a report from a dataset.
+func TestReport(t *testing.T) {
+	inputs := []string{
+		"3ud3gtvgolimgu9lah6aie99o",
+		"3UD3GTVGOLIMGU9LAH6AIE99O",
+		"40eb9860-cf3e-45e2-a90e-b82236ac806c",
+		"40EB9860-CF3E-45E2-A90E-B82236AC806C",
+		"URN:UUID:40eb9860-cf3e-45e2-a90e-b82236ac806c",
+		"{40eb9860-cf3e-45e2-a90e-b82236ac806c}",
+		"40eb9860cf3e45e2a90eb82236ac806c",
+		"not a uuid",
+		"",
+	}
+	i := 0
+	r := BuildReport(func() (string, bool) {
+		if i >= len(inputs) {
+			return "", false
+		}
+		i += 1
+		return inputs[i-1], true
+	})
+	if r.Total != 9 || r.Invalid != 2 || r.NonCanonical != 3 ||
+		r.Formats[FormatUuid25] != 2 || r.Formats[FormatHyphenated] != 2 ||
+		r.Formats[FormatUrn] != 1 || r.Formats[FormatBraced] != 1 || r.Formats[FormatHex] != 1 {
+		t.Errorf("unexpected report: %+v", r)
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil || !strings.Contains(string(data), `"formats":{"braced":1,"hex":1,"hyphenated":2,"urn":1,"uuid25":2}`) ||
+		!strings.Contains(string(data), `"invalid_samples":["not a uuid",""]`) {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	var many Report
+	for i := 0; i < 2*ReportSampleLimit; i += 1 {
+		many.Add("invalid")
+	}
+	if many.Invalid != 2*ReportSampleLimit || len(many.InvalidSamples) != ReportSampleLimit {
+		t.Fail()
+	}
+}
@@ -0,0 +1,59 @@
+package uuid25
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// A uniform random sample of fixed size from a stream of UUIDs.
+//
+// A reservoir keeps up to `size` IDs such that each ID added so far is
+// included in the sample with the same probability, using Algorithm R. It uses
+// memory proportional to the sample size regardless of the stream length and
+// is safe for concurrent use.
+type Reservoir struct {
+	mu     sync.Mutex
+	rand   *rand.Rand
+	sample []Uuid25
+	count  uint64
+}
+
+// Creates a reservoir that keeps up to `size` IDs and draws random numbers
+// from a source seeded with `seed`.
+//
+// This function panics if `size` is not positive.
+func NewReservoir(size int, seed int64) *Reservoir {
+	if size <= 0 {
+		panic("size must be positive")
+	}
+	return &Reservoir{
+		rand:   rand.New(rand.NewSource(seed)),
+		sample: make([]Uuid25, 0, size),
+	}
+}
+
+// Adds an ID from the stream to the reservoir.
+func (r *Reservoir) Add(id Uuid25) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.count += 1
+	if len(r.sample) < cap(r.sample) {
+		r.sample = append(r.sample, id)
+	} else if j := r.rand.Int63n(int64(r.count)); j < int64(len(r.sample)) {
+		r.sample[j] = id
+	}
+}
+
+// Returns a copy of the current sample in no particular order.
+func (r *Reservoir) Sample() []Uuid25 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Uuid25(nil), r.sample...)
+}
+
+// Returns the number of IDs added to the reservoir.
+func (r *Reservoir) Count() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count
+}
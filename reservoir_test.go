@@ -0,0 +1,47 @@
+package uuid25
+
+import "testing"
+
+// Tests that Reservoir keeps every ID until it is full.
+func TestReservoirFill(t *testing.T) {
+	r := NewReservoir(4, 1)
+	if len(r.Sample()) != 0 {
+		t.Fail()
+	}
+	for i := 0; i < 3; i += 1 {
+		r.Add(FromUint128(Uint128From64(uint64(i))))
+	}
+	s := r.Sample()
+	if len(s) != 3 || r.Count() != 3 || s[2] != FromUint128(Uint128From64(2)) {
+		t.Fail()
+	}
+	s[0] = ""
+	if r.Sample()[0] == "" {
+		t.Fail()
+	}
+}
+
+// Tests that Reservoir samples IDs roughly uniformly.
+func TestReservoirUniform(t *testing.T) {
+	const n, size, rounds = 20, 5, 4000
+	hits := make([]int, n)
+	for round := 0; round < rounds; round += 1 {
+		r := NewReservoir(size, int64(round))
+		for i := 0; i < n; i += 1 {
+			r.Add(FromUint128(Uint128From64(uint64(i))))
+		}
+		if len(r.Sample()) != size || r.Count() != n {
+			t.Fail()
+		}
+		for _, e := range r.Sample() {
+			hits[e.ToUint128().Lo] += 1
+		}
+	}
+	want := rounds * size / n
+	for _, e := range hits {
+		if e < want*8/10 || e > want*12/10 {
+			t.Errorf("hits = %v", hits)
+			break
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package uuid25
+
+import "math"
+
+// Returns the IDs that fall into a deterministic sample of `pct` percent of all
+// IDs under `salt`, preserving their order.
+//
+// Whether an ID is in the sample depends only on the ID, the percentage, and
+// the salt, such as the name of a backfill, so the same users are always in or
+// out across runs and processes. Samples are nested: raising the percentage
+// only adds IDs, which suits canary rollouts widened step by step. Different
+// salts select independent samples. `pct` is clamped to the range [0, 100].
+func SampleFraction(ids []Uuid25, pct float64, salt string) []Uuid25 {
+	threshold := sampleThreshold(pct)
+	var sample []Uuid25
+	for _, e := range ids {
+		if saltedKey([]byte(salt), e).Hi < threshold {
+			sample = append(sample, e)
+		}
+	}
+	return sample
+}
+
+// Reports whether an ID falls into the sample selected by SampleFraction()
+// with the same percentage and salt.
+func InSample(id Uuid25, pct float64, salt string) bool {
+	return saltedKey([]byte(salt), id).Hi < sampleThreshold(pct)
+}
+
+// Returns the threshold of the most significant 64 bits of salted keys below
+// which an ID is in a sample of `pct` percent.
+func sampleThreshold(pct float64) uint64 {
+	if !(pct > 0) {
+		return 0
+	} else if pct >= 100 {
+		return math.MaxUint64
+	}
+	return uint64(math.Ldexp(pct/100, 64))
+}
@@ -0,0 +1,42 @@
+package uuid25
+
+import "testing"
+
+// Tests the size, stability, and nesting of samples.
+func TestSampleFraction(t *testing.T) {
+	ids := make([]Uuid25, 10_000)
+	for i := range ids {
+		ids[i] = FromUint128(Uint128From64(uint64(i)))
+	}
+	ten := SampleFraction(ids, 10, "backfill-1")
+	if len(ten) < 900 || len(ten) > 1100 {
+		t.Errorf("got %d", len(ten))
+	}
+	twenty := SampleFraction(ids, 20, "backfill-1")
+	in := make(map[Uuid25]bool)
+	for _, e := range twenty {
+		in[e] = true
+	}
+	for _, e := range ten {
+		if !in[e] || !InSample(e, 10, "backfill-1") {
+			t.Fatal("samples must be nested")
+		}
+	}
+	other := SampleFraction(ids, 10, "backfill-2")
+	overlap := 0
+	for _, e := range other {
+		if InSample(e, 10, "backfill-1") {
+			overlap += 1
+		}
+	}
+	if overlap > 200 {
+		t.Fail()
+	}
+
+	if len(SampleFraction(ids, 0, "x")) != 0 || len(SampleFraction(ids, -1, "x")) != 0 {
+		t.Fail()
+	}
+	if all := SampleFraction(ids, 100, "x"); len(all) != len(ids) || all[1] != ids[1] {
+		t.Fail()
+	}
+}
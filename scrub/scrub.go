@@ -0,0 +1,92 @@
+// Scanning and rewriting of UUIDs embedded in text
+//
+// The functions in this package find UUID strings in any supported format in
+// arbitrary text, such as log lines and payload dumps, and rewrite them into
+// the Uuid25 format, mask them, or collect them. This is useful for
+// normalizing logs from services that print IDs in different formats, and for
+// removing IDs from data leaving a trusted environment.
+//
+// UUID strings are recognized by uuid25.FindAll, which ignores parts of longer
+// alphanumeric tokens.
+package scrub
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// The default replacement used by Mask.
+const DefaultMask = "[uuid]"
+
+// Returns a copy of `text` in which each UUID string is replaced by the result
+// of `fn`, which receives the parsed value and the original string.
+func ReplaceAll(text string, fn func(id uuid25.Uuid25, original string) string) string {
+	var b strings.Builder
+	last := 0
+	forEach(text, func(start int, end int, id uuid25.Uuid25) {
+		if last == 0 {
+			b.Grow(len(text))
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(fn(id, text[start:end]))
+		last = end
+	})
+	if last == 0 {
+		return text
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// Returns a copy of `text` in which each UUID string is rewritten into the
+// Uuid25 format.
+func Normalize(text string) string {
+	return ReplaceAll(text, func(id uuid25.Uuid25, _ string) string { return id.String() })
+}
+
+// Returns a copy of `text` in which each UUID string is replaced by
+// `replacement`, or DefaultMask if `replacement` is empty.
+func Mask(text string, replacement string) string {
+	if replacement == "" {
+		replacement = DefaultMask
+	}
+	return ReplaceAll(text, func(uuid25.Uuid25, string) string { return replacement })
+}
+
+// Returns the UUIDs found in `text` in the order of appearance.
+func Collect(text string) []uuid25.Uuid25 {
+	var ids []uuid25.Uuid25
+	forEach(text, func(_ int, _ int, id uuid25.Uuid25) { ids = append(ids, id) })
+	return ids
+}
+
+// Copies lines from `r` to `w`, rewriting each line with `fn`, such as
+// Normalize, until the end of `r`.
+//
+// Lines are terminated by `\n` in the output. Lines longer than 1 MiB cause an
+// error.
+func Lines(w io.Writer, r io.Reader, fn func(line string) string) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	writer := bufio.NewWriter(w)
+	for scanner.Scan() {
+		writer.WriteString(fn(scanner.Text()))
+		if err := writer.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+// Calls `fn` with the position and value of each UUID string in `text`.
+func forEach(text string, fn func(start int, end int, id uuid25.Uuid25)) {
+	for _, m := range uuid25.FindAll(text) {
+		fn(m.Start, m.End, m.Value)
+	}
+}
@@ -0,0 +1,58 @@
+package scrub
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+const sample = `GET /users/e7a1d63b-7117-4423-8988-afcf12161878/orders?ref={40EB9860-CF3E-45E2-A90E-B82236AC806C} ` +
+	`trace=urn:uuid:e7a1d63b-7117-4423-8988-afcf12161878 span=3ud3gtvgolimgu9lah6aie99o ` +
+	`x=e7a1d63b711744238988afcf12161878a y=e7a1d63b711744238988afcf12161878`
+
+// Tests Normalize with UUIDs in every format and a non-matching longer token.
+func TestNormalize(t *testing.T) {
+	want := `GET /users/dpoadk8izg9y4tte7vy1xt94o/orders?ref=3ud3gtvgolimgu9lah6aie99o ` +
+		`trace=dpoadk8izg9y4tte7vy1xt94o span=3ud3gtvgolimgu9lah6aie99o ` +
+		`x=e7a1d63b711744238988afcf12161878a y=dpoadk8izg9y4tte7vy1xt94o`
+	if got := Normalize(sample); got != want {
+		t.Errorf("got %q", got)
+	}
+	if Normalize("no ids here") != "no ids here" {
+		t.Fail()
+	}
+}
+
+// Tests Mask and Collect.
+func TestMaskCollect(t *testing.T) {
+	if Mask("a e7a1d63b-7117-4423-8988-afcf12161878 b", "") != "a [uuid] b" {
+		t.Fail()
+	}
+	if Mask("3ud3gtvgolimgu9lah6aie99o", "***") != "***" {
+		t.Fail()
+	}
+	ids := Collect(sample)
+	want := []uuid25.Uuid25{"dpoadk8izg9y4tte7vy1xt94o", "3ud3gtvgolimgu9lah6aie99o", "dpoadk8izg9y4tte7vy1xt94o", "3ud3gtvgolimgu9lah6aie99o", "dpoadk8izg9y4tte7vy1xt94o"}
+	if len(ids) != len(want) {
+		t.Fatalf("got %v", ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fail()
+		}
+	}
+}
+
+// Tests Lines.
+func TestLines(t *testing.T) {
+	var buffer bytes.Buffer
+	input := "id=e7a1d63b-7117-4423-8988-afcf12161878\nplain\n"
+	if Lines(&buffer, strings.NewReader(input), Normalize) != nil {
+		t.Fail()
+	}
+	if buffer.String() != "id=dpoadk8izg9y4tte7vy1xt94o\nplain\n" {
+		t.Fail()
+	}
+}
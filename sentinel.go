@@ -0,0 +1,28 @@
+package uuid25
+
+// The Nil UUID `00000000-0000-0000-0000-000000000000`, with all 128 bits set to
+// zero, in the Uuid25 format.
+//
+// Note that the Nil UUID differs from the zero value of Uuid25, which is an
+// empty string and not a valid value.
+const Nil Uuid25 = "0000000000000000000000000"
+
+// The Max UUID `ffffffff-ffff-ffff-ffff-ffffffffffff`, with all 128 bits set to
+// one, in the Uuid25 format.
+const Max Uuid25 = "f5lxx1zz5pnorynqglhzmsp33"
+
+// Reports whether the UUID is the Nil UUID.
+//
+// This method panics if the receiver is not constructed properly, including
+// the zero value.
+func (uuid25 Uuid25) IsNil() bool {
+	return uuid25.String() == string(Nil)
+}
+
+// Reports whether the UUID is the Max UUID.
+//
+// This method panics if the receiver is not constructed properly, including
+// the zero value.
+func (uuid25 Uuid25) IsMax() bool {
+	return uuid25.String() == string(Max)
+}
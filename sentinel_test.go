@@ -0,0 +1,30 @@
+package uuid25
+
+import "testing"
+
+// Tests the Nil and Max UUID sentinels.
+func TestSentinels(t *testing.T) {
+	if MustParse("00000000-0000-0000-0000-000000000000") != Nil ||
+		MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff") != Max {
+		t.Fail()
+	}
+	if !Nil.IsNil() || Nil.IsMax() || !Max.IsMax() || Max.IsNil() {
+		t.Fail()
+	}
+	if Nil.Variant() != VariantNCS || Max.Variant() != VariantFuture {
+		t.Fail()
+	}
+	for _, e := range testCases {
+		x := Uuid25(e.uuid25)
+		if x.IsNil() != (x == Nil) || x.IsMax() != (x == Max) {
+			t.Fail()
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fail()
+		}
+	}()
+	Uuid25("").IsNil()
+}
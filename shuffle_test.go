@@ -0,0 +1,76 @@
+package uuid25
+
+import (
+	"sort"
+	"testing"
+)
+
+// Tests that ShuffleDeterministic depends only on the seed and the set of IDs.
+func TestShuffleDeterministic(t *testing.T) {
+	ids := make([]Uuid25, 100)
+	for i := range ids {
+		ids[i] = FromUint128(Uint128From64(uint64(i)))
+	}
+	a := append([]Uuid25(nil), ids...)
+	b := append([]Uuid25(nil), ids...)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	ShuffleDeterministic(a, 42)
+	ShuffleDeterministic(b, 42)
+	moved := 0
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatal("order must not depend on input order")
+		}
+		if a[i] != ids[i] {
+			moved += 1
+		}
+	}
+	if moved < 90 {
+		t.Fail()
+	}
+
+	c := append([]Uuid25(nil), ids...)
+	ShuffleDeterministic(c, 43)
+	same := 0
+	for i := range a {
+		if a[i] == c[i] {
+			same += 1
+		}
+	}
+	if same > 10 {
+		t.Fail()
+	}
+	if (Uuid25Slice)(c).Sort(); c[0] != ids[0] || c[99] != ids[99] {
+		t.Fail()
+	}
+}
+
+// Tests that StableOrderBySalt gives consistent orders across subsets.
+func TestStableOrderBySalt(t *testing.T) {
+	ids := make([]Uuid25, 50)
+	for i := range ids {
+		ids[i] = FromUint128(Uint128From64(uint64(i)))
+	}
+	less := StableOrderBySalt("experiment-1")
+	sort.Slice(ids, func(i, j int) bool { return less(ids[i], ids[j]) })
+	subset := []Uuid25{ids[40], ids[3], ids[25], ids[10]}
+	sort.Slice(subset, func(i, j int) bool { return less(subset[i], subset[j]) })
+	if subset[0] != ids[3] || subset[1] != ids[10] || subset[2] != ids[25] || subset[3] != ids[40] {
+		t.Fail()
+	}
+	if less(ids[0], ids[0]) {
+		t.Fail()
+	}
+	other := StableOrderBySalt("experiment-2")
+	disagree := 0
+	for i := 1; i < len(ids); i += 1 {
+		if !other(ids[i-1], ids[i]) {
+			disagree += 1
+		}
+	}
+	if disagree < 10 {
+		t.Fail()
+	}
+}
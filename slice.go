@@ -0,0 +1,141 @@
+package uuid25
+
+import (
+	"database/sql/driver"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// A slice of Uuid25 values with convenience methods.
+//
+// This type is marshaled into a JSON array of Uuid25 strings, and it implements
+// the sql.Scanner and driver.Valuer interfaces with the PostgreSQL array literal
+// syntax such as `{3ud3gtvgolimgu9lah6aie99o,...}`, so it can be bound directly
+// to a `uuid[]` or `text[]` column.
+type Uuid25Slice []Uuid25
+
+// Reports whether the slice contains a UUID.
+func (s Uuid25Slice) Contains(uuid25 Uuid25) bool {
+	for _, e := range s {
+		if e == uuid25 {
+			return true
+		}
+	}
+	return false
+}
+
+// Removes duplicate elements in place, keeping the first occurrence of each
+// UUID in the original order, and returns the shortened slice.
+func (s Uuid25Slice) Dedup() Uuid25Slice {
+	seen := make(map[Uuid25]struct{}, len(s))
+	n := 0
+	for _, e := range s {
+		if _, ok := seen[e]; !ok {
+			seen[e] = struct{}{}
+			s[n] = e
+			n += 1
+		}
+	}
+	return s[:n]
+}
+
+// Sorts the slice in place in ascending order of the 128-bit values.
+func (s Uuid25Slice) Sort() {
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+}
+
+// Returns the Uuid25 strings of the elements.
+func (s Uuid25Slice) Strings() []string {
+	strs := make([]string, len(s))
+	for i, e := range s {
+		strs[i] = e.String()
+	}
+	return strs
+}
+
+// Returns the 16-byte representations of the elements concatenated into a
+// single byte slice.
+func (s Uuid25Slice) ToBytesConcat() []byte {
+	data := make([]byte, 0, 16*len(s))
+	for _, e := range s {
+		b := e.ToBytes()
+		data = append(data, b[:]...)
+	}
+	return data
+}
+
+// Implements the json.Marshaler interface.
+//
+// A nil slice is encoded as an empty JSON array rather than a JSON null.
+func (s Uuid25Slice) MarshalJSON() ([]byte, error) {
+	data := []byte{'['}
+	for i, e := range s {
+		if i > 0 {
+			data = append(data, ',')
+		}
+		text, err := e.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, '"')
+		data = append(data, text...)
+		data = append(data, '"')
+	}
+	return append(data, ']'), nil
+}
+
+// Implements the sql.Scanner interface.
+//
+// This method accepts a PostgreSQL array literal whose elements are in any
+// format accepted by Parse(), optionally double-quoted. A SQL NULL is scanned
+// as a nil slice, whereas a NULL element results in an error.
+func (s *Uuid25Slice) Scan(src any) error {
+	if s == nil {
+		return errors.New("nil receiver")
+	}
+	var literal string
+	switch src := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case string:
+		literal = src
+	case []byte:
+		literal = string(src)
+	default:
+		return errors.New("unsupported type conversion")
+	}
+
+	if len(literal) < 2 || literal[0] != '{' || literal[len(literal)-1] != '}' {
+		return errors.New("could not parse an array literal")
+	}
+	literal = literal[1 : len(literal)-1]
+	if literal == "" {
+		*s = Uuid25Slice{}
+		return nil
+	}
+
+	elems := strings.Split(literal, ",")
+	result := make(Uuid25Slice, len(elems))
+	for i, e := range elems {
+		if len(e) >= 2 && e[0] == '"' && e[len(e)-1] == '"' {
+			e = e[1 : len(e)-1]
+		}
+		var err error
+		if result[i], err = Parse(e); err != nil {
+			return err
+		}
+	}
+	*s = result
+	return nil
+}
+
+// Implements the driver.Valuer interface, returning a PostgreSQL array literal
+// of Uuid25 strings.
+func (s Uuid25Slice) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return "{" + strings.Join(s.Strings(), ",") + "}", nil
+}
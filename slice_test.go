@@ -0,0 +1,93 @@
+package uuid25
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+)
+
+// Tests the convenience methods of Uuid25Slice.
+func TestUuid25Slice(t *testing.T) {
+	var s Uuid25Slice
+	var concat []byte
+	for _, e := range testCases {
+		x, _ := Parse(e.uuid25)
+		s = append(s, x, x)
+		concat = append(concat, e.bytes...)
+	}
+	s = s.Dedup()
+	if len(s) != len(testCases) {
+		t.Fatal("Dedup must remove duplicates")
+	}
+	for i, e := range testCases {
+		if s[i].String() != e.uuid25 || !s.Contains(s[i]) {
+			t.Fail()
+		}
+		if s.Strings()[i] != e.uuid25 {
+			t.Fail()
+		}
+	}
+	if s[:1].Contains(s[1]) {
+		t.Fail()
+	}
+	if !bytes.Equal(s.ToBytesConcat(), concat) {
+		t.Fail()
+	}
+
+	s.Sort()
+	for i := 1; i < len(s); i += 1 {
+		a, b := s[i-1].ToBytes(), s[i].ToBytes()
+		if bytes.Compare(a[:], b[:]) > 0 {
+			t.Fatal("Sort must order elements by 128-bit values")
+		}
+	}
+}
+
+// Tests JSON and SQL array marshaling of Uuid25Slice.
+func TestUuid25SliceMarshalers(t *testing.T) {
+	s := Uuid25Slice{"3ud3gtvgolimgu9lah6aie99o", "0000000000000000000000000"}
+	if y, err := json.Marshal(s); string(y) != `["3ud3gtvgolimgu9lah6aie99o","0000000000000000000000000"]` || err != nil {
+		t.Fail()
+	}
+	if y, err := json.Marshal(Uuid25Slice(nil)); string(y) != "[]" || err != nil {
+		t.Fail()
+	}
+	var unmarshaled Uuid25Slice
+	if json.Unmarshal([]byte(`["40eb9860-cf3e-45e2-a90e-b82236ac806c","0000000000000000000000000"]`), &unmarshaled) != nil ||
+		len(unmarshaled) != 2 || unmarshaled[0] != s[0] || unmarshaled[1] != s[1] {
+		t.Fail()
+	}
+
+	v, err := s.Value()
+	if v.(string) != "{3ud3gtvgolimgu9lah6aie99o,0000000000000000000000000}" || err != nil {
+		t.Fail()
+	}
+	var scanned Uuid25Slice
+	if scanned.Scan(v) != nil || len(scanned) != 2 || scanned[0] != s[0] || scanned[1] != s[1] {
+		t.Fail()
+	}
+	if scanned.Scan([]byte(`{"40eb9860-cf3e-45e2-a90e-b82236ac806c",00000000-0000-0000-0000-000000000000}`)) != nil ||
+		len(scanned) != 2 || scanned[0] != s[0] || scanned[1] != s[1] {
+		t.Fail()
+	}
+	if scanned.Scan("{}") != nil || scanned == nil || len(scanned) != 0 {
+		t.Fail()
+	}
+	if scanned.Scan(nil) != nil || scanned != nil {
+		t.Fail()
+	}
+	if v, err := scanned.Value(); v != nil || err != nil {
+		t.Fail()
+	}
+	for _, e := range []any{"", "{", "{NULL}", "{3ud3gtvgolimgu9lah6aie99o,}", 42} {
+		if scanned.Scan(e) == nil {
+			t.Fail()
+		}
+	}
+
+	var _ json.Marshaler = s
+	var _ sql.Scanner = &s
+	var _ driver.Valuer = s
+}
@@ -0,0 +1,37 @@
+package uuid25
+
+import "strings"
+
+// Wraps a UUID string in a formula, `="<s>"`, that spreadsheet applications
+// such as Excel and Google Sheets display as the string itself.
+//
+// Spreadsheets interpret CSV fields that look like numbers, such as an
+// all-digit Uuid25 string or a hexadecimal string like `12345e10...`, as
+// numbers and drop leading zeros or convert them into exponential notation.
+// The formula keeps the value as text while importing. A CSV writer such as
+// encoding/csv quotes the result as `"=""<s>"""` automatically.
+func ToSpreadsheetSafe(s string) string {
+	return `="` + s + `"`
+}
+
+// Removes the wrappers that protect a UUID string from spreadsheets: a
+// surrounding `="..."` formula, a leading apostrophe, and surrounding
+// whitespace.
+//
+// Strings without such wrappers are returned with only whitespace trimmed.
+func TrimSpreadsheetSafe(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 3 && strings.HasPrefix(s, `="`) && strings.HasSuffix(s, `"`) {
+		return strings.TrimSpace(s[2 : len(s)-1])
+	} else if strings.HasPrefix(s, "'") {
+		return strings.TrimSpace(s[1:])
+	}
+	return s
+}
+
+// Creates an instance from a UUID string exported from a spreadsheet, in any
+// supported format, possibly wrapped by ToSpreadsheetSafe() or prefixed with
+// an apostrophe.
+func ParseSpreadsheetSafe(s string) (Uuid25, error) {
+	return Parse(TrimSpreadsheetSafe(s))
+}
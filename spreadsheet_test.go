@@ -0,0 +1,43 @@
+package uuid25
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+// Tests the round trip through spreadsheet-safe strings and CSV.
+func TestSpreadsheetSafe(t *testing.T) {
+	for _, e := range testCases {
+		for _, s := range []string{e.uuid25, e.hex, e.hyphenated} {
+			wrapped := ToSpreadsheetSafe(s)
+			if wrapped != `="`+s+`"` || TrimSpreadsheetSafe(wrapped) != s {
+				t.Fail()
+			}
+			if x, err := ParseSpreadsheetSafe(wrapped); x != Uuid25(e.uuid25) || err != nil {
+				t.Fail()
+			}
+			if x, err := ParseSpreadsheetSafe(" '" + s); x != Uuid25(e.uuid25) || err != nil {
+				t.Fail()
+			}
+		}
+	}
+
+	var buffer bytes.Buffer
+	w := csv.NewWriter(&buffer)
+	w.Write([]string{ToSpreadsheetSafe("00000000000000000000000001")})
+	w.Flush()
+	if buffer.String() != "\"=\"\"00000000000000000000000001\"\"\"\n" {
+		t.Fail()
+	}
+	records, _ := csv.NewReader(&buffer).ReadAll()
+	if TrimSpreadsheetSafe(records[0][0]) != "00000000000000000000000001" {
+		t.Fail()
+	}
+
+	for _, e := range []string{"", `="`, `""`, "'", "abc"} {
+		if _, err := ParseSpreadsheetSafe(e); err == nil {
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package sqlmigrate
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// The maximum number of issues kept in ColumnReport.Issues.
+const IssueLimit = 100
+
+// A row whose UUID string failed a check.
+type Issue struct {
+	// The 1-based position of the row in the result set.
+	Row int64 `json:"row"`
+
+	// The value of the column.
+	Value string `json:"value"`
+
+	// The reason: a parse error, uuid25.ErrNotCanonical, or
+	// uuid25.ErrRoundTrip.
+	Err error `json:"-"`
+}
+
+// Implements the json.Marshaler interface.
+//
+// The reason is encoded as the error message in the `error` field.
+func (e Issue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Row   int64  `json:"row"`
+		Value string `json:"value"`
+		Error string `json:"error"`
+	}{e.Row, e.Value, e.Err.Error()})
+}
+
+// Returns a description of the issue.
+func (e Issue) String() string {
+	return fmt.Sprintf("row %d: %q: %v", e.Row, e.Value, e.Err)
+}
+
+// A summary of the UUID strings in a column.
+type ColumnReport struct {
+	// The statistics of non-NULL values.
+	uuid25.Report
+
+	// The number of NULL values.
+	Null int `json:"null"`
+
+	// The number of rows with invalid or non-canonical values.
+	IssueCount int `json:"issue_count"`
+
+	// The first issues, up to IssueLimit.
+	Issues []Issue `json:"issues,omitempty"`
+}
+
+// Reads all rows of a result set and checks the UUID strings in the column at
+// the 0-based index `col` with uuid25.RoundTripCheck().
+//
+// This function is intended for preflight checks before migrating a text
+// column, such as with the statements of ConvertColumn, for example:
+//
+//	rows, err := db.QueryContext(ctx, "SELECT id FROM users")
+//	report, err := sqlmigrate.CheckColumn(rows, 0)
+//
+// The column must be scannable into a string, and NULL values are counted
+// separately. The rows are closed when this function returns.
+func CheckColumn(rows *sql.Rows, col int) (*ColumnReport, error) {
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if col < 0 || col >= len(columns) {
+		return nil, fmt.Errorf("column %d out of range", col)
+	}
+
+	dest := make([]any, len(columns))
+	for i := range dest {
+		dest[i] = new(sql.RawBytes)
+	}
+	var value sql.NullString
+	dest[col] = &value
+
+	report := &ColumnReport{}
+	for row := int64(1); rows.Next(); row += 1 {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		if !value.Valid {
+			report.Null += 1
+			continue
+		}
+		report.Add(value.String)
+		if err := uuid25.RoundTripCheck(value.String); err != nil {
+			report.IssueCount += 1
+			if len(report.Issues) < IssueLimit {
+				report.Issues = append(report.Issues, Issue{row, value.String, err})
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
@@ -0,0 +1,102 @@
+package sqlmigrate
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// A minimal driver serving the rows given as the data source name, with rows
+// separated by newlines and columns by commas, and `NULL` for NULL values.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn(name), nil }
+
+type fakeConn string
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt(c), nil }
+func (c fakeConn) Close() error                              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+type fakeStmt string
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return 0 }
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{lines: strings.Split(string(s), "\n")}, nil
+}
+
+type fakeRows struct{ lines []string }
+
+func (r *fakeRows) Columns() []string { return []string{"name", "id"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.lines) == 0 {
+		return io.EOF
+	}
+	for i, e := range strings.Split(r.lines[0], ",") {
+		if e == "NULL" {
+			dest[i] = nil
+		} else {
+			dest[i] = []byte(e)
+		}
+	}
+	r.lines = r.lines[1:]
+	return nil
+}
+
+func init() {
+	sql.Register("sqlmigrate-fake", fakeDriver{})
+}
+
+// Tests CheckColumn with valid, non-canonical, invalid, and NULL values.
+func TestCheckColumn(t *testing.T) {
+	db, _ := sql.Open("sqlmigrate-fake", strings.Join([]string{
+		"a,e7a1d63b-7117-4423-8988-afcf12161878",
+		"b,E7A1D63B-7117-4423-8988-AFCF12161878",
+		"c,NULL",
+		"d,dpoadk8izg9y4tte7vy1xt94o",
+		"e,foo",
+	}, "\n"))
+	defer db.Close()
+
+	rows, err := db.Query("SELECT name, id FROM t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := CheckColumn(rows, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Total != 4 || report.Null != 1 || report.Invalid != 1 || report.NonCanonical != 1 ||
+		report.Formats[uuid25.FormatHyphenated] != 2 || report.Formats[uuid25.FormatUuid25] != 1 {
+		t.Errorf("got %+v", report)
+	}
+	if report.IssueCount != 2 || len(report.Issues) != 2 {
+		t.Fatalf("got %+v", report.Issues)
+	}
+	if e := report.Issues[0]; e.Row != 2 || e.Err != uuid25.ErrNotCanonical {
+		t.Fail()
+	}
+	if e := report.Issues[1]; e.Row != 5 || e.Value != "foo" || !errors.Is(e.Err, uuid25.ErrParse) {
+		t.Fail()
+	}
+	data, _ := json.Marshal(report.Issues[0])
+	if string(data) != `{"row":2,"value":"E7A1D63B-7117-4423-8988-AFCF12161878","error":"UUID string not in canonical form"}` {
+		t.Errorf("got %s", data)
+	}
+
+	rows, _ = db.Query("SELECT name, id FROM t")
+	if _, err := CheckColumn(rows, 2); err == nil {
+		t.Fail()
+	}
+}
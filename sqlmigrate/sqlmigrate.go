@@ -0,0 +1,173 @@
+// SQL snippets for migrating UUID columns between storage formats
+//
+// The functions in this package emit dialect-specific SQL that converts UUID
+// columns between the text formats and the compact binary storage, which is
+// the native `uuid` type in PostgreSQL and a 16-byte binary string in MySQL
+// and SQLite. Binary values use the big-endian byte order of RFC 9562, as
+// produced by Uuid25.ToBytes() and stored by Uuid25.MarshalWire(); note that
+// MySQL's `UUID_TO_BIN(s, 1)` uses a different order and is incompatible.
+//
+// The Uuid25 text format cannot be converted in SQL because these databases
+// lack 128-bit integer arithmetic. Convert such columns in the application,
+// for example with the batch package, and the functions here return
+// ErrUnsupported for them.
+//
+// The generated statements are meant to be reviewed and embedded in the
+// migration files of a schema migration tool. Run CheckColumn beforehand to
+// find invalid and non-canonical values that would fail or change in the
+// conversion.
+package sqlmigrate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// An error returned when a conversion cannot be expressed in SQL.
+var ErrUnsupported = errors.New("conversion not supported in SQL")
+
+// A SQL dialect.
+type Dialect int
+
+const (
+	// PostgreSQL 9.4 or later.
+	Postgres Dialect = iota
+
+	// MySQL 8.0 or later.
+	MySQL
+
+	// SQLite 3.41 or later, which provides `unhex()`, `DROP COLUMN`, and
+	// `RENAME COLUMN`.
+	SQLite
+)
+
+// A storage format of a UUID column.
+type Storage int
+
+const (
+	// Compact binary storage: `uuid` in PostgreSQL, `BINARY(16)` in MySQL, and
+	// `BLOB` in SQLite.
+	Binary Storage = iota
+
+	// 8-4-4-4-12 hyphenated text.
+	Hyphenated
+
+	// 32-digit hexadecimal text.
+	Hex
+
+	// 25-digit Uuid25 text.
+	Uuid25
+)
+
+// Returns the column type for a storage format in a dialect.
+func ColumnType(d Dialect, s Storage) string {
+	switch d {
+	case Postgres:
+		return [...]string{"uuid", "char(36)", "char(32)", "char(25)"}[s]
+	case MySQL:
+		return [...]string{"BINARY(16)", "CHAR(36)", "CHAR(32)", "CHAR(25)"}[s]
+	case SQLite:
+		return [...]string{"BLOB", "TEXT", "TEXT", "TEXT"}[s]
+	default:
+		panic("unknown dialect")
+	}
+}
+
+// Returns a SQL expression that converts the value of `expr` from the storage
+// format `from` into `to`.
+//
+// The expression can be used in views and queries as well as in migrations.
+// Text is written in lowercase, and both cases are accepted. This function
+// returns ErrUnsupported if either format is Uuid25.
+func ConvertExpr(d Dialect, expr string, from Storage, to Storage) (string, error) {
+	if from == Uuid25 || to == Uuid25 {
+		return "", ErrUnsupported
+	} else if from == to {
+		return expr, nil
+	}
+	if d == Postgres {
+		if from == Hyphenated && to == Binary {
+			return "CAST(" + expr + " AS uuid)", nil
+		} else if from == Binary && to == Hyphenated {
+			return "CAST(" + expr + " AS text)", nil
+		}
+	}
+	return fromHex(d, to, toHex(d, from, expr)), nil
+}
+
+// Returns SQL statements that convert a column in place from the storage
+// format `from` into `to`.
+//
+// PostgreSQL converts the column with a single `ALTER TABLE` statement. For
+// MySQL and SQLite, the statements add a column named `<column>_new`, fill it,
+// drop the original column, and rename the new column, which does not carry
+// over indexes, constraints, or the column position; add them back afterwards.
+// This function returns ErrUnsupported if either format is Uuid25.
+func ConvertColumn(d Dialect, table string, column string, from Storage, to Storage) ([]string, error) {
+	expr, err := ConvertExpr(d, quote(d, column), from, to)
+	if err != nil {
+		return nil, err
+	}
+	t, c, tmp := quote(d, table), quote(d, column), quote(d, column+"_new")
+	if d == Postgres {
+		return []string{
+			fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s", t, c, ColumnType(d, to), expr),
+		}, nil
+	}
+	return []string{
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", t, tmp, ColumnType(d, to)),
+		fmt.Sprintf("UPDATE %s SET %s = %s", t, tmp, expr),
+		fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", t, c),
+		fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s", t, tmp, c),
+	}, nil
+}
+
+// Returns an expression that converts `expr` into lowercase 32-digit hex.
+func toHex(d Dialect, from Storage, expr string) string {
+	switch from {
+	case Hex:
+		return "lower(" + expr + ")"
+	case Hyphenated:
+		return "lower(replace(" + expr + ", '-', ''))"
+	case Binary:
+		if d == Postgres {
+			return "replace(CAST(" + expr + " AS text), '-', '')"
+		}
+		return "lower(hex(" + expr + "))"
+	default:
+		panic("unreachable")
+	}
+}
+
+// Returns an expression that converts 32-digit hex `h` into `to`.
+func fromHex(d Dialect, to Storage, h string) string {
+	switch to {
+	case Hex:
+		return h
+	case Hyphenated:
+		parts := make([]string, 5)
+		for i, e := range [...][2]int{{1, 8}, {9, 4}, {13, 4}, {17, 4}, {21, 12}} {
+			parts[i] = fmt.Sprintf("substr(%s, %d, %d)", h, e[0], e[1])
+		}
+		if d == MySQL {
+			return "concat_ws('-', " + strings.Join(parts, ", ") + ")"
+		}
+		return strings.Join(parts, " || '-' || ")
+	case Binary:
+		if d == Postgres {
+			return "CAST(" + h + " AS uuid)"
+		}
+		return "unhex(" + h + ")"
+	default:
+		panic("unreachable")
+	}
+}
+
+// Quotes an identifier in a dialect.
+func quote(d Dialect, ident string) string {
+	if d == MySQL {
+		return "`" + strings.ReplaceAll(ident, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+}
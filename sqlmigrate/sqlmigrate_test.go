@@ -0,0 +1,73 @@
+package sqlmigrate
+
+import "testing"
+
+// Tests ConvertExpr for each dialect.
+func TestConvertExpr(t *testing.T) {
+	cases := []struct {
+		d        Dialect
+		from, to Storage
+		want     string
+	}{
+		{Postgres, Hyphenated, Binary, `CAST(id AS uuid)`},
+		{Postgres, Binary, Hyphenated, `CAST(id AS text)`},
+		{Postgres, Hex, Binary, `CAST(lower(id) AS uuid)`},
+		{Postgres, Binary, Hex, `replace(CAST(id AS text), '-', '')`},
+		{MySQL, Hyphenated, Binary, `unhex(lower(replace(id, '-', '')))`},
+		{MySQL, Binary, Hyphenated, `concat_ws('-', substr(lower(hex(id)), 1, 8), substr(lower(hex(id)), 9, 4), substr(lower(hex(id)), 13, 4), substr(lower(hex(id)), 17, 4), substr(lower(hex(id)), 21, 12))`},
+		{SQLite, Hex, Binary, `unhex(lower(id))`},
+		{SQLite, Hex, Hyphenated, `substr(lower(id), 1, 8) || '-' || substr(lower(id), 9, 4) || '-' || substr(lower(id), 13, 4) || '-' || substr(lower(id), 17, 4) || '-' || substr(lower(id), 21, 12)`},
+		{SQLite, Binary, Binary, `id`},
+	}
+	for _, e := range cases {
+		if got, err := ConvertExpr(e.d, "id", e.from, e.to); got != e.want || err != nil {
+			t.Errorf("got %s, want %s", got, e.want)
+		}
+	}
+	if _, err := ConvertExpr(Postgres, "id", Uuid25, Binary); err != ErrUnsupported {
+		t.Fail()
+	}
+	if _, err := ConvertExpr(MySQL, "id", Binary, Uuid25); err != ErrUnsupported {
+		t.Fail()
+	}
+}
+
+// Tests ConvertColumn for each dialect.
+func TestConvertColumn(t *testing.T) {
+	got, err := ConvertColumn(Postgres, "users", "id", Hyphenated, Binary)
+	if err != nil || len(got) != 1 || got[0] != `ALTER TABLE "users" ALTER COLUMN "id" TYPE uuid USING CAST("id" AS uuid)` {
+		t.Errorf("got %q", got)
+	}
+
+	got, err = ConvertColumn(MySQL, "order`s", "id", Hex, Binary)
+	want := []string{
+		"ALTER TABLE `order``s` ADD COLUMN `id_new` BINARY(16)",
+		"UPDATE `order``s` SET `id_new` = unhex(lower(`id`))",
+		"ALTER TABLE `order``s` DROP COLUMN `id`",
+		"ALTER TABLE `order``s` RENAME COLUMN `id_new` TO `id`",
+	}
+	if err != nil || len(got) != len(want) {
+		t.Fatalf("got %q", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %q, want %q", got[i], want[i])
+		}
+	}
+
+	got, err = ConvertColumn(SQLite, "users", "id", Binary, Hex)
+	if err != nil || got[0] != `ALTER TABLE "users" ADD COLUMN "id_new" TEXT` || got[1] != `UPDATE "users" SET "id_new" = lower(hex("id"))` {
+		t.Errorf("got %q", got)
+	}
+
+	if _, err := ConvertColumn(SQLite, "users", "id", Uuid25, Hex); err != ErrUnsupported {
+		t.Fail()
+	}
+}
+
+// Tests ColumnType.
+func TestColumnType(t *testing.T) {
+	if ColumnType(Postgres, Binary) != "uuid" || ColumnType(MySQL, Hyphenated) != "CHAR(36)" || ColumnType(SQLite, Uuid25) != "TEXT" {
+		t.Fail()
+	}
+}
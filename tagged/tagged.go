@@ -0,0 +1,466 @@
+// Struct tag driven formatting of Uuid25 fields in JSON
+//
+// This package marshals structs into JSON while letting a `uuid25` struct tag
+// choose the output format of each Uuid25 field, so that a single payload can
+// mix representations, for example to keep a legacy hyphenated field next to
+// compact Uuid25 ones:
+//
+//	type Order struct {
+//		ID        uuid25.Uuid25   `json:"id"`
+//		LegacyID  uuid25.Uuid25   `json:"legacy_id" uuid25:"hyphenated"`
+//		ItemIDs   []uuid25.Uuid25 `json:"item_ids" uuid25:"hex"`
+//		ParentID  *uuid25.Uuid25  `json:"parent_id,omitempty" uuid25:"urn"`
+//	}
+//
+// The tag value is one of "uuid25", "hex", "hyphenated", "braced", and "urn",
+// and applies to fields of type Uuid25, *Uuid25, and []Uuid25, as well as
+// those using uuid25.ZeroAsNull or uuid25.ZeroAsNil in place of Uuid25. Nested and
+// embedded structs, including those in slices, arrays, maps, and interfaces,
+// are processed recursively. Other fields, the `json` tag options "-",
+// "omitempty", and "string", and the rules for conflicting names of fields
+// promoted from embedded structs are handled as in encoding/json. Since Uuid25
+// accepts all of the formats when unmarshaling, the output can be decoded with
+// json.Unmarshal().
+package tagged
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+var (
+	uuid25Type        = reflect.TypeOf(uuid25.Uuid25(""))
+	zeroAsNullType    = reflect.TypeOf(uuid25.ZeroAsNull(""))
+	zeroAsNilType     = reflect.TypeOf(uuid25.ZeroAsNil(""))
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Returns the JSON encoding of `v`, formatting Uuid25 fields as specified by
+// their `uuid25` struct tags.
+//
+// This function returns an error if a tag value is not a known format or a
+// Uuid25 value is not constructed properly. The zero value in a tagged field is
+// rejected as with json.Marshal() unless the field uses uuid25.ZeroAsNull or
+// uuid25.ZeroAsNil.
+func Marshal(v any) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := encodeValue(&buffer, reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Writes the JSON encoding of `v`, recursing into structs that do not marshal
+// themselves.
+func encodeValue(buffer *bytes.Buffer, v reflect.Value) error {
+	if v.IsValid() && !marshalsItself(v) {
+		switch v.Kind() {
+		case reflect.Interface:
+			if !v.IsNil() {
+				return encodeValue(buffer, v.Elem())
+			}
+		case reflect.Pointer:
+			if v.IsNil() {
+				buffer.WriteString("null")
+				return nil
+			} else if v.Elem().Kind() == reflect.Struct {
+				return encodeValue(buffer, v.Elem())
+			}
+		case reflect.Struct:
+			return encodeFields(buffer, v)
+		case reflect.Slice:
+			if v.IsNil() {
+				buffer.WriteString("null")
+				return nil
+			} else if v.Type().Elem().Kind() != reflect.Uint8 {
+				// byte slices are left to encoding/json, which emits Base64
+				return encodeElems(buffer, v)
+			}
+		case reflect.Array:
+			return encodeElems(buffer, v)
+		case reflect.Map:
+			if v.IsNil() {
+				buffer.WriteString("null")
+				return nil
+			}
+			return encodeMap(buffer, v)
+		}
+	}
+
+	var data []byte
+	var err error
+	if v.IsValid() && v.CanAddr() {
+		// let pointer receiver marshaler methods apply as in encoding/json
+		data, err = json.Marshal(v.Addr().Interface())
+	} else if v.IsValid() {
+		data, err = json.Marshal(v.Interface())
+	} else {
+		data, err = json.Marshal(nil)
+	}
+	if err != nil {
+		return err
+	}
+	buffer.Write(data)
+	return nil
+}
+
+// Writes the elements of the slice or array `v` as a JSON array.
+func encodeElems(buffer *bytes.Buffer, v reflect.Value) error {
+	buffer.WriteByte('[')
+	for i := 0; i < v.Len(); i += 1 {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		if err := encodeValue(buffer, v.Index(i)); err != nil {
+			return err
+		}
+	}
+	buffer.WriteByte(']')
+	return nil
+}
+
+// Writes the map `v` as a JSON object whose members are sorted by key like
+// encoding/json does.
+func encodeMap(buffer *bytes.Buffer, v reflect.Value) error {
+	type member struct {
+		key   string
+		value reflect.Value
+	}
+	members := make([]member, 0, v.Len())
+	for iter := v.MapRange(); iter.Next(); {
+		key, err := mapKey(iter.Key())
+		if err != nil {
+			return err
+		}
+		members = append(members, member{key, iter.Value()})
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].key < members[j].key })
+
+	buffer.WriteByte('{')
+	for i, e := range members {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		key, _ := json.Marshal(e.key)
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		if err := encodeValue(buffer, e.value); err != nil {
+			return err
+		}
+	}
+	buffer.WriteByte('}')
+	return nil
+}
+
+// Returns the JSON object key of a map key, following encoding/json.
+func mapKey(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+	if tm, ok := k.Interface().(encoding.TextMarshaler); ok {
+		if k.Kind() == reflect.Pointer && k.IsNil() {
+			return "", nil
+		}
+		text, err := tm.MarshalText()
+		return string(text), err
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	}
+	return "", errors.New("unsupported map key type " + k.Type().String())
+}
+
+// A JSON object member derived from a struct field, possibly promoted from an
+// embedded struct.
+type field struct {
+	name   string
+	tagged bool
+	opts   string
+	index  []int
+	sf     reflect.StructField
+}
+
+// The cache of the fields of struct types.
+var fieldCache sync.Map // map[reflect.Type][]field
+
+// Returns the fields of the struct type `t` that encoding/json would encode, in
+// the order of encoding.
+//
+// Fields of embedded structs are promoted, and among fields with the same name,
+// the one at the shallowest depth wins, preferring a field with a JSON tag
+// name. If the rule leaves more than one field, all of them are dropped, as
+// encoding/json does.
+func typeFields(t reflect.Type) []field {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]field)
+	}
+
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+	var fields []field
+	var current []embedded
+	next := []embedded{{typ: t}}
+	var count, nextCount map[reflect.Type]int
+	visited := map[reflect.Type]bool{}
+	for len(next) > 0 {
+		current, next = next, nil
+		count, nextCount = nextCount, map[reflect.Type]int{}
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+			for i := 0; i < e.typ.NumField(); i += 1 {
+				sf := e.typ.Field(i)
+				ft := sf.Type
+				if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if sf.Anonymous {
+					if !sf.IsExported() && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+				jsonTag := sf.Tag.Get("json")
+				if jsonTag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(jsonTag, ",")
+				index := append(append([]int(nil), e.index...), i)
+
+				if name == "" && sf.Anonymous && ft.Kind() == reflect.Struct {
+					nextCount[ft] += 1
+					if nextCount[ft] == 1 {
+						next = append(next, embedded{ft, index})
+					}
+					continue
+				}
+				f := field{name, name != "", opts, index, sf}
+				if !f.tagged {
+					f.name = sf.Name
+				}
+				fields = append(fields, f)
+				if count[e.typ] > 1 {
+					// the same struct embedded twice at one depth conflicts with
+					// itself, so add a duplicate to annihilate the field
+					fields = append(fields, f)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		a, b := fields[i], fields[j]
+		if a.name != b.name {
+			return a.name < b.name
+		} else if len(a.index) != len(b.index) {
+			return len(a.index) < len(b.index)
+		}
+		return a.tagged && !b.tagged
+	})
+	dominant := fields[:0]
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j += 1
+		}
+		if j-i == 1 || len(fields[i].index) != len(fields[i+1].index) ||
+			fields[i].tagged != fields[i+1].tagged {
+			dominant = append(dominant, fields[i])
+		}
+		i = j
+	}
+	sort.Slice(dominant, func(i, j int) bool {
+		a, b := dominant[i].index, dominant[j].index
+		for k := 0; k < len(a) && k < len(b); k += 1 {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+
+	cached, _ := fieldCache.LoadOrStore(t, dominant)
+	return cached.([]field)
+}
+
+// Returns the field of the struct `v` at `index`, or false if the path passes
+// through a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// Writes the struct `v` as a JSON object.
+func encodeFields(buffer *bytes.Buffer, v reflect.Value) error {
+	buffer.WriteByte('{')
+	n := 0
+	for _, f := range typeFields(v.Type()) {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok || hasOption(f.opts, "omitempty") && isEmpty(fv) {
+			continue
+		}
+
+		if n > 0 {
+			buffer.WriteByte(',')
+		}
+		n += 1
+		key, _ := json.Marshal(f.name)
+		buffer.Write(key)
+		buffer.WriteByte(':')
+
+		if tag, ok := f.sf.Tag.Lookup("uuid25"); ok {
+			if err := encodeTagged(buffer, fv, tag); err != nil {
+				return fmt.Errorf("field %s: %w", f.sf.Name, err)
+			}
+		} else if hasOption(f.opts, "string") {
+			data, err := json.Marshal(fv.Interface())
+			if err != nil {
+				return err
+			}
+			if k := fv.Kind(); k == reflect.String ||
+				(k >= reflect.Bool && k <= reflect.Float64) {
+				data, _ = json.Marshal(string(data))
+			}
+			buffer.Write(data)
+		} else if err := encodeValue(buffer, fv); err != nil {
+			return err
+		}
+	}
+	buffer.WriteByte('}')
+	return nil
+}
+
+// Writes a Uuid25, *Uuid25, or []Uuid25 value, or a value of the same shape
+// with uuid25.ZeroAsNull or uuid25.ZeroAsNil, in the format named `tag`.
+func encodeTagged(buffer *bytes.Buffer, v reflect.Value, tag string) error {
+	var format uuid25.Format
+	if err := format.UnmarshalText([]byte(tag)); err != nil || format == uuid25.FormatInvalid {
+		return fmt.Errorf("unknown format %q", tag)
+	}
+
+	switch {
+	case isUuid25Type(v.Type()):
+		return encodeUuid25(buffer, v, format)
+	case v.Kind() == reflect.Pointer && isUuid25Type(v.Type().Elem()):
+		if v.IsNil() {
+			buffer.WriteString("null")
+			return nil
+		}
+		return encodeUuid25(buffer, v.Elem(), format)
+	case v.Kind() == reflect.Slice && isUuid25Type(v.Type().Elem()):
+		if v.IsNil() {
+			buffer.WriteString("null")
+			return nil
+		}
+		buffer.WriteByte('[')
+		for i := 0; i < v.Len(); i += 1 {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			if err := encodeUuid25(buffer, v.Index(i), format); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+		return nil
+	default:
+		return errors.New("uuid25 tag on unsupported type " + v.Type().String())
+	}
+}
+
+// Reports whether `t` is Uuid25, uuid25.ZeroAsNull, or uuid25.ZeroAsNil.
+func isUuid25Type(t reflect.Type) bool {
+	return t == uuid25Type || t == zeroAsNullType || t == zeroAsNilType
+}
+
+// Writes a Uuid25 value as a JSON string in the format `format`, encoding the
+// zero value as the type of `v` specifies.
+func encodeUuid25(buffer *bytes.Buffer, v reflect.Value, format uuid25.Format) error {
+	x := uuid25.Uuid25(v.String())
+	if x == "" {
+		switch v.Type() {
+		case zeroAsNullType:
+			buffer.WriteString("null")
+			return nil
+		case zeroAsNilType:
+			x = uuid25.Nil
+		}
+	}
+	if _, err := x.MarshalText(); err != nil {
+		return err
+	}
+	var text [uuid25.MaxInputLen]byte
+	buffer.WriteByte('"')
+	buffer.Write(x.AppendFormat(text[:0], format))
+	buffer.WriteByte('"')
+	return nil
+}
+
+// Reports whether encoding/json would encode `v` through its own marshaler
+// method.
+func marshalsItself(v reflect.Value) bool {
+	t := v.Type()
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+		return true
+	}
+	pt := reflect.PointerTo(t)
+	return v.CanAddr() && (pt.Implements(jsonMarshalerType) || pt.Implements(textMarshalerType))
+}
+
+// Reports whether the comma-separated `opts` contains `name`.
+func hasOption(opts, name string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Reports whether `v` is empty in the sense of the `omitempty` option.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
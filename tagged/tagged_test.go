@@ -0,0 +1,197 @@
+package tagged
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+type Base struct {
+	CreatedBy uuid25.Uuid25 `json:"created_by" uuid25:"braced"`
+}
+
+type Item struct {
+	ID uuid25.Uuid25 `json:"id" uuid25:"hex"`
+}
+
+type Order struct {
+	Base
+	ID       uuid25.Uuid25   `json:"id"`
+	LegacyID uuid25.Uuid25   `json:"legacy_id" uuid25:"hyphenated"`
+	ItemIDs  []uuid25.Uuid25 `json:"item_ids" uuid25:"hex"`
+	ParentID *uuid25.Uuid25  `json:"parent_id,omitempty" uuid25:"urn"`
+	Items    []Item          `json:"items"`
+	Main     *Item           `json:"main"`
+	Extra    any             `json:"extra"`
+	Count    int             `json:"count,string"`
+	Note     string          `json:",omitempty"`
+	Skipped  string          `json:"-"`
+	private  string
+}
+
+// Tests per-field formatting.
+func TestMarshal(t *testing.T) {
+	x := uuid25.Uuid25("dpoadk8izg9y4tte7vy1xt94o")
+	hyphenated := "e7a1d63b-7117-4423-8988-afcf12161878"
+	hex := "e7a1d63b711744238988afcf12161878"
+	order := Order{
+		Base:     Base{CreatedBy: x},
+		ID:       x,
+		LegacyID: x,
+		ItemIDs:  []uuid25.Uuid25{x, x},
+		ParentID: &x,
+		Items:    []Item{{x}},
+		Extra:    Item{x},
+		Count:    3,
+		Skipped:  "skipped",
+		private:  "private",
+	}
+
+	data, err := Marshal(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"created_by":"{` + hyphenated + `}","id":"` + x.String() +
+		`","legacy_id":"` + hyphenated + `","item_ids":["` + hex + `","` + hex +
+		`"],"parent_id":"urn:uuid:` + hyphenated + `","items":[{"id":"` + hex +
+		`"}],"main":null,"extra":{"id":"` + hex + `"},"count":"3"}`
+	if string(data) != expected {
+		t.Errorf("unexpected JSON:\n%s\n%s", data, expected)
+	}
+
+	// the output must be decodable by encoding/json
+	var decoded Order
+	if err := json.Unmarshal(data, &decoded); err != nil ||
+		decoded.LegacyID != x || decoded.ParentID == nil || *decoded.ParentID != x ||
+		decoded.CreatedBy != x || decoded.ItemIDs[1] != x || decoded.Count != 3 {
+		t.Errorf("unexpected decoded value: %+v %v", decoded, err)
+	}
+
+	order.ParentID, order.Main, order.Note = nil, &Item{x}, "note"
+	data, _ = Marshal(&order)
+	if strings.Contains(string(data), "parent_id") ||
+		!strings.Contains(string(data), `"main":{"id":"`+hex+`"}`) ||
+		!strings.HasSuffix(string(data), `"Note":"note"}`) {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
+
+// Tests that tags apply to structs inside slices, arrays, and maps.
+func TestMarshalContainers(t *testing.T) {
+	x := uuid25.Uuid25("dpoadk8izg9y4tte7vy1xt94o")
+	hex := `{"id":"e7a1d63b711744238988afcf12161878"}`
+	v := struct {
+		Slice    []Item                 `json:"slice"`
+		Pointers []*Item                `json:"pointers"`
+		Nested   [][]Item               `json:"nested"`
+		Array    [1]Item                `json:"array"`
+		Map      map[string]Item        `json:"map"`
+		ByID     map[uuid25.Uuid25]Item `json:"by_id"`
+		ByInt    map[int]Item           `json:"by_int"`
+		Nil      []Item                 `json:"nil"`
+		NilMap   map[string]Item        `json:"nil_map"`
+		Bytes    []byte                 `json:"bytes"`
+	}{
+		Slice:    []Item{{x}},
+		Pointers: []*Item{{x}, nil},
+		Nested:   [][]Item{{{x}}},
+		Array:    [1]Item{{x}},
+		Map:      map[string]Item{"b": {x}, "a": {x}},
+		ByID:     map[uuid25.Uuid25]Item{x: {x}},
+		ByInt:    map[int]Item{10: {x}, 9: {x}},
+		Bytes:    []byte{1, 2},
+	}
+
+	data, err := Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"slice":[` + hex + `],"pointers":[` + hex + `,null],"nested":[[` + hex +
+		`]],"array":[` + hex + `],"map":{"a":` + hex + `,"b":` + hex + `},"by_id":{"` +
+		x.String() + `":` + hex + `},"by_int":{"10":` + hex + `,"9":` + hex +
+		`},"nil":null,"nil_map":null,"bytes":"AQI="}`
+	if string(data) != expected {
+		t.Errorf("unexpected JSON:\n%s\n%s", data, expected)
+	}
+}
+
+type conflictA struct {
+	X int
+	Y int `json:"Y"`
+	Z int
+}
+
+type conflictB struct {
+	X int
+	Y int
+	Z int
+}
+
+type conflictC struct {
+	Inner conflictA
+	*conflictB
+	W int
+}
+
+// Tests that fields promoted from embedded structs follow the name conflict
+// rules of encoding/json.
+func TestMarshalConflicts(t *testing.T) {
+	cases := []any{
+		struct {
+			conflictA
+			conflictB
+		}{conflictA{1, 2, 3}, conflictB{4, 5, 6}},
+		struct {
+			conflictA
+			conflictB
+			Z int
+		}{conflictA{1, 2, 3}, conflictB{4, 5, 6}, 7},
+		struct {
+			conflictC
+			conflictA
+			W int `json:"w"`
+		}{conflictC{conflictA{1, 2, 3}, &conflictB{4, 5, 6}, 7}, conflictA{8, 9, 10}, 11},
+		struct {
+			conflictC
+			W int `json:"w"`
+		}{conflictC{W: 7}, 11},
+	}
+	for _, e := range cases {
+		expected, _ := json.Marshal(e)
+		if data, err := Marshal(e); err != nil || string(data) != string(expected) {
+			t.Errorf("unexpected JSON:\n%s\n%s", data, expected)
+		}
+	}
+}
+
+// Tests errors and zero values.
+func TestMarshalErr(t *testing.T) {
+	if _, err := Marshal(struct {
+		ID uuid25.Uuid25 `uuid25:"foo"`
+	}{"dpoadk8izg9y4tte7vy1xt94o"}); err == nil {
+		t.Fail()
+	}
+	if _, err := Marshal(struct {
+		ID string `uuid25:"hex"`
+	}{"foo"}); err == nil {
+		t.Fail()
+	}
+
+	zero := struct {
+		ID uuid25.Uuid25 `json:"id" uuid25:"hyphenated"`
+	}{}
+	if _, err := Marshal(zero); err == nil {
+		t.Fail()
+	}
+	zeros := struct {
+		Null  uuid25.ZeroAsNull   `json:"null" uuid25:"hyphenated"`
+		Nil   *uuid25.ZeroAsNil   `json:"nil" uuid25:"hyphenated"`
+		Slice []uuid25.ZeroAsNull `json:"slice" uuid25:"hex"`
+	}{Nil: new(uuid25.ZeroAsNil), Slice: []uuid25.ZeroAsNull{"", "dpoadk8izg9y4tte7vy1xt94o"}}
+	if data, err := Marshal(zeros); err != nil || string(data) !=
+		`{"null":null,"nil":"00000000-0000-0000-0000-000000000000","slice":[null,"e7a1d63b711744238988afcf12161878"]}` {
+		t.Errorf("unexpected JSON: %s %v", data, err)
+	}
+}
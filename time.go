@@ -0,0 +1,181 @@
+package uuid25
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// An error returned when a UUID does not embed a timestamp.
+var ErrNotTimeBased = errors.New("not a time-based UUID")
+
+// The number of 100-nanosecond intervals between the Gregorian epoch
+// (1582-10-15) used by UUIDv1 and UUIDv6 and the Unix epoch.
+const gregorianToUnix = 122_192_928_000_000_000
+
+// Returns the creation time embedded in a time-based UUID.
+//
+// This method supports UUIDv1, UUIDv6, and UUIDv7 with the RFC 9562 variant,
+// and returns ErrNotTimeBased for other UUIDs. The result has the precision of
+// the respective version: 100 nanoseconds for UUIDv1 and UUIDv6 and 1
+// millisecond for UUIDv7.
+func (uuid25 Uuid25) Time() (time.Time, error) {
+	b := uuid25.ToBytes()
+	if b[8]>>6 != 0b10 {
+		return time.Time{}, ErrNotTimeBased
+	}
+	switch b[6] >> 4 {
+	case 1:
+		ts := uint64(b[6]&0x0f)<<56 | uint64(b[7])<<48 |
+			uint64(b[4])<<40 | uint64(b[5])<<32 |
+			uint64(b[0])<<24 | uint64(b[1])<<16 | uint64(b[2])<<8 | uint64(b[3])
+		return fromGregorianTimestamp(ts), nil
+	case 6:
+		ts := uint64(b[0])<<52 | uint64(b[1])<<44 | uint64(b[2])<<36 |
+			uint64(b[3])<<28 | uint64(b[4])<<20 | uint64(b[5])<<12 |
+			uint64(b[6]&0x0f)<<8 | uint64(b[7])
+		return fromGregorianTimestamp(ts), nil
+	case 7:
+		ms := uint64(b[0])<<40 | uint64(b[1])<<32 | uint64(b[2])<<24 |
+			uint64(b[3])<<16 | uint64(b[4])<<8 | uint64(b[5])
+		return time.UnixMilli(int64(ms)), nil
+	default:
+		return time.Time{}, ErrNotTimeBased
+	}
+}
+
+// Returns nil if the UUID is a time-based UUIDv1, UUIDv6, or UUIDv7 with the
+// RFC 9562 variant, or an error wrapping ErrNotTimeBased otherwise.
+//
+// This method is intended for APIs that accept only time-ordered IDs, such as
+// pagination cursors, to reject random UUIDv4 values early. The error message
+// names the actual version or variant of the UUID so that it can be returned to
+// clients as it is.
+func (uuid25 Uuid25) RequireTimeOrdered() error {
+	if v := uuid25.Variant(); v != VariantRFC9562 {
+		return fmt.Errorf("%w: %s has the %v variant; expected an RFC 9562 UUIDv1, UUIDv6, or UUIDv7",
+			ErrNotTimeBased, uuid25.ToHyphenated(), v)
+	}
+	switch v := uuid25.Version(); v {
+	case 1, 6, 7:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s is a UUIDv%d; expected a UUIDv1, UUIDv6, or UUIDv7",
+			ErrNotTimeBased, uuid25.ToHyphenated(), v)
+	}
+}
+
+// Converts a 60-bit count of 100-nanosecond intervals since the Gregorian epoch
+// into a time.Time value.
+func fromGregorianTimestamp(ts uint64) time.Time {
+	unix := int64(ts) - gregorianToUnix
+	return time.Unix(unix/10_000_000, unix%10_000_000*100)
+}
+
+// Returns the time elapsed from the creation of a time-based UUID until `now`.
+//
+// This method returns ErrNotTimeBased if the UUID does not embed a timestamp
+// (see Time()). The result is negative if the UUID was created after `now`.
+func (uuid25 Uuid25) Age(now time.Time) (time.Duration, error) {
+	t, err := uuid25.Time()
+	if err != nil {
+		return 0, err
+	}
+	return now.Sub(t), nil
+}
+
+// Reports whether the receiver was created before `other` according to the
+// embedded timestamps.
+//
+// This method compares UUIDv1, UUIDv6, and UUIDv7 with each other, so datasets
+// mixing versions during a migration can be ordered by creation time. When
+// either UUID is a UUIDv7, both timestamps are truncated to milliseconds
+// before comparison, and UUIDs created within the same millisecond are not
+// considered to be created before each other. This method returns
+// ErrNotTimeBased if either UUID does not embed a timestamp.
+func (uuid25 Uuid25) CreatedBefore(other Uuid25) (bool, error) {
+	a, err := uuid25.Time()
+	if err != nil {
+		return false, err
+	}
+	b, err := other.Time()
+	if err != nil {
+		return false, err
+	}
+	if uuid25.Version() == 7 || other.Version() == 7 {
+		a, b = a.Truncate(time.Millisecond), b.Truncate(time.Millisecond)
+	}
+	return a.Before(b), nil
+}
+
+// Returns the time-based UUIDs in `uuids` created before `cutoff`, preserving
+// the order.
+//
+// UUIDs that do not embed a timestamp are never included. This function is
+// intended for retention sweeps over time-ordered IDs such as UUIDv7.
+func ExpiredBefore(uuids []Uuid25, cutoff time.Time) []Uuid25 {
+	var expired []Uuid25
+	for _, e := range uuids {
+		if t, err := e.Time(); err == nil && t.Before(cutoff) {
+			expired = append(expired, e)
+		}
+	}
+	return expired
+}
+
+// A histogram that counts time-based UUIDs by the embedded timestamp.
+//
+// Add UUIDs one by one from a stream or a slice and read the counts through
+// Buckets(). UUIDs that do not embed a timestamp are counted in Skipped.
+type TimeHistogram struct {
+	bucketSize time.Duration
+	counts     map[time.Time]int
+
+	// The number of UUIDs skipped because they do not embed a timestamp.
+	Skipped int
+}
+
+// A time range of a TimeHistogram and the number of UUIDs created within it.
+type TimeBucket struct {
+	Start time.Time
+	Count int
+}
+
+// Creates a histogram whose buckets span `bucketSize`, e.g., time.Minute,
+// time.Hour, or 24 * time.Hour.
+//
+// Buckets are aligned to the multiples of `bucketSize` since the zero time in
+// UTC, so day buckets start at midnight UTC. This function panics if
+// `bucketSize` is not positive.
+func NewTimeHistogram(bucketSize time.Duration) *TimeHistogram {
+	if bucketSize <= 0 {
+		panic("bucket size must be positive")
+	}
+	return &TimeHistogram{bucketSize: bucketSize, counts: make(map[time.Time]int)}
+}
+
+// Adds a UUID to the histogram and reports whether it embeds a timestamp.
+func (h *TimeHistogram) Add(uuid25 Uuid25) bool {
+	t, err := uuid25.Time()
+	if err != nil {
+		h.Skipped += 1
+		return false
+	}
+	// key by time.Time because UnixNano() overflows before 1678, within the
+	// range of UUIDv1 and UUIDv6
+	h.counts[t.Truncate(h.bucketSize).UTC()] += 1
+	return true
+}
+
+// Returns the non-empty buckets in chronological order.
+func (h *TimeHistogram) Buckets() []TimeBucket {
+	buckets := make([]TimeBucket, 0, len(h.counts))
+	for k, v := range h.counts {
+		buckets = append(buckets, TimeBucket{k, v})
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Start.Before(buckets[j].Start)
+	})
+	return buckets
+}
@@ -0,0 +1,181 @@
+package uuid25
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// Tests timestamp extraction using the test vectors in RFC 9562.
+func TestTime(t *testing.T) {
+	expected := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	cases := []string{
+		"c232ab00-9414-11ec-b3c8-9f6bdeced846", // v1
+		"1ec9414c-232a-6b00-b3c8-9f6bdeced846", // v6
+		"017f22e2-79b0-7cc3-98c4-dc0c0c07398f", // v7
+	}
+	for _, e := range cases {
+		x, _ := Parse(e)
+		if y, err := x.Time(); !y.Equal(expected) || err != nil {
+			t.Errorf("unexpected time of %s: %v", e, y)
+		}
+		if y, err := x.Age(expected.Add(time.Hour)); y != time.Hour || err != nil {
+			t.Errorf("unexpected age of %s: %v", e, y)
+		}
+	}
+
+	for _, e := range []string{
+		"919108f7-52d1-4320-9bac-f847db4148a8", // v4
+		"017f22e2-79b0-7cc3-18c4-dc0c0c07398f", // v7 with wrong variant
+		"00000000-0000-0000-0000-000000000000",
+	} {
+		x, _ := Parse(e)
+		if _, err := x.Time(); err != ErrNotTimeBased {
+			t.Errorf("%s must not be time-based", e)
+		}
+		if _, err := x.Age(expected); err != ErrNotTimeBased {
+			t.Errorf("%s must not be time-based", e)
+		}
+	}
+}
+
+// Tests that RequireTimeOrdered() accepts only UUIDv1, UUIDv6, and UUIDv7.
+func TestRequireTimeOrdered(t *testing.T) {
+	cases := []struct {
+		s   string
+		msg string
+	}{
+		{"c232ab00-9414-11ec-b3c8-9f6bdeced846", ""},
+		{"1ec9414c-232a-6b00-b3c8-9f6bdeced846", ""},
+		{"017f22e2-79b0-7cc3-98c4-dc0c0c07398f", ""},
+		{"e7a1d63b-7117-4423-8988-afcf12161878",
+			"not a time-based UUID: e7a1d63b-7117-4423-8988-afcf12161878 is a UUIDv4; expected a UUIDv1, UUIDv6, or UUIDv7"},
+		{"00000000-0000-0000-0000-000000000000",
+			"not a time-based UUID: 00000000-0000-0000-0000-000000000000 has the NCS variant; expected an RFC 9562 UUIDv1, UUIDv6, or UUIDv7"},
+		{"017f22e2-79b0-7cc3-d8c4-dc0c0c07398f",
+			"not a time-based UUID: 017f22e2-79b0-7cc3-d8c4-dc0c0c07398f has the Microsoft variant; expected an RFC 9562 UUIDv1, UUIDv6, or UUIDv7"},
+	}
+	for _, e := range cases {
+		x, _ := Parse(e.s)
+		err := x.RequireTimeOrdered()
+		if e.msg == "" && err != nil {
+			t.Errorf("unexpected error for %s: %v", e.s, err)
+		} else if e.msg != "" && (!errors.Is(err, ErrNotTimeBased) || err.Error() != e.msg) {
+			t.Errorf("unexpected error for %s: %v", e.s, err)
+		}
+	}
+}
+
+// Tests CreatedBefore() across UUIDv1, UUIDv6, and UUIDv7.
+func TestCreatedBefore(t *testing.T) {
+	parse := func(s string) Uuid25 {
+		x, _ := Parse(s)
+		return x
+	}
+	v1 := parse("c232ab00-9414-11ec-b3c8-9f6bdeced846")   // 2022-02-22T19:22:22Z
+	v1ns := parse("c232ab01-9414-11ec-b3c8-9f6bdeced846") // 100 ns later
+	v6 := parse("1ec9414c-232a-6b00-b3c8-9f6bdeced846")   // 2022-02-22T19:22:22Z
+	v7 := parse("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")   // 2022-02-22T19:22:22Z
+	v7ms := parse("017f22e2-79b1-7cc3-98c4-dc0c0c07398f") // 1 ms later
+	v4 := parse("e7a1d63b-7117-4423-8988-afcf12161878")
+
+	cases := []struct {
+		a, b     Uuid25
+		expected bool
+	}{
+		{v1, v1ns, true},
+		{v1ns, v1, false},
+		{v1, v6, false},
+		{v6, v1ns, true},
+		{v1ns, v7, false},
+		{v7, v1ns, false},
+		{v1ns, v7ms, true},
+		{v7ms, v6, false},
+		{v7, v7ms, true},
+		{v7, v7, false},
+	}
+	for i, e := range cases {
+		if got, err := e.a.CreatedBefore(e.b); err != nil || got != e.expected {
+			t.Errorf("unexpected result of case %d: %v %v", i, got, err)
+		}
+	}
+
+	if _, err := v4.CreatedBefore(v7); err != ErrNotTimeBased {
+		t.Fail()
+	}
+	if _, err := v7.CreatedBefore(v4); err != ErrNotTimeBased {
+		t.Fail()
+	}
+}
+
+// Tests filtering expired UUIDs.
+func TestExpiredBefore(t *testing.T) {
+	var uuids []Uuid25
+	for _, e := range []string{
+		"017f22e2-79b0-7cc3-98c4-dc0c0c07398f", // 2022-02-22T19:22:22Z
+		"919108f7-52d1-4320-9bac-f847db4148a8", // v4
+		"01867b2c-a0dd-7000-8000-000000000000", // 2023-02-17
+		"017f22e2-79af-7cc3-98c4-dc0c0c07398f", // 1 ms earlier than the first
+	} {
+		x, _ := Parse(e)
+		uuids = append(uuids, x)
+	}
+
+	cutoff := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	if x := ExpiredBefore(uuids, cutoff); len(x) != 1 || x[0] != uuids[3] {
+		t.Fail()
+	}
+	if x := ExpiredBefore(uuids, cutoff.Add(time.Millisecond)); len(x) != 2 || x[0] != uuids[0] || x[1] != uuids[3] {
+		t.Fail()
+	}
+	if x := ExpiredBefore(uuids, cutoff.AddDate(10, 0, 0)); len(x) != 3 {
+		t.Fail()
+	}
+}
+
+// Tests counting UUIDs by the embedded timestamp.
+func TestTimeHistogram(t *testing.T) {
+	h := NewTimeHistogram(time.Hour)
+	for _, e := range []string{
+		"017f22e2-79b0-7cc3-98c4-dc0c0c07398f", // 2022-02-22T19:22:22Z
+		"1ec9414c-232a-6b00-b3c8-9f6bdeced846", // 2022-02-22T19:22:22Z
+		"919108f7-52d1-4320-9bac-f847db4148a8", // v4
+		"017f2336-e630-7000-8000-000000000000", // 2022-02-22T20:54:35.056Z
+		"017f21d0-7a00-7000-8000-000000000000", // 2022-02-22T14:21:37.024Z
+	} {
+		x, _ := Parse(e)
+		h.Add(x)
+	}
+
+	buckets := h.Buckets()
+	expected := []TimeBucket{
+		{time.Date(2022, 2, 22, 14, 0, 0, 0, time.UTC), 1},
+		{time.Date(2022, 2, 22, 19, 0, 0, 0, time.UTC), 2},
+		{time.Date(2022, 2, 22, 20, 0, 0, 0, time.UTC), 1},
+	}
+	if len(buckets) != len(expected) || h.Skipped != 1 {
+		t.Fatalf("unexpected buckets: %v", buckets)
+	}
+	for i, e := range expected {
+		if !buckets[i].Start.Equal(e.Start) || buckets[i].Count != e.Count {
+			t.Errorf("unexpected bucket: %v", buckets[i])
+		}
+	}
+
+	// timestamps near the Gregorian epoch are out of the range of UnixNano()
+	h = NewTimeHistogram(24 * time.Hour)
+	for _, e := range []string{
+		"00000000-0000-1000-8000-000000000000", // 1582-10-15T00:00:00Z
+		"00000001-0000-1000-8000-000000000000", // 1582-10-15T00:00:00.0000001Z
+		"00000000-0000-1001-8000-000000000000", // 1583-09-05T18:44:57.6710656Z
+	} {
+		x, _ := Parse(e)
+		h.Add(x)
+	}
+	buckets = h.Buckets()
+	if len(buckets) != 2 ||
+		!buckets[0].Start.Equal(time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC)) || buckets[0].Count != 2 ||
+		!buckets[1].Start.Equal(time.Date(1583, 9, 5, 0, 0, 0, 0, time.UTC)) || buckets[1].Count != 1 {
+		t.Errorf("unexpected buckets: %v", buckets)
+	}
+}
@@ -0,0 +1,61 @@
+package token
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+var key = []byte("0123456789abcdef0123456789abcdef")
+
+const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+// Tests signing and verifying tokens.
+func TestSignVerify(t *testing.T) {
+	signer := NewSigner(key, "password-reset")
+	id, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	now := time.Unix(1700000000, 0)
+	expiry := now.Add(time.Hour)
+
+	token := signer.Sign(id, expiry)
+	if len(token) != 55 || strings.ContainsAny(token, "+/=") {
+		t.Errorf("unexpected token: %q", token)
+	}
+	if x, y, err := signer.Verify(token, now); x != id || !y.Equal(expiry) || err != nil {
+		t.Fail()
+	}
+	if _, _, err := signer.Verify(token, expiry); err != ErrExpired {
+		t.Fail()
+	}
+	if _, _, err := NewSigner(key, "invitation").Verify(token, now); err != ErrInvalidSignature {
+		t.Fail()
+	}
+	if _, _, err := NewSigner([]byte("fedcba9876543210"), "password-reset").Verify(token, now); err != ErrInvalidSignature {
+		t.Fail()
+	}
+
+	for i := 1; i < 41; i += 1 {
+		tampered, _ := base64.RawURLEncoding.DecodeString(token)
+		tampered[i] ^= 1
+		if _, _, err := signer.Verify(base64.RawURLEncoding.EncodeToString(tampered), now); err != ErrInvalidSignature {
+			t.Errorf("tampering byte %d must be detected", i)
+		}
+	}
+	// the last character carries two unused bits, which must be zero
+	last := strings.IndexByte(alphabet, token[len(token)-1])
+	for _, e := range []int{1, 2, 3} {
+		changed := token[:len(token)-1] + string(alphabet[last^e])
+		if _, _, err := signer.Verify(changed, now); err != ErrMalformed {
+			t.Errorf("%q must be malformed", changed)
+		}
+	}
+
+	for _, e := range []string{"", token[1:], token + "A", "!" + token[1:]} {
+		if _, _, err := signer.Verify(e, now); err != ErrMalformed {
+			t.Errorf("%q must be malformed", e)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package uuid25
+
+import "errors"
+
+// An error returned by Original() when a value is not a tombstone marker.
+var ErrNotTombstone = errors.New("not a tombstone marker")
+
+// The bit flipped by Tombstone(), which turns the RFC 9562 variant `0b10x`
+// into the reserved variants `0b11x`.
+const tombstoneBit = 1 << 62
+
+// Returns the tombstone marker of a UUID, a reversible transformation that
+// marks an ID as deleted while retaining the linkage to the original ID.
+//
+// The marker is the original UUID with the most significant variant bits
+// changed from the RFC 9562 variant `0b10` to the reserved `0b11`, so every
+// other bit, including the version field, is preserved and Original() restores
+// the original value. Since the marker is no longer an RFC 9562 UUID, it never
+// collides with a live ID and is rejected by ParseVersion() and ParseV7().
+//
+// This method returns ErrWrongVersion unless the receiver is an RFC 9562 UUID
+// of versions 1 through 8, and it panics if the receiver is not constructed
+// properly.
+func (uuid25 Uuid25) Tombstone() (Uuid25, error) {
+	x := uuid25.toUint128()
+	if v := x.Hi >> 12 & 0xf; v < 1 || v > 8 || x.Lo>>62 != 0b10 {
+		return "", ErrWrongVersion
+	}
+	x.Lo |= tombstoneBit
+	return FromUint128(x), nil
+}
+
+// Reports whether the receiver is a tombstone marker created by Tombstone().
+//
+// A marker has the reserved variant bits `0b11` and a version field between 1
+// and 8. Note that legacy Microsoft GUIDs may share this bit pattern.
+//
+// This method panics if the receiver is not constructed properly.
+func (uuid25 Uuid25) IsTombstone() bool {
+	x := uuid25.toUint128()
+	v := x.Hi >> 12 & 0xf
+	return v >= 1 && v <= 8 && x.Lo>>62 == 0b11
+}
+
+// Returns the original UUID of a tombstone marker created by Tombstone().
+//
+// This method returns ErrNotTombstone if IsTombstone() is false, and it panics
+// if the receiver is not constructed properly.
+func (uuid25 Uuid25) Original() (Uuid25, error) {
+	if !uuid25.IsTombstone() {
+		return "", ErrNotTombstone
+	}
+	x := uuid25.toUint128()
+	x.Lo &^= tombstoneBit
+	return FromUint128(x), nil
+}
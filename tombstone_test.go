@@ -0,0 +1,48 @@
+package uuid25
+
+import "testing"
+
+// Tests that Original() reverses Tombstone().
+func TestTombstone(t *testing.T) {
+	ids := []Uuid25{NewV4(), NewV7(), Uuid25("dpoadk8izg9y4tte7vy1xt94o")}
+	ids = append(ids, newV5(Uuid25("dpoadk8izg9y4tte7vy1xt94o"), []byte("name")))
+	for _, id := range ids {
+		marker, err := id.Tombstone()
+		if err != nil || marker == id || !marker.IsTombstone() || id.IsTombstone() {
+			t.Errorf("unexpected marker of %s: %s %v", id, marker, err)
+		}
+		if marker.Version() != id.Version() || marker.Variant() == VariantRFC9562 {
+			t.Fail()
+		}
+		if _, err := ParseVersion(marker.ToHyphenated(), id.Version()); err != ErrWrongVersion {
+			t.Fail()
+		}
+		if orig, err := marker.Original(); err != nil || orig != id {
+			t.Fail()
+		}
+		if _, err := marker.Tombstone(); err != ErrWrongVersion {
+			t.Fail()
+		}
+	}
+
+	id, _ := Parse("01809424-3e59-7c05-9219-566f82fff672")
+	if marker, _ := id.Tombstone(); marker.ToHyphenated() != "01809424-3e59-7c05-d219-566f82fff672" {
+		t.Fail()
+	}
+
+	for _, s := range []string{
+		"00000000-0000-0000-0000-000000000000",
+		"ffffffff-ffff-ffff-ffff-ffffffffffff",
+		"01809424-3e59-0c05-9219-566f82fff672",
+		"01809424-3e59-9c05-9219-566f82fff672",
+		"01809424-3e59-7c05-1219-566f82fff672",
+	} {
+		id, _ := Parse(s)
+		if _, err := id.Tombstone(); err != ErrWrongVersion {
+			t.Errorf("unexpected marker of %s", s)
+		}
+		if _, err := id.Original(); err != ErrNotTombstone {
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package typed
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// An interface implemented by marker types that define entity kinds.
+//
+// A marker type is typically an empty struct:
+//
+//	type User struct{}
+//
+//	func (User) Prefix() string { return "user" }
+//
+//	type UserID = typed.ID[User]
+type Kind interface {
+	// Returns the prefix of the IDs of this kind, which must satisfy
+	// ValidPrefix().
+	Prefix() string
+}
+
+// A Uuid25 value typed with an entity kind.
+//
+// This type is represented externally as a prefixed string such as
+// `user_3ud3gtvgolimgu9lah6aie99o` through String(), MarshalText(), and
+// MarshalJSON(), whereas Value() stores only the bare 25-digit Uuid25 string in
+// a database so that adopting typed IDs does not change the persisted data.
+//
+// A valid value of this type must be constructed through New() or Parse().
+type ID[K Kind] uuid25.Uuid25
+
+// Creates a typed ID from a Uuid25 value.
+func New[K Kind](uuid25 uuid25.Uuid25) ID[K] {
+	return ID[K](uuid25)
+}
+
+// Creates a typed ID from the prefixed string representation.
+//
+// This function returns ErrInvalidTypedID if the string is malformed or has a
+// prefix other than that of `K`.
+func Parse[K Kind](s string) (ID[K], error) {
+	var k K
+	prefix, uuid25, err := Split(s)
+	if err != nil {
+		return "", err
+	}
+	if prefix != k.Prefix() {
+		return "", ErrInvalidTypedID
+	}
+	return ID[K](uuid25), nil
+}
+
+// Returns the prefix of the kind of this ID.
+func (id ID[K]) Prefix() string {
+	var k K
+	return k.Prefix()
+}
+
+// Returns the untyped Uuid25 value.
+func (id ID[K]) Uuid25() uuid25.Uuid25 {
+	return uuid25.Uuid25(id)
+}
+
+// Returns the prefixed string representation.
+func (id ID[K]) String() string {
+	return Format(id.Prefix(), id.Uuid25())
+}
+
+// Implements the encoding.TextUnmarshaler interface.
+func (id *ID[K]) UnmarshalText(text []byte) error {
+	if id == nil {
+		return errors.New("nil receiver")
+	}
+	result, err := Parse[K](string(text))
+	*id = result
+	return err
+}
+
+// Implements the encoding.TextMarshaler interface.
+//
+// Unlike String(), this method returns an error instead of panicking if the
+// receiver is not constructed properly.
+func (id ID[K]) MarshalText() (text []byte, err error) {
+	if _, err := id.Uuid25().MarshalText(); err != nil {
+		return nil, err
+	}
+	return []byte(id.String()), nil
+}
+
+// Implements the json.Unmarshaler interface.
+//
+// A JSON null leaves the receiver unchanged.
+func (id *ID[K]) UnmarshalJSON(data []byte) error {
+	if id == nil {
+		return errors.New("nil receiver")
+	} else if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return id.UnmarshalText([]byte(s))
+}
+
+// Implements the json.Marshaler interface.
+func (id ID[K]) MarshalJSON() ([]byte, error) {
+	text, err := id.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// Implements the sql.Scanner interface.
+//
+// This method accepts any format accepted by Uuid25.Scan(), as well as the
+// prefixed string representation of this kind in a string or []byte. Sources
+// longer than any prefixed ID are rejected before they are copied.
+func (id *ID[K]) Scan(src any) error {
+	if id == nil {
+		return errors.New("nil receiver")
+	}
+	var uuid25 uuid25.Uuid25
+	if err := uuid25.Scan(src); err != nil {
+		// the prefixed form may exceed MaxInputLen but is still bounded
+		var s string
+		switch src := src.(type) {
+		case string:
+			s = src
+		case []byte:
+			if len(src) <= maxTypedLen {
+				s = string(src)
+			}
+		default:
+			return err
+		}
+		if len(s) == 0 || len(s) > maxTypedLen {
+			return err
+		}
+		if result, err := Parse[K](s); err == nil {
+			*id = result
+			return nil
+		}
+		return err
+	}
+	*id = ID[K](uuid25)
+	return nil
+}
+
+// Implements the driver.Valuer interface, storing the bare Uuid25 string.
+func (id ID[K]) Value() (driver.Value, error) {
+	return id.Uuid25().Value()
+}
@@ -0,0 +1,155 @@
+// Prefixed, typed Uuid25 identifiers
+//
+// This package provides the prefixed ID representation that tags a Uuid25 value
+// with the kind of entity it identifies, such as
+// `user_3ud3gtvgolimgu9lah6aie99o`, the generic ID type that carries the kind in
+// the Go type system, and a registry that maps prefixes to entity kinds so that
+// generic handlers can accept any registered entity ID.
+package typed
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// The character separating a prefix from the Uuid25 part.
+const Separator = '_'
+
+// The maximum length of a prefix.
+const MaxPrefixLen = 63
+
+// The maximum length of a prefixed ID: a prefix, the separator, and 25 digits.
+const maxTypedLen = MaxPrefixLen + 26
+
+// An error returned when a string is not a valid prefixed ID.
+var ErrInvalidTypedID = errors.New("invalid typed ID")
+
+// An error returned when a prefix is not registered.
+var ErrUnknownPrefix = errors.New("unknown typed ID prefix")
+
+// Formats a Uuid25 value with `prefix`: `user_3ud3gtvgolimgu9lah6aie99o`.
+//
+// This function panics if the prefix is invalid.
+func Format(prefix string, id uuid25.Uuid25) string {
+	if !ValidPrefix(prefix) {
+		panic("invalid prefix")
+	}
+	return prefix + string(Separator) + id.String()
+}
+
+// Splits a prefixed ID into the prefix and the Uuid25 value.
+//
+// The Uuid25 part is parsed case-insensitively, while the prefix must consist
+// of lowercase letters, digits, and underscores as checked by ValidPrefix().
+func Split(s string) (prefix string, id uuid25.Uuid25, err error) {
+	n := len(s) - 26
+	if n < 1 || s[n] != Separator || !ValidPrefix(s[:n]) {
+		return "", "", ErrInvalidTypedID
+	}
+	id, err = uuid25.ParseUuid25(s[n+1:])
+	if err != nil {
+		return "", "", ErrInvalidTypedID
+	}
+	return s[:n], id, nil
+}
+
+// Reports whether a string is usable as a prefix.
+//
+// A valid prefix is 1 to 63 characters long, starts with a lowercase letter,
+// does not end with an underscore, and consists of lowercase letters, digits,
+// and underscores.
+func ValidPrefix(prefix string) bool {
+	if len(prefix) == 0 || len(prefix) > MaxPrefixLen || prefix[len(prefix)-1] == '_' {
+		return false
+	}
+	for i := 0; i < len(prefix); i += 1 {
+		c := prefix[i]
+		if !('a' <= c && c <= 'z' || i > 0 && ('0' <= c && c <= '9' || c == '_')) {
+			return false
+		}
+	}
+	return true
+}
+
+// A concurrency-safe mapping from prefixes to entity kinds.
+//
+// The zero value is an empty registry ready to use.
+type Registry struct {
+	mu    sync.RWMutex
+	kinds map[string]string
+}
+
+// Registers a prefix for an entity kind.
+//
+// This method returns an error if the prefix is invalid or already registered
+// for a different kind. Registering the same pair twice is a no-op.
+func (registry *Registry) Register(prefix string, kind string) error {
+	if !ValidPrefix(prefix) {
+		return fmt.Errorf("invalid prefix %q", prefix)
+	}
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if existing, ok := registry.kinds[prefix]; ok {
+		if existing == kind {
+			return nil
+		}
+		return fmt.Errorf("prefix %q already registered for kind %q", prefix, existing)
+	}
+	if registry.kinds == nil {
+		registry.kinds = make(map[string]string)
+	}
+	registry.kinds[prefix] = kind
+	return nil
+}
+
+// Returns the entity kind registered for a prefix.
+func (registry *Registry) Lookup(prefix string) (kind string, ok bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	kind, ok = registry.kinds[prefix]
+	return
+}
+
+// Returns a copy of the prefix-to-kind mapping.
+func (registry *Registry) Kinds() map[string]string {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	kinds := make(map[string]string, len(registry.kinds))
+	for k, v := range registry.kinds {
+		kinds[k] = v
+	}
+	return kinds
+}
+
+// Parses a prefixed ID of any registered kind and returns the kind and the
+// Uuid25 value.
+//
+// This method returns ErrInvalidTypedID if the string is malformed and
+// ErrUnknownPrefix if the prefix is not registered.
+func (registry *Registry) ParseAnyTyped(s string) (kind string, id uuid25.Uuid25, err error) {
+	prefix, id, err := Split(s)
+	if err != nil {
+		return "", "", err
+	}
+	kind, ok := registry.Lookup(prefix)
+	if !ok {
+		return "", "", ErrUnknownPrefix
+	}
+	return kind, id, nil
+}
+
+// The process-wide registry used by the package-level functions.
+var DefaultRegistry = &Registry{}
+
+// Registers a prefix for an entity kind in DefaultRegistry.
+func Register(prefix string, kind string) error {
+	return DefaultRegistry.Register(prefix, kind)
+}
+
+// Parses a prefixed ID of any kind registered in DefaultRegistry.
+func ParseAnyTyped(s string) (kind string, id uuid25.Uuid25, err error) {
+	return DefaultRegistry.ParseAnyTyped(s)
+}
@@ -0,0 +1,161 @@
+package typed
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests formatting and splitting prefixed IDs.
+func TestFormatSplit(t *testing.T) {
+	id, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	s := Format("user", id)
+	if s != "user_dpoadk8izg9y4tte7vy1xt94o" {
+		t.Fail()
+	}
+	if prefix, x, err := Split(s); prefix != "user" || x != id || err != nil {
+		t.Fail()
+	}
+	if prefix, x, err := Split("api_key_DPOADK8IZG9Y4TTE7VY1XT94O"); prefix != "api_key" || x != id || err != nil {
+		t.Fail()
+	}
+
+	cases := []string{
+		"",
+		"dpoadk8izg9y4tte7vy1xt94o",
+		"_dpoadk8izg9y4tte7vy1xt94o",
+		"User_dpoadk8izg9y4tte7vy1xt94o",
+		"1user_dpoadk8izg9y4tte7vy1xt94o",
+		"user__dpoadk8izg9y4tte7vy1xt94o",
+		"user-dpoadk8izg9y4tte7vy1xt94o",
+		"user_dpoadk8izg9y4tte7vy1xt94",
+		"user_zzzzzzzzzzzzzzzzzzzzzzzzz",
+	}
+	for _, e := range cases {
+		if _, _, err := Split(e); err != ErrInvalidTypedID {
+			t.Errorf("%q must be rejected", e)
+		}
+	}
+}
+
+// Tests registering prefixes and parsing IDs of any registered kind.
+func TestRegistry(t *testing.T) {
+	var registry Registry
+	if registry.Register("user", "User") != nil ||
+		registry.Register("org", "Organization") != nil ||
+		registry.Register("user", "User") != nil {
+		t.Fail()
+	}
+	if registry.Register("user", "Account") == nil || registry.Register("User", "User") == nil {
+		t.Fail()
+	}
+	if len(registry.Kinds()) != 2 {
+		t.Fail()
+	}
+
+	id, _ := uuid25.Parse("dpoadk8izg9y4tte7vy1xt94o")
+	if kind, x, err := registry.ParseAnyTyped("org_dpoadk8izg9y4tte7vy1xt94o"); kind != "Organization" || x != id || err != nil {
+		t.Fail()
+	}
+	if _, _, err := registry.ParseAnyTyped("team_dpoadk8izg9y4tte7vy1xt94o"); err != ErrUnknownPrefix {
+		t.Fail()
+	}
+	if _, _, err := registry.ParseAnyTyped("org_"); err != ErrInvalidTypedID {
+		t.Fail()
+	}
+}
+
+type user struct{}
+
+func (user) Prefix() string { return "user" }
+
+type org struct{}
+
+func (org) Prefix() string { return "org" }
+
+// Tests the marshaling and SQL interfaces of typed IDs.
+func TestID(t *testing.T) {
+	x := New[user](uuid25.FromBytes([]byte{231, 161, 214, 59, 113, 23, 68, 35, 137, 136, 175, 207, 18, 22, 24, 120}))
+	if x.String() != "user_dpoadk8izg9y4tte7vy1xt94o" || x.Uuid25() != "dpoadk8izg9y4tte7vy1xt94o" {
+		t.Fail()
+	}
+	if y, err := Parse[user]("user_dpoadk8izg9y4tte7vy1xt94o"); y != x || err != nil {
+		t.Fail()
+	}
+	if _, err := Parse[org]("user_dpoadk8izg9y4tte7vy1xt94o"); err != ErrInvalidTypedID {
+		t.Fail()
+	}
+
+	type record struct {
+		ID    ID[user]  `json:"id"`
+		Owner *ID[user] `json:"owner"`
+	}
+	data, err := json.Marshal(record{ID: x})
+	if string(data) != `{"id":"user_dpoadk8izg9y4tte7vy1xt94o","owner":null}` || err != nil {
+		t.Fail()
+	}
+	var r record
+	if json.Unmarshal(data, &r) != nil || r.ID != x || r.Owner != nil {
+		t.Fail()
+	}
+	if json.Unmarshal([]byte(`{"id":"org_dpoadk8izg9y4tte7vy1xt94o"}`), &r) == nil {
+		t.Fail()
+	}
+
+	if v, err := x.Value(); v != "dpoadk8izg9y4tte7vy1xt94o" || err != nil {
+		t.Fail()
+	}
+	var scanned ID[user]
+	if scanned.Scan("dpoadk8izg9y4tte7vy1xt94o") != nil || scanned != x {
+		t.Fail()
+	}
+	if scanned.Scan([]byte("e7a1d63b-7117-4423-8988-afcf12161878")) != nil || scanned != x {
+		t.Fail()
+	}
+	if scanned.Scan("user_dpoadk8izg9y4tte7vy1xt94o") != nil || scanned != x {
+		t.Fail()
+	}
+	scanned = ""
+	if scanned.Scan([]byte("user_dpoadk8izg9y4tte7vy1xt94o")) != nil || scanned != x {
+		t.Fail()
+	}
+	if scanned.Scan("org_dpoadk8izg9y4tte7vy1xt94o") == nil ||
+		scanned.Scan([]byte("org_dpoadk8izg9y4tte7vy1xt94o")) == nil || scanned.Scan(42) == nil {
+		t.Fail()
+	}
+	var long any = []byte(strings.Repeat("a", MaxPrefixLen+1) + "_dpoadk8izg9y4tte7vy1xt94o")
+	if !errors.Is(scanned.Scan(long), uuid25.ErrInputTooLong) {
+		t.Fail()
+	}
+	if n := testing.AllocsPerRun(10, func() { scanned.Scan(long) }); n != 0 {
+		t.Errorf("Scan must not allocate for too long input: %v", n)
+	}
+
+	// improper values are reported as errors rather than panics
+	var zero ID[user]
+	if _, err := zero.MarshalText(); err == nil {
+		t.Fail()
+	}
+	if _, err := json.Marshal(record{}); err == nil {
+		t.Fail()
+	}
+}
+
+// Ensures compliance with interfaces.
+func TestInterfaces(t *testing.T) {
+	var x ID[user]
+	var _ fmt.Stringer = x
+	var _ encoding.TextMarshaler = x
+	var _ encoding.TextUnmarshaler = &x
+	var _ json.Marshaler = x
+	var _ json.Unmarshaler = &x
+	var _ sql.Scanner = &x
+	var _ driver.Valuer = x
+}
@@ -0,0 +1,157 @@
+package uuid25
+
+import "math/bits"
+
+// An unsigned 128-bit integer for arithmetic on the 128-bit values of UUIDs.
+//
+// `Hi` holds the most significant 64 bits and `Lo` the least significant 64
+// bits, so the zero value represents zero. Arithmetic operations wrap around on
+// overflow like the built-in unsigned integer types; use Cmp() or the carry
+// returned by AddCarry() and SubBorrow() to detect overflow.
+type Uint128 struct {
+	Hi uint64
+	Lo uint64
+}
+
+// Converts this type into the 128-bit integer value of the UUID.
+func (uuid25 Uuid25) ToUint128() Uint128 {
+	return uuid25.toUint128()
+}
+
+// Creates an instance from the 128-bit integer value of a UUID.
+func FromUint128(x Uint128) Uuid25 {
+	buffer := encodeBase36(x)
+	return Uuid25(buffer[:])
+}
+
+// Creates an instance from a 64-bit integer.
+func Uint128From64(x uint64) Uint128 {
+	return Uint128{0, x}
+}
+
+// Reports whether the value is zero.
+func (x Uint128) IsZero() bool {
+	return x.Hi == 0 && x.Lo == 0
+}
+
+// Returns -1, 0, or +1 if `x` is less than, equal to, or greater than `y`,
+// respectively.
+func (x Uint128) Cmp(y Uint128) int {
+	if x.Hi < y.Hi || (x.Hi == y.Hi && x.Lo < y.Lo) {
+		return -1
+	} else if x == y {
+		return 0
+	}
+	return +1
+}
+
+// Returns `x + y`, wrapping around on overflow.
+func (x Uint128) Add(y Uint128) Uint128 {
+	z, _ := x.AddCarry(y)
+	return z
+}
+
+// Returns `x + y` and the carry out, which is 1 if the sum overflowed.
+func (x Uint128) AddCarry(y Uint128) (Uint128, uint64) {
+	lo, carry := bits.Add64(x.Lo, y.Lo, 0)
+	hi, carry := bits.Add64(x.Hi, y.Hi, carry)
+	return Uint128{hi, lo}, carry
+}
+
+// Returns `x - y`, wrapping around on underflow.
+func (x Uint128) Sub(y Uint128) Uint128 {
+	z, _ := x.SubBorrow(y)
+	return z
+}
+
+// Returns `x - y` and the borrow out, which is 1 if the difference underflowed.
+func (x Uint128) SubBorrow(y Uint128) (Uint128, uint64) {
+	lo, borrow := bits.Sub64(x.Lo, y.Lo, 0)
+	hi, borrow := bits.Sub64(x.Hi, y.Hi, borrow)
+	return Uint128{hi, lo}, borrow
+}
+
+// Returns `x + 1`, wrapping around on overflow.
+func (x Uint128) Inc() Uint128 {
+	return x.Add(Uint128{0, 1})
+}
+
+// Returns `x - 1`, wrapping around on underflow.
+func (x Uint128) Dec() Uint128 {
+	return x.Sub(Uint128{0, 1})
+}
+
+// Returns the quotient and remainder of `x / y`.
+//
+// This method panics if `y` is zero.
+func (x Uint128) DivMod64(y uint64) (Uint128, uint64) {
+	qhi, r := bits.Div64(0, x.Hi, y)
+	qlo, r := bits.Div64(r, x.Lo, y)
+	return Uint128{qhi, qlo}, r
+}
+
+// Returns the bitwise AND of `x` and `y`.
+func (x Uint128) And(y Uint128) Uint128 {
+	return Uint128{x.Hi & y.Hi, x.Lo & y.Lo}
+}
+
+// Returns the bitwise OR of `x` and `y`.
+func (x Uint128) Or(y Uint128) Uint128 {
+	return Uint128{x.Hi | y.Hi, x.Lo | y.Lo}
+}
+
+// Returns the bitwise XOR of `x` and `y`.
+func (x Uint128) Xor(y Uint128) Uint128 {
+	return Uint128{x.Hi ^ y.Hi, x.Lo ^ y.Lo}
+}
+
+// Returns the bitwise complement of `x`.
+func (x Uint128) Not() Uint128 {
+	return Uint128{^x.Hi, ^x.Lo}
+}
+
+// Returns `x << n`. The result is zero if `n` is 128 or greater.
+func (x Uint128) Lsh(n uint) Uint128 {
+	if n >= 64 {
+		return Uint128{x.Lo << (n - 64), 0}
+	}
+	return Uint128{x.Hi<<n | x.Lo>>(64-n), x.Lo << n}
+}
+
+// Returns `x >> n`. The result is zero if `n` is 128 or greater.
+func (x Uint128) Rsh(n uint) Uint128 {
+	if n >= 64 {
+		return Uint128{0, x.Hi >> (n - 64)}
+	}
+	return Uint128{x.Hi >> n, x.Lo>>n | x.Hi<<(64-n)}
+}
+
+// Returns the number of leading zero bits in `x`; the result is 128 for zero.
+func (x Uint128) LeadingZeros() int {
+	if x.Hi != 0 {
+		return bits.LeadingZeros64(x.Hi)
+	}
+	return 64 + bits.LeadingZeros64(x.Lo)
+}
+
+// Returns a 64-bit hash of `x` that is close to uniformly distributed for any
+// set of distinct values, including sequential values and UUIDv7 values
+// sharing the same timestamp.
+//
+// With `fmix` being the 64-bit finalizer of MurmurHash3, the hash is
+// `fmix(lo ^ fmix(hi))`, where `hi` and `lo` are the most and least significant
+// 64 bits of `x`. The result is therefore stable across versions of this
+// package and reproducible in other languages.
+func (x Uint128) Hash64() uint64 {
+	return fmix64(x.Lo ^ fmix64(x.Hi))
+}
+
+// The 64-bit finalizer of MurmurHash3.
+func fmix64(k uint64) uint64 {
+	k ^= k >> 33
+	k *= 0xff51afd7ed558ccd
+	k ^= k >> 33
+	k *= 0xc4ceb9fe1a85ec53
+	k ^= k >> 33
+	return k
+}
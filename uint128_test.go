@@ -0,0 +1,98 @@
+package uuid25
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// Converts a 128-bit integer into a big integer.
+func (x Uint128) toBig() *big.Int {
+	z := new(big.Int).SetUint64(x.Hi)
+	return z.Lsh(z, 64).Or(z, new(big.Int).SetUint64(x.Lo))
+}
+
+// Tests conversions between Uint128 and Uuid25.
+func TestUint128Conversion(t *testing.T) {
+	for _, e := range testCases {
+		x := Uuid25(e.uuid25).ToUint128()
+		if x != u128FromHex(Uuid25(e.uuid25)) || FromUint128(x) != Uuid25(e.uuid25) {
+			t.Fail()
+		}
+	}
+	if !Uuid25("0000000000000000000000000").ToUint128().IsZero() {
+		t.Fail()
+	}
+	if Uuid25("f5lxx1zz5pnorynqglhzmsp33").ToUint128() != (Uint128{}).Not() {
+		t.Fail()
+	}
+}
+
+// Tests arithmetic and bit operations against math/big.
+func TestUint128Ops(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	mod := new(big.Int).Lsh(big.NewInt(1), 128)
+	wrap := func(z *big.Int) *big.Int { return z.Mod(z, mod) }
+	random := func() Uint128 {
+		// mix in edge values occasionally
+		switch r.Intn(8) {
+		case 0:
+			return Uint128{}
+		case 1:
+			return Uint128{}.Not()
+		case 2:
+			return Uint128{0, r.Uint64()}
+		}
+		return Uint128{r.Uint64(), r.Uint64()}
+	}
+
+	for i := 0; i < 10000; i += 1 {
+		x, y := random(), random()
+		bx, by := x.toBig(), y.toBig()
+
+		if x.Cmp(y) != bx.Cmp(by) {
+			t.Fatalf("Cmp(%v, %v)", x, y)
+		}
+		if z, c := x.AddCarry(y); z.toBig().Cmp(wrap(new(big.Int).Add(bx, by))) != 0 ||
+			(c == 1) != (new(big.Int).Add(bx, by).Cmp(mod) >= 0) || z != x.Add(y) {
+			t.Fatalf("Add(%v, %v)", x, y)
+		}
+		if z, b := x.SubBorrow(y); z.toBig().Cmp(wrap(new(big.Int).Sub(bx, by))) != 0 ||
+			(b == 1) != (bx.Cmp(by) < 0) || z != x.Sub(y) {
+			t.Fatalf("Sub(%v, %v)", x, y)
+		}
+		if x.Inc().Dec() != x || x.Inc().toBig().Cmp(wrap(new(big.Int).Add(bx, big.NewInt(1)))) != 0 {
+			t.Fatalf("Inc(%v)", x)
+		}
+		if y.Lo != 0 {
+			q, m := x.DivMod64(y.Lo)
+			bq, bm := new(big.Int).DivMod(bx, new(big.Int).SetUint64(y.Lo), new(big.Int))
+			if q.toBig().Cmp(bq) != 0 || m != bm.Uint64() {
+				t.Fatalf("DivMod64(%v, %v)", x, y.Lo)
+			}
+		}
+		if x.And(y).toBig().Cmp(new(big.Int).And(bx, by)) != 0 ||
+			x.Or(y).toBig().Cmp(new(big.Int).Or(bx, by)) != 0 ||
+			x.Xor(y).toBig().Cmp(new(big.Int).Xor(bx, by)) != 0 ||
+			x.Not().Xor(x) != (Uint128{}).Not() {
+			t.Fatalf("bit ops(%v, %v)", x, y)
+		}
+		n := uint(r.Intn(140))
+		if x.Lsh(n).toBig().Cmp(wrap(new(big.Int).Lsh(bx, n))) != 0 ||
+			x.Rsh(n).toBig().Cmp(new(big.Int).Rsh(bx, n)) != 0 {
+			t.Fatalf("shift(%v, %d)", x, n)
+		}
+		if x.LeadingZeros() != 128-bx.BitLen() {
+			t.Fatalf("LeadingZeros(%v)", x)
+		}
+	}
+}
+
+// Tests the stability of Hash64().
+func TestUint128Hash64(t *testing.T) {
+	if (Uint128{}).Hash64() != 0 ||
+		(Uint128{1, 2}).Hash64() != 0x4e0fcd7162342871 ||
+		Uuid25("dpoadk8izg9y4tte7vy1xt94o").ToUint128().Hash64() != 0xa272f5391a5db170 {
+		t.Fail()
+	}
+}
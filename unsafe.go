@@ -0,0 +1,52 @@
+package uuid25
+
+import "unsafe"
+
+// Creates an instance that refers to the 25-byte canonical Uuid25 string in
+// `text` without copying it.
+//
+// This function is an opt-in optimization for storage engines that read IDs out
+// of large memory-mapped or pooled buffers. It accepts only the canonical form
+// (25 lowercase Base36 digits not exceeding 128 bits) and returns a parse error
+// otherwise, but unlike ParseUuid25() it does not allocate.
+//
+// The returned value aliases the memory of `text`, so the caller must ensure
+// that the bytes are neither modified nor unmapped while the value, or any
+// string derived from it, is in use. Otherwise, the value silently changes or
+// the program crashes. Use ParseUuid25() when in doubt.
+func FromBytesUnsafe(text []byte) (Uuid25, error) {
+	if len(text) != 25 {
+		return "", ErrInvalidLength
+	}
+	maybeTooLarge := true
+	for i, c := range text {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'z') {
+			return "", ErrInvalidDigit
+		}
+		if maybeTooLarge && c > Max[i] {
+			return "", ErrOverflow
+		} else if c < Max[i] {
+			maybeTooLarge = false
+		}
+	}
+	text = text[:25:25]
+	return Uuid25(*(*string)(unsafe.Pointer(&text))), nil
+}
+
+// The memory layout of a string header.
+type stringHeader struct {
+	data unsafe.Pointer
+	len  int
+}
+
+// Returns the 25 bytes of the Uuid25 string without copying them.
+//
+// This method is the counterpart of FromBytesUnsafe() for writing IDs into
+// buffers and hashing them without allocation. The returned slice shares memory
+// with the immutable string, so the caller must never modify it; doing so
+// results in undefined behavior. This method panics if the receiver is not
+// constructed properly.
+func (uuid25 Uuid25) ViewBytes() []byte {
+	s := uuid25.String()
+	return (*[25]byte)((*stringHeader)(unsafe.Pointer(&s)).data)[:]
+}
@@ -0,0 +1,52 @@
+package uuid25
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Tests zero-copy conversions.
+func TestFromBytesUnsafe(t *testing.T) {
+	buffer := []byte("header:" + testCases[0].uuid25 + testCases[1].uuid25 + ":footer")
+	for i := 0; i < 2; i += 1 {
+		x, err := FromBytesUnsafe(buffer[7+25*i : 7+25*(i+1)])
+		if string(x) != testCases[i].uuid25 || err != nil {
+			t.Fail()
+		}
+		if !bytes.Equal(x.ViewBytes(), []byte(testCases[i].uuid25)) {
+			t.Fail()
+		}
+	}
+
+	// the returned value must alias the buffer
+	text := []byte("3ud3gtvgolimgu9lah6aie99o")
+	x, _ := FromBytesUnsafe(text)
+	text[0] = '2'
+	if x != "2ud3gtvgolimgu9lah6aie99o" {
+		t.Fail()
+	}
+
+	cases := []struct {
+		input    string
+		expected error
+	}{
+		{"3ud3gtvgolimgu9lah6aie99", ErrInvalidLength},
+		{"3UD3GTVGOLIMGU9LAH6AIE99O", ErrInvalidDigit},
+		{"3ud3gtvgolimgu9lah6aie99-", ErrInvalidDigit},
+		{"f5lxx1zz5pnorynqglhzmsp34", ErrOverflow},
+		{"f5lxx1zz5pnorynqglhzmsp33", nil},
+	}
+	for _, e := range cases {
+		if _, err := FromBytesUnsafe([]byte(e.input)); err != e.expected {
+			t.Errorf("unexpected error for %q: %v", e.input, err)
+		}
+	}
+
+	if n := testing.AllocsPerRun(10, func() { FromBytesUnsafe(buffer[7:32]) }); n != 0 {
+		t.Errorf("FromBytesUnsafe must not allocate: %v", n)
+	}
+	y := Uuid25(testCases[0].uuid25)
+	if n := testing.AllocsPerRun(10, func() { y.ViewBytes() }); n != 0 {
+		t.Errorf("ViewBytes must not allocate: %v", n)
+	}
+}
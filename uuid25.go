@@ -8,8 +8,10 @@ package uuid25
 
 import (
 	"database/sql/driver"
+	"encoding/binary"
+	"encoding/json"
 	"errors"
-	"math"
+	"fmt"
 )
 
 // The primary value type containing the Uuid25 representation of a UUID.
@@ -28,54 +30,25 @@ func (uuid25 Uuid25) String() string {
 	return string(uuid25)
 }
 
-// Creates an instance from an array of Base36 digit values.
-func fromDigitValues(digitValues []byte) (Uuid25, error) {
-	if len(digitValues) != 25 {
-		panic("invalid length of digit value array")
-	}
-	const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
-	const u128Max = "f5lxx1zz5pnorynqglhzmsp33" // 2^128 - 1
-
-	var buffer [25]byte
-	maybeTooLarge := true
-	for i, e := range digitValues {
-		if e >= 36 {
-			return "", parseError // invalid digit value
-		}
-		buffer[i] = digits[e]
-		if maybeTooLarge && buffer[i] > u128Max[i] {
-			return "", parseError // 128-bit overflow
-		} else if buffer[i] < u128Max[i] {
-			maybeTooLarge = false
-		}
-	}
-	return Uuid25(buffer[:]), nil
-}
-
 // Creates an instance from a 16-byte UUID binary representation.
 func FromBytes(uuidBytes []byte) Uuid25 {
 	if len(uuidBytes) != 16 {
 		panic("the length of byte slice must be 16")
 	}
-	var buffer [25]byte
-	if convertBase(uuidBytes[:], buffer[:], 256, 36) == nil {
-		if uuid25, err := fromDigitValues(buffer[:]); err == nil {
-			return uuid25
-		}
-	}
-	panic("unreachable")
+	buffer := encodeBase36(Uint128{
+		binary.BigEndian.Uint64(uuidBytes[:8]),
+		binary.BigEndian.Uint64(uuidBytes[8:]),
+	})
+	return Uuid25(buffer[:])
 }
 
 // Converts this type into the 16-byte binary representation of a UUID.
 func (uuid25 Uuid25) ToBytes() [16]byte {
-	var src [25]byte
-	if decodeDigitChars(uuid25.String(), src[:], 36) == nil {
-		var uuidBytes [16]byte
-		if convertBase(src[:], uuidBytes[:], 36, 256) == nil {
-			return uuidBytes
-		}
-	}
-	panic("unreachable")
+	x := uuid25.toUint128()
+	var uuidBytes [16]byte
+	binary.BigEndian.PutUint64(uuidBytes[:8], x.Hi)
+	binary.BigEndian.PutUint64(uuidBytes[8:], x.Lo)
+	return uuidBytes
 }
 
 // Creates an instance from a UUID string representation.
@@ -102,110 +75,122 @@ func Parse(uuidString string) (Uuid25, error) {
 	case 45:
 		return ParseUrn(uuidString)
 	default:
-		return "", parseError
+		return "", newParseError(ErrInvalidLength, uuidString, FormatInvalid)
 	}
 }
 
 // Creates an instance from the 25-digit Base36 Uuid25 format:
 // `3ud3gtvgolimgu9lah6aie99o`.
 func ParseUuid25(uuidString string) (Uuid25, error) {
-	if len(uuidString) != 25 {
-		return "", parseError
+	x, err := decodeBase36(uuidString)
+	if err != nil {
+		return "", newParseError(err, uuidString, FormatUuid25)
 	}
 	var buffer [25]byte
-	if err := decodeDigitChars(uuidString, buffer[:], 36); err != nil {
-		return "", parseError
+	for i := 0; i < 25; i += 1 {
+		if c := uuidString[i]; c >= 'A' && c <= 'Z' {
+			// re-encode to lowercase only when necessary
+			buffer = encodeBase36(x)
+			return Uuid25(buffer[:]), nil
+		}
 	}
-	return fromDigitValues(buffer[:])
+	copy(buffer[:], uuidString)
+	return Uuid25(buffer[:]), nil
 }
 
 // Creates an instance from the 32-digit hexadecimal format without hyphens:
 // `40eb9860cf3e45e2a90eb82236ac806c`.
 func ParseHex(uuidString string) (Uuid25, error) {
-	if len(uuidString) != 32 {
-		return "", parseError
-	}
-	var src [32]byte
-	if err := decodeDigitChars(uuidString, src[:], 16); err != nil {
-		return "", parseError
-	}
-	var buffer [25]byte
-	if err := convertBase(src[:], buffer[:], 16, 36); err != nil {
-		return "", parseError
+	x, err := decodeHex(uuidString)
+	if err != nil {
+		return "", newParseError(err, uuidString, FormatHex)
 	}
-	return fromDigitValues(buffer[:])
+	buffer := encodeBase36(x)
+	return Uuid25(buffer[:]), nil
 }
 
 // Creates an instance from the 8-4-4-4-12 hyphenated format:
 // `40eb9860-cf3e-45e2-a90e-b82236ac806c`.
 func ParseHyphenated(uuidString string) (Uuid25, error) {
-	if len(uuidString) != 36 ||
-		uuidString[8] != '-' ||
-		uuidString[13] != '-' ||
-		uuidString[18] != '-' ||
-		uuidString[23] != '-' {
-		return "", parseError
+	x, err := decodeHyphenated(uuidString)
+	if err != nil {
+		return "", newParseError(err, uuidString, FormatHyphenated)
 	}
-	return ParseHex(
-		uuidString[:8] +
-			uuidString[9:13] +
-			uuidString[14:18] +
-			uuidString[19:23] +
-			uuidString[24:])
+	buffer := encodeBase36(x)
+	return Uuid25(buffer[:]), nil
 }
 
 // Creates an instance from the hyphenated format with surrounding braces:
 // `{40eb9860-cf3e-45e2-a90e-b82236ac806c}`.
 func ParseBraced(uuidString string) (Uuid25, error) {
-	if len(uuidString) != 38 ||
-		uuidString[0] != '{' ||
-		uuidString[37] != '}' {
-		return "", parseError
+	x, err := decodeBraced(uuidString)
+	if err != nil {
+		return "", newParseError(err, uuidString, FormatBraced)
 	}
-	return ParseHyphenated(uuidString[1:37])
+	buffer := encodeBase36(x)
+	return Uuid25(buffer[:]), nil
 }
 
 // Creates an instance from the RFC 4122 URN format:
 // `urn:uuid:40eb9860-cf3e-45e2-a90e-b82236ac806c`.
 func ParseUrn(uuidString string) (Uuid25, error) {
-	if len(uuidString) != 45 ||
-		(uuidString[0] != 'U' && uuidString[0] != 'u') ||
-		(uuidString[1] != 'R' && uuidString[1] != 'r') ||
-		(uuidString[2] != 'N' && uuidString[2] != 'n') ||
-		(uuidString[3] != ':') ||
-		(uuidString[4] != 'U' && uuidString[4] != 'u') ||
-		(uuidString[5] != 'U' && uuidString[5] != 'u') ||
-		(uuidString[6] != 'I' && uuidString[6] != 'i') ||
-		(uuidString[7] != 'D' && uuidString[7] != 'd') ||
-		(uuidString[8] != ':') {
-		return "", parseError
+	x, err := decodeUrn(uuidString)
+	if err != nil {
+		return "", newParseError(err, uuidString, FormatUrn)
 	}
-	return ParseHyphenated(uuidString[9:])
+	buffer := encodeBase36(x)
+	return Uuid25(buffer[:]), nil
+}
+
+// Creates an instance from a Base36 string of up to 25 digits, treating a
+// shorter string as if it were left-padded with zeros.
+//
+// This opt-in function accepts IDs produced by naive big-integer encoders that
+// omit leading zeros, such as `1` for `0000000000000000000000001`, and returns
+// the canonical Uuid25 value. Like ParseUuid25(), it is case-insensitive and
+// rejects values exceeding 128 bits. Use ParseUuid25() unless such input is
+// expected, because padding also accepts truncated IDs.
+func ParsePaddedBase36(uuidString string) (Uuid25, error) {
+	if len(uuidString) == 0 || len(uuidString) > 25 {
+		return "", &ParseError{ErrInvalidLength, FormatUuid25, -1}
+	}
+	var buffer [25]byte
+	n := copy(buffer[25-len(uuidString):], uuidString)
+	for i := 0; i < 25-n; i += 1 {
+		buffer[i] = '0'
+	}
+	uuid25, err := ParseUuid25(string(buffer[:]))
+	if e, ok := err.(*ParseError); ok && e.Offset >= 0 {
+		e.Offset -= 25 - n
+	}
+	return uuid25, err
 }
 
 // Formats this type in the 32-digit hexadecimal format without hyphens:
 // `40eb9860cf3e45e2a90eb82236ac806c`.
 func (uuid25 Uuid25) ToHex() string {
-	const digits = "0123456789abcdef"
-	var src [25]byte
-	if decodeDigitChars(uuid25.String(), src[:], 36) != nil {
-		panic("unreachable")
-	}
+	x := uuid25.toUint128()
 	var buffer [32]byte
-	if convertBase(src[:], buffer[:], 36, 16) != nil {
-		panic("unreachable")
-	}
-	for i, e := range buffer {
-		buffer[i] = digits[e]
-	}
+	putHex(buffer[:16], x.Hi)
+	putHex(buffer[16:], x.Lo)
 	return string(buffer[:])
 }
 
 // Formats this type in the 8-4-4-4-12 hyphenated format:
 // `40eb9860-cf3e-45e2-a90e-b82236ac806c`.
 func (uuid25 Uuid25) ToHyphenated() string {
-	s := uuid25.ToHex()
-	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+	x := uuid25.toUint128()
+	var hex [32]byte
+	putHex(hex[:16], x.Hi)
+	putHex(hex[16:], x.Lo)
+	var buffer [36]byte
+	copy(buffer[:8], hex[:8])
+	copy(buffer[9:13], hex[8:12])
+	copy(buffer[14:18], hex[12:16])
+	copy(buffer[19:23], hex[16:20])
+	copy(buffer[24:], hex[20:])
+	buffer[8], buffer[13], buffer[18], buffer[23] = '-', '-', '-', '-'
+	return string(buffer[:])
 }
 
 // Formats this type in the hyphenated format with surrounding braces:
@@ -220,19 +205,128 @@ func (uuid25 Uuid25) ToUrn() string {
 	return "urn:uuid:" + uuid25.ToHyphenated()
 }
 
+// The maximum length in bytes of inputs accepted by UnmarshalText(),
+// UnmarshalBinary(), and Scan(), which is the length of the longest supported
+// format (RFC 4122 URN).
+const MaxInputLen = 45
+
+// An error returned when an input exceeds MaxInputLen bytes. This error wraps
+// ErrInvalidLength.
+//
+// UnmarshalText(), UnmarshalBinary(), and Scan() reject such inputs before
+// copying or parsing them, so that values from untrusted sources such as query
+// parameters cannot cause a large allocation.
+var ErrInputTooLong = fmt.Errorf("%w: input too long", ErrInvalidLength)
+
+// An error returned by Scan() when the source is of an unsupported type.
+var ErrUnsupportedType = errors.New("unsupported type conversion")
+
 // Implements the encoding.TextUnmarshaler interface.
+//
+// This method returns ErrInputTooLong if the input exceeds MaxInputLen bytes.
 func (uuid25 *Uuid25) UnmarshalText(text []byte) error {
 	if uuid25 == nil {
 		return errors.New("nil receiver")
+	} else if len(text) > MaxInputLen {
+		*uuid25 = ""
+		return ErrInputTooLong
 	}
-	result, err := Parse(string(text))
+	result, err := ParseBytes(text)
 	*uuid25 = result
 	return err
 }
 
 // Implements the encoding.TextMarshaler interface.
+//
+// Unlike String(), this method returns an error instead of panicking if the
+// receiver is not constructed properly, so that encoders report an improper
+// value as an ordinary error.
 func (uuid25 Uuid25) MarshalText() (text []byte, err error) {
-	return []byte(uuid25.String()), nil
+	if len(uuid25) != 25 {
+		return nil, errImproperValue
+	}
+	return []byte(uuid25), nil
+}
+
+// A Uuid25 variant that encodes the zero value as a JSON null.
+//
+// Uuid25 itself rejects the zero value when marshaling, which catches
+// uninitialized IDs. Declare fields with this type to opt into a null instead,
+// and convert between this type and Uuid25 with a plain type conversion. A nil
+// *Uuid25 is encoded as a JSON null by encoding/json without this type.
+type ZeroAsNull Uuid25
+
+// Implements the json.Marshaler interface, encoding the zero value as a JSON
+// null.
+func (x ZeroAsNull) MarshalJSON() ([]byte, error) {
+	if x == "" {
+		return []byte("null"), nil
+	}
+	return marshalJSONString(Uuid25(x))
+}
+
+// Implements the json.Unmarshaler interface, decoding a JSON null as the zero
+// value.
+func (x *ZeroAsNull) UnmarshalJSON(data []byte) error {
+	if x == nil {
+		return errors.New("nil receiver")
+	} else if string(data) == "null" {
+		*x = ""
+		return nil
+	}
+	return (*Uuid25)(x).unmarshalJSONString(data)
+}
+
+// A Uuid25 variant that encodes the zero value as the Nil UUID
+// `0000000000000000000000000`.
+//
+// Declare fields with this type to opt into the Nil UUID instead of the error
+// that Uuid25 returns for the zero value, and convert between this type and
+// Uuid25 with a plain type conversion.
+type ZeroAsNil Uuid25
+
+// Implements the json.Marshaler interface, encoding the zero value as the Nil
+// UUID.
+func (x ZeroAsNil) MarshalJSON() ([]byte, error) {
+	if x == "" {
+		return marshalJSONString(Nil)
+	}
+	return marshalJSONString(Uuid25(x))
+}
+
+// Implements the json.Unmarshaler interface.
+func (x *ZeroAsNil) UnmarshalJSON(data []byte) error {
+	if x == nil {
+		return errors.New("nil receiver")
+	}
+	return (*Uuid25)(x).unmarshalJSONString(data)
+}
+
+// Returns the JSON string of a Uuid25 value, or an error if the value is not
+// constructed properly.
+func marshalJSONString(uuid25 Uuid25) ([]byte, error) {
+	text, err := uuid25.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(`"` + string(text) + `"`), nil
+}
+
+// Decodes a JSON string in any supported format.
+//
+// This method returns ErrInputTooLong without decoding the string if `data`
+// exceeds the length of the longest format written entirely in `\uXXXX`
+// escapes.
+func (uuid25 *Uuid25) unmarshalJSONString(data []byte) error {
+	if len(data) > 6*MaxInputLen+2 {
+		*uuid25 = ""
+		return ErrInputTooLong
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return uuid25.UnmarshalText([]byte(s))
 }
 
 // Implements the encoding.BinaryUnmarshaler interface.
@@ -247,22 +341,35 @@ func (uuid25 *Uuid25) UnmarshalBinary(data []byte) error {
 }
 
 // Implements the encoding.BinaryMarshaler interface.
+//
+// This method returns the 25-byte Uuid25 string, identical to MarshalText().
+// Use the Compact type to store UUIDs in 16 bytes through gob, CBOR, and other
+// binary encoders.
 func (uuid25 Uuid25) MarshalBinary() (data []byte, err error) {
 	return uuid25.MarshalText()
 }
 
 // Implements the sql.Scanner interface.
+//
+// This method returns ErrInputTooLong if the source exceeds MaxInputLen bytes
+// and ErrUnsupportedType if the source is neither a string nor a byte slice.
 func (uuid25 *Uuid25) Scan(src any) error {
 	if uuid25 == nil {
 		return errors.New("nil receiver")
 	}
 	switch src := src.(type) {
 	case string:
-		return uuid25.UnmarshalText([]byte(src))
+		if len(src) > MaxInputLen {
+			*uuid25 = ""
+			return ErrInputTooLong
+		}
+		result, err := Parse(src)
+		*uuid25 = result
+		return err
 	case []byte:
 		return uuid25.UnmarshalBinary(src)
 	default:
-		return errors.New("unsupported type conversion")
+		return ErrUnsupportedType
 	}
 }
 
@@ -272,109 +379,24 @@ func (uuid25 Uuid25) Value() (driver.Value, error) {
 }
 
 // An error parsing a UUID string representation.
-var parseError = errors.New("could not parse a UUID string")
-
-// Converts a digit value array in `srcBase` to that in `dstBase`.
-func convertBase(src []byte, dst []byte, srcBase uint, dstBase uint) error {
-	if srcBase < 2 || srcBase > 256 || dstBase < 2 || dstBase > 256 {
-		panic("invalid base")
-	}
-
-	// determine the number of `src` digits to read for each outer loop
-	wordLen := 1
-	wordBase := srcBase
-	for wordBase <= math.MaxUint/(srcBase*dstBase) {
-		wordLen += 1
-		wordBase *= srcBase
-	}
-
-	for i := range dst {
-		dst[i] = 0
-	}
-
-	if len(src) == 0 {
-		return nil
-	} else if len(dst) == 0 {
-		return errors.New("too small dst")
-	}
-
-	dstUsed := len(dst) - 1 // storage to memorize range of `dst` filled
-
-	// read `wordLen` digits from `src` for each outer loop
-	wordHead := len(src) % wordLen
-	if wordHead > 0 {
-		wordHead -= wordLen
-	}
-	for ; wordHead < len(src); wordHead += wordLen {
-		var carry uint = 0
-		i := wordHead
-		if i < 0 {
-			i = 0
-		}
-		for ; i < wordHead+wordLen; i += 1 {
-			e := uint(src[i])
-			if e >= srcBase {
-				panic("invalid src digit")
-			}
-			carry = carry*srcBase + e
-		}
-
-		// fill in `dst` from right to left, while carrying up prior result to left
-		for i := len(dst) - 1; i >= 0; i -= 1 {
-			carry += uint(dst[i]) * wordBase
-			dst[i] = byte(carry % dstBase)
-			carry /= dstBase
-
-			// break inner loop when `carry` and remaining `dst` digits are all zero
-			if carry == 0 && i <= dstUsed {
-				dstUsed = i
-				break
-			}
-		}
-		if carry > 0 {
-			return errors.New("too small dst")
-		}
-	}
-	return nil
-}
-
-// An O(1) map from ASCII code points to Base36 digit values.
-var decodeMap = [256]byte{
-	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x01, 0x02, 0x03,
-	0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-	0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16,
-	0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20, 0x21, 0x22, 0x23,
-	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
-	0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d,
-	0x1e, 0x1f, 0x20, 0x21, 0x22, 0x23, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-}
-
-// Converts from a string of digit characters to an array of digit values.
-func decodeDigitChars(src string, dst []byte, base byte) error {
-	if base < 2 || base > 36 {
-		panic("invalid base")
-	}
-	if len(src) != len(dst) {
-		panic("invalid length of dst slice")
-	}
-	for i := 0; i < len(src); i += 1 {
-		dst[i] = decodeMap[src[i]]
-		if dst[i] >= base {
-			return errors.New("invalid digit character")
-		}
-	}
-	return nil
-}
+//
+// The parse functions return a *ParseError wrapping one of ErrInvalidLength,
+// ErrInvalidDigit, ErrOverflow, and ErrWrongFormat, all of which wrap this
+// error, so callers can test for any parse failure with
+// `errors.Is(err, uuid25.ErrParse)`.
+var ErrParse = errors.New("could not parse a UUID string")
+
+// An error returned when a string has a length of none of the supported
+// formats.
+var ErrInvalidLength = fmt.Errorf("%w: invalid length", ErrParse)
+
+// An error returned when a string contains a character not allowed at its
+// position, such as a non-digit character or a misplaced hyphen.
+var ErrInvalidDigit = fmt.Errorf("%w: invalid digit", ErrParse)
+
+// An error returned when a 25-digit Base36 string represents a value exceeding
+// 128 bits.
+var ErrOverflow = fmt.Errorf("%w: value exceeds 128 bits", ErrParse)
+
+// An error marshaling a value not constructed properly.
+var errImproperValue = errors.New("receiver not constructed properly")
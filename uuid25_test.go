@@ -5,8 +5,14 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
+	"strings"
 	"testing"
+
+	"github.com/uuid25/go-uuid25/baseconv"
 )
 
 // Tests equality comparison.
@@ -206,6 +212,126 @@ func TestParseErr(t *testing.T) {
 	}
 }
 
+// Tests the classification of parse errors.
+func TestParseErrKinds(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected error
+		offset   int
+	}{
+		{"", ErrInvalidLength, -1},
+		{"5xe2jcp3zjc704bvftqjzbiw", ErrInvalidLength, -1},
+		{"{8273b64c5ed0a88b10dad09a6a2b963c}", ErrInvalidLength, -1},
+		{"f5lxx1zz5pnorynqglhzmsp34", ErrOverflow, -1},
+		{"zzzzzzzzzzzzzzzzzzzzzzzzz", ErrOverflow, -1},
+		{"65xe2jcp-zjc704bvftqjzbiw", ErrInvalidDigit, 8},
+		{"82f1dd3cd-e95-075b-93ff-a240f135f8fd", ErrInvalidDigit, 8},
+		{"82f1dd3c-de95-075b-93ff-a240f135f8fg", ErrInvalidDigit, 35},
+		{"(82f1dd3c-de95-075b-93ff-a240f135f8fd)", ErrInvalidDigit, 0},
+		{"{82f1dd3c-de95-075b-93ff-a240f135f8fd)", ErrInvalidDigit, 37},
+		{"{82f1dd3c-de95-075b-93ff-a240f135f8fd-", ErrInvalidDigit, 37},
+		{"urn:uuid+82f1dd3c-de95-075b-93ff-a240f135f8fd", ErrInvalidDigit, 8},
+		{"URN:UUID:82f1dd3c-de95-075b-93ff_a240f135f8fd", ErrInvalidDigit, 32},
+		{"urn\x1auuid:82f1dd3c-de95-075b-93ff-a240f135f8fd", ErrInvalidDigit, 3},
+		{"urn:uuid\x1a82f1dd3c-de95-075b-93ff-a240f135f8fd", ErrInvalidDigit, 8},
+		{"urn:uuid:82f1dd3c-de95-075b-93ff-a240f135f8fd", nil, -1},
+	}
+	for _, e := range cases {
+		_, err := Parse(e.input)
+		if e.expected == nil {
+			if err != nil {
+				t.Errorf("unexpected error for %q: %v", e.input, err)
+			}
+			continue
+		}
+		var pe *ParseError
+		if !errors.Is(err, e.expected) || !errors.Is(err, ErrParse) || !errors.As(err, &pe) {
+			t.Errorf("unexpected error for %q: %v", e.input, err)
+		} else if pe.Err != e.expected || pe.Offset != e.offset {
+			t.Errorf("unexpected details for %q: %v %d", e.input, pe.Err, pe.Offset)
+		}
+	}
+
+	var x Uuid25
+	if err := x.Scan(strings.Repeat("0", 46)); !errors.Is(err, ErrInvalidLength) || !errors.Is(err, ErrParse) {
+		t.Fail()
+	}
+}
+
+// Tests ErrWrongFormat and the messages of parse errors.
+func TestParseError(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected string
+	}{
+		{errorOf(ParseHex("40eb9860-cf3e-45e2-a90e-b82236ac806c")),
+			"could not parse a UUID string: invalid length: wrong format (hyphenated format)"},
+		{errorOf(ParseUuid25("40eb9860cf3e45e2a90eb82236ac806c")),
+			"could not parse a UUID string: invalid length: wrong format (hex format)"},
+		{errorOf(ParseHyphenated("40eb9860-cf3e-45e2-a90e-b82236ac806")),
+			"could not parse a UUID string: invalid length (hyphenated format)"},
+		{errorOf(ParseUrn("urn:uuid:40eb9860-cf3e-45e2-a90e-b82236ac8o6c")),
+			"could not parse a UUID string: invalid digit at offset 42 in urn format"},
+		{errorOf(ParseVersion("40eb9860-cf3e-45e2-a90e-b82236ac8o6c", 4)),
+			"could not parse a UUID string: invalid digit at offset 33 in hyphenated format"},
+		{errorOf(ParsePaddedBase36("3ud3gtvgol_mgu9lah6aie99o")),
+			"could not parse a UUID string: invalid digit at offset 10 in uuid25 format"},
+		{errorOf(ParsePaddedBase36("gol_mgu9lah6aie99o")),
+			"could not parse a UUID string: invalid digit at offset 3 in uuid25 format"},
+	}
+	for _, e := range cases {
+		if e.err == nil || e.err.Error() != e.expected {
+			t.Errorf("unexpected error: %v", e.err)
+		}
+	}
+
+	_, err := ParseHex("{40eb9860-cf3e-45e2-a90e-b82236ac806c}")
+	if !errors.Is(err, ErrWrongFormat) || !errors.Is(err, ErrInvalidLength) || !errors.Is(err, ErrParse) {
+		t.Fail()
+	}
+	if _, err := ParseNoAlloc("40eb9860-cf3e-45e2-a90e-b82236ac806"); err != ErrInvalidLength {
+		t.Fail()
+	}
+}
+
+// Returns the error of a parse function result.
+func errorOf(_ Uuid25, err error) error {
+	return err
+}
+
+// Tests parsing of Base36 strings shorter than 25 digits.
+func TestParsePaddedBase36(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{"0", "0000000000000000000000000"},
+		{"1", "0000000000000000000000001"},
+		{"Z", "000000000000000000000000z"},
+		{"3ud3gtvgolimgu9lah6aie99o", "3ud3gtvgolimgu9lah6aie99o"},
+		{"ud3gtvgolimgu9lah6aie99o", "0ud3gtvgolimgu9lah6aie99o"},
+		{"F5LXX1ZZ5PNORYNQGLHZMSP33", "f5lxx1zz5pnorynqglhzmsp33"},
+	}
+	for _, e := range cases {
+		if x, err := ParsePaddedBase36(e.input); err != nil || x != Uuid25(e.expected) {
+			t.Errorf("unexpected result for %q: %s %v", e.input, x, err)
+		}
+	}
+
+	errCases := []struct {
+		input    string
+		expected error
+	}{
+		{"", ErrInvalidLength},
+		{"03ud3gtvgolimgu9lah6aie99o", ErrInvalidLength},
+		{"f5lxx1zz5pnorynqglhzmsp34", ErrOverflow},
+		{"-1", ErrInvalidDigit},
+		{" 1", ErrInvalidDigit},
+	}
+	for _, e := range errCases {
+		if _, err := ParsePaddedBase36(e.input); !errors.Is(err, e.expected) {
+			t.Errorf("unexpected error for %q: %v", e.input, err)
+		}
+	}
+}
+
 // Tests the encoding.BinaryMarshaler and encoding.TextMarshaler interface
 // implementation.
 func TestMarshalers(t *testing.T) {
@@ -220,6 +346,73 @@ func TestMarshalers(t *testing.T) {
 	}
 }
 
+// Tests the JSON encoding of Uuid25 and the zero value handling of ZeroAsNull
+// and ZeroAsNil.
+func TestMarshalJSON(t *testing.T) {
+	for _, e := range testCases {
+		x, _ := Parse(e.uuid25)
+		if y, err := json.Marshal(x); string(y) != `"`+e.uuid25+`"` || err != nil {
+			t.Fail()
+		}
+		if y, err := json.Marshal(ZeroAsNull(x)); string(y) != `"`+e.uuid25+`"` || err != nil {
+			t.Fail()
+		}
+		if y, err := json.Marshal(ZeroAsNil(x)); string(y) != `"`+e.uuid25+`"` || err != nil {
+			t.Fail()
+		}
+	}
+
+	var record struct {
+		ID    Uuid25     `json:"id"`
+		Ref   *Uuid25    `json:"ref"`
+		Null  ZeroAsNull `json:"null"`
+		Nil   ZeroAsNil  `json:"nil"`
+		Other Uuid25     `json:"other"`
+	}
+	record.Other, _ = Parse("40eb9860-cf3e-45e2-a90e-b82236ac806c")
+	if _, err := json.Marshal(record); err == nil {
+		t.Fail()
+	}
+	if _, err := json.Marshal(&record); err == nil {
+		t.Fail()
+	}
+
+	record.ID = record.Other
+	expected := `{"id":"3ud3gtvgolimgu9lah6aie99o","ref":null,"null":null,"nil":"0000000000000000000000000","other":"3ud3gtvgolimgu9lah6aie99o"}`
+	if y, err := json.Marshal(record); string(y) != expected || err != nil {
+		t.Fail()
+	}
+
+	var decoded struct {
+		Null ZeroAsNull `json:"null"`
+		Nil  ZeroAsNil  `json:"nil"`
+	}
+	decoded.Null = "3ud3gtvgolimgu9lah6aie99o"
+	if err := json.Unmarshal([]byte(expected), &decoded); err != nil ||
+		decoded.Null != "" || Uuid25(decoded.Nil) != Nil {
+		t.Fail()
+	}
+	if json.Unmarshal([]byte(`{"null":"40eb9860-cf3e-45e2-a90e-b82236ac806c"}`), &decoded) != nil ||
+		decoded.Null != "3ud3gtvgolimgu9lah6aie99o" {
+		t.Fail()
+	}
+	if json.Unmarshal([]byte(`{"nil":"foo"}`), &decoded) == nil ||
+		json.Unmarshal([]byte(`{"null":1}`), &decoded) == nil {
+		t.Fail()
+	}
+
+	improper := Uuid25("3ud3gtvgolimgu9lah6aie99")
+	if _, err := json.Marshal(ZeroAsNull(improper)); err == nil {
+		t.Fail()
+	}
+	if _, err := json.Marshal(ZeroAsNil(improper)); err == nil {
+		t.Fail()
+	}
+	if _, err := improper.MarshalText(); err == nil {
+		t.Fail()
+	}
+}
+
 // Tests the encoding.BinaryUnmarshaler and encoding.TextUnmarshaler interface
 // implementation.
 func TestUnmarshalers(t *testing.T) {
@@ -313,12 +506,58 @@ func TestScannerValuer(t *testing.T) {
 	}
 }
 
+// Tests that Scan() and UnmarshalText() reject hostile inputs without parsing
+// or copying them.
+func TestScanHostile(t *testing.T) {
+	long := strings.Repeat("3ud3gtvgolimgu9lah6aie99o", 1<<16)
+	inputs := []string{
+		long,
+		"urn:uuid:40eb9860-cf3e-45e2-a90e-b82236ac806c ",
+		"{40eb9860-cf3e-45e2-a90e-b82236ac806c}" + strings.Repeat("}", 100),
+		strings.Repeat("\x00", 46),
+		strings.Repeat("\u00e9", 23),
+	}
+	for _, e := range inputs {
+		var x Uuid25 = "3ud3gtvgolimgu9lah6aie99o"
+		if err := x.Scan(e); err != ErrInputTooLong || x != "" {
+			t.Fail()
+		}
+		if err := x.Scan([]byte(e)); err != ErrInputTooLong || x != "" {
+			t.Fail()
+		}
+		if err := x.UnmarshalText([]byte(e)); err != ErrInputTooLong || x != "" {
+			t.Fail()
+		}
+	}
+
+	for _, e := range []string{"", "\x00", strings.Repeat("\x00", 25), "3ud3gtvgolimgu9lah6aie99\u00e9", "' OR 1=1 --"} {
+		var x Uuid25
+		if err := x.Scan(e); err == nil || err == ErrInputTooLong {
+			t.Fail()
+		}
+	}
+
+	var x Uuid25
+	if err := x.Scan(42); err != ErrUnsupportedType {
+		t.Fail()
+	}
+
+	var src any = []byte(long)
+	if n := testing.AllocsPerRun(10, func() { x.Scan(src) }); n != 0 {
+		t.Errorf("Scan must not allocate for too long input: %v", n)
+	}
+}
+
 // Ensures compliance with interfaces.
 func TestInterfaces(t *testing.T) {
 	var x Uuid25
 	var _ fmt.Stringer = x
 	var _ encoding.TextMarshaler = x
 	var _ encoding.TextUnmarshaler = &x
+	var _ json.Marshaler = ZeroAsNull(x)
+	var _ json.Unmarshaler = (*ZeroAsNull)(&x)
+	var _ json.Marshaler = ZeroAsNil(x)
+	var _ json.Unmarshaler = (*ZeroAsNil)(&x)
 	var _ encoding.BinaryMarshaler = x
 	var _ encoding.BinaryUnmarshaler = &x
 	var _ sql.Scanner = &x
@@ -352,3 +591,75 @@ var testCases = []struct {
 	{uuid25: "6ry55bbvow6mllk9nvfsd4w5f", hex: "7275ea4776280fa82afb0c4b47f148c3", hyphenated: "7275ea47-7628-0fa8-2afb-0c4b47f148c3", braced: "{7275ea47-7628-0fa8-2afb-0c4b47f148c3}", urn: "urn:uuid:7275ea47-7628-0fa8-2afb-0c4b47f148c3", bytes: []byte{114, 117, 234, 71, 118, 40, 15, 168, 42, 251, 12, 75, 71, 241, 72, 195}},
 	{uuid25: "1xl7tld67nekvdlrp0pkvsut5", hex: "20a6bddafff4faa14e8fc0eb75a169f9", hyphenated: "20a6bdda-fff4-faa1-4e8f-c0eb75a169f9", braced: "{20a6bdda-fff4-faa1-4e8f-c0eb75a169f9}", urn: "urn:uuid:20a6bdda-fff4-faa1-4e8f-c0eb75a169f9", bytes: []byte{32, 166, 189, 218, 255, 244, 250, 161, 78, 143, 192, 235, 117, 161, 105, 249}},
 }
+
+// Tests the conversion fast paths against the generic base conversion.
+func TestConversionConsistency(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i += 1 {
+		var b [16]byte
+		r.Read(b[:])
+		if i%4 == 0 {
+			// exercise values with many leading or trailing zero or one bits
+			for j := r.Intn(16); j < 16; j += 1 {
+				b[j] = byte(0xff * r.Intn(2))
+			}
+		}
+
+		var digits [25]byte
+		if baseconv.Convert(b[:], digits[:], 256, 36) != nil {
+			t.Fatal("unexpected conversion error")
+		}
+		expected, _ := baseconv.EncodeDigits(digits[:], 36)
+		x := FromBytes(b[:])
+		if x.String() != expected || x.ToBytes() != b {
+			t.Fatalf("unexpected conversion: %x", b)
+		}
+		if y, err := ParseUuid25(strings.ToUpper(expected)); y != x || err != nil {
+			t.Fatalf("unexpected conversion: %s", expected)
+		}
+		if y, err := ParseHyphenated(strings.ToUpper(x.ToHyphenated())); y != x || err != nil {
+			t.Fatalf("unexpected conversion: %s", x.ToHyphenated())
+		}
+		if x.ToHex() != fmt.Sprintf("%x", b) {
+			t.Fatalf("unexpected conversion: %x", b)
+		}
+	}
+}
+
+// Benchmarks parsing the hyphenated format.
+func BenchmarkParseHyphenated(b *testing.B) {
+	for i := 0; i < b.N; i += 1 {
+		ParseHyphenated("40eb9860-cf3e-45e2-a90e-b82236ac806c")
+	}
+}
+
+// Benchmarks parsing the Uuid25 format.
+func BenchmarkParseUuid25(b *testing.B) {
+	for i := 0; i < b.N; i += 1 {
+		ParseUuid25("3ud3gtvgolimgu9lah6aie99o")
+	}
+}
+
+// Benchmarks formatting in the hyphenated format.
+func BenchmarkToHyphenated(b *testing.B) {
+	x := Uuid25("3ud3gtvgolimgu9lah6aie99o")
+	for i := 0; i < b.N; i += 1 {
+		x.ToHyphenated()
+	}
+}
+
+// Benchmarks conversion from the 16-byte representation.
+func BenchmarkFromBytes(b *testing.B) {
+	x := []byte{0x40, 0xeb, 0x98, 0x60, 0xcf, 0x3e, 0x45, 0xe2, 0xa9, 0x0e, 0xb8, 0x22, 0x36, 0xac, 0x80, 0x6c}
+	for i := 0; i < b.N; i += 1 {
+		FromBytes(x)
+	}
+}
+
+// Benchmarks conversion into the 16-byte representation.
+func BenchmarkToBytes(b *testing.B) {
+	x := Uuid25("3ud3gtvgolimgu9lah6aie99o")
+	for i := 0; i < b.N; i += 1 {
+		x.ToBytes()
+	}
+}
@@ -0,0 +1,91 @@
+package uuid25test
+
+import (
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Returns `n` distinct version 4 IDs generated deterministically from `seed`,
+// suitable as a benchmark corpus that is stable across runs.
+func Corpus(n int, seed int64) []uuid25.Uuid25 {
+	seq := NewSequence(seed)
+	seen := make(map[uuid25.Uuid25]struct{}, n)
+	corpus := make([]uuid25.Uuid25, 0, n)
+	for len(corpus) < n {
+		id := seq.Next()
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			corpus = append(corpus, id)
+		}
+	}
+	return corpus
+}
+
+// Returns the representations of `ids` in the format `f`.
+func CorpusStrings(ids []uuid25.Uuid25, f uuid25.Format) []string {
+	strs := make([]string, len(ids))
+	for i, e := range ids {
+		strs[i] = formatAs(e, f)
+	}
+	return strs
+}
+
+// Runs benchmarks of the core parsing and formatting functions as
+// sub-benchmarks of `b`.
+//
+// Downstream projects can call this function from their own benchmark to track
+// the performance of the package version they depend on in their CI:
+//
+//	func BenchmarkUuid25(b *testing.B) { uuid25test.RunBenchmarks(b) }
+func RunBenchmarks(b *testing.B) {
+	const corpusSize = 1024
+	ids := Corpus(corpusSize, 1)
+	formats := []uuid25.Format{
+		uuid25.FormatUuid25,
+		uuid25.FormatHex,
+		uuid25.FormatHyphenated,
+		uuid25.FormatBraced,
+		uuid25.FormatUrn,
+	}
+
+	for _, f := range formats {
+		strs := CorpusStrings(ids, f)
+		b.Run("Parse/"+f.String(), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i += 1 {
+				uuid25.Parse(strs[i%corpusSize])
+			}
+		})
+		b.Run("ParseNoAlloc/"+f.String(), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i += 1 {
+				uuid25.ParseNoAlloc(strs[i%corpusSize])
+			}
+		})
+		b.Run("AppendFormat/"+f.String(), func(b *testing.B) {
+			b.ReportAllocs()
+			buffer := make([]byte, 0, uuid25.MaxInputLen)
+			for i := 0; i < b.N; i += 1 {
+				ids[i%corpusSize].AppendFormat(buffer, f)
+			}
+		})
+	}
+
+	bytes := make([][16]byte, corpusSize)
+	for i, e := range ids {
+		bytes[i] = e.ToBytes()
+	}
+	b.Run("FromBytes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i += 1 {
+			uuid25.FromBytes(bytes[i%corpusSize][:])
+		}
+	})
+	b.Run("ToBytes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i += 1 {
+			ids[i%corpusSize].ToBytes()
+		}
+	})
+}
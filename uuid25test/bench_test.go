@@ -0,0 +1,33 @@
+package uuid25test
+
+import (
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests benchmark corpus generation.
+func TestCorpus(t *testing.T) {
+	a, b := Corpus(100, 1), Corpus(100, 1)
+	if len(a) != 100 {
+		t.Fatal("unexpected corpus size")
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatal("corpus must be deterministic")
+		}
+	}
+
+	strs := CorpusStrings(a, uuid25.FormatBraced)
+	for i, e := range strs {
+		if uuid25.DetectFormat(e) != uuid25.FormatBraced {
+			t.Fail()
+		}
+		AssertEqual(t, a[i], e)
+	}
+}
+
+// Runs the core benchmarks.
+func BenchmarkCore(b *testing.B) {
+	RunBenchmarks(b)
+}
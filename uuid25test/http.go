@@ -0,0 +1,126 @@
+package uuid25test
+
+import (
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// A deterministic, concurrency-safe source of version 4 UUIDs for fixtures.
+type Sequence struct {
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// Creates a sequence that yields the same IDs for the same `seed`.
+func NewSequence(seed int64) *Sequence {
+	return &Sequence{rand: rand.New(rand.NewSource(seed))}
+}
+
+// Returns the next ID of the sequence.
+func (s *Sequence) Next() uuid25.Uuid25 {
+	var b [16]byte
+	s.mu.Lock()
+	s.rand.Read(b[:])
+	s.mu.Unlock()
+	b[6] = 0x40 | b[6]&0x0f
+	b[8] = 0x80 | b[8]&0x3f
+	return uuid25.FromBytes(b[:])
+}
+
+// Starts a fake ID service that serves IDs taken from `seq` over HTTP.
+//
+// The server responds to GET requests to any path with `count` IDs (default 1,
+// max 1000), one per line, in the `format` given by the query parameters
+// (default "uuid25"). The caller should call Close() when finished.
+func NewIDServer(seq *Sequence) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		count := 1
+		if v := r.URL.Query().Get("count"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 || n > 1000 {
+				http.Error(w, "invalid count", http.StatusBadRequest)
+				return
+			}
+			count = n
+		}
+		format := uuid25.FormatUuid25
+		if v := r.URL.Query().Get("format"); v != "" {
+			if format.UnmarshalText([]byte(v)) != nil || format == uuid25.FormatInvalid {
+				http.Error(w, "invalid format", http.StatusBadRequest)
+				return
+			}
+		}
+
+		var sb strings.Builder
+		for i := 0; i < count; i += 1 {
+			sb.WriteString(formatAs(seq.Next(), format))
+			sb.WriteByte('\n')
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte(sb.String()))
+	}))
+}
+
+// An http.RoundTripper that sets a request ID header on outgoing requests that
+// do not have one.
+type RequestIDTransport struct {
+	// The underlying transport. The default is http.DefaultTransport.
+	Base http.RoundTripper
+
+	// The source of request IDs. The default is a sequence seeded with 0.
+	Seq *Sequence
+
+	// The header name. The default is "X-Request-ID".
+	Header string
+
+	once sync.Once
+}
+
+// Implements the http.RoundTripper interface.
+func (t *RequestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.once.Do(func() {
+		if t.Seq == nil {
+			t.Seq = NewSequence(0)
+		}
+		if t.Header == "" {
+			t.Header = "X-Request-ID"
+		}
+	})
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if req.Header.Get(t.Header) == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(t.Header, t.Seq.Next().String())
+	}
+	return base.RoundTrip(req)
+}
+
+// Returns the representation of `id` in the format `f`.
+func formatAs(id uuid25.Uuid25, f uuid25.Format) string {
+	switch f {
+	case uuid25.FormatHex:
+		return id.ToHex()
+	case uuid25.FormatHyphenated:
+		return id.ToHyphenated()
+	case uuid25.FormatBraced:
+		return id.ToBraced()
+	case uuid25.FormatUrn:
+		return id.ToUrn()
+	default:
+		return id.String()
+	}
+}
@@ -0,0 +1,92 @@
+package uuid25test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// Tests that sequences are deterministic and yield version 4 UUIDs.
+func TestSequence(t *testing.T) {
+	a, b := NewSequence(1), NewSequence(1)
+	for i := 0; i < 100; i += 1 {
+		x := a.Next()
+		if x != b.Next() || !AssertVersion(t, x, 4) {
+			t.Fatal("sequences must be deterministic")
+		}
+	}
+	if NewSequence(1).Next() == NewSequence(2).Next() {
+		t.Fail()
+	}
+}
+
+// Tests the fake ID server.
+func TestIDServer(t *testing.T) {
+	srv := NewIDServer(NewSequence(1))
+	defer srv.Close()
+	expected := NewSequence(1)
+
+	get := func(query string) (int, []string) {
+		res, err := http.Get(srv.URL + "/ids" + query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+		return res.StatusCode, strings.Fields(string(body))
+	}
+
+	if code, ids := get(""); code != 200 || len(ids) != 1 || ids[0] != expected.Next().String() {
+		t.Fail()
+	}
+	code, ids := get("?count=3&format=hyphenated")
+	if code != 200 || len(ids) != 3 {
+		t.Fatalf("unexpected response: %d %v", code, ids)
+	}
+	for _, e := range ids {
+		AssertEqual(t, expected.Next(), e)
+		if uuid25.DetectFormat(e) != uuid25.FormatHyphenated {
+			t.Fail()
+		}
+	}
+	for _, e := range []string{"?count=0", "?count=x", "?format=invalid", "?format=foo"} {
+		if code, _ := get(e); code != 400 {
+			t.Errorf("%s must be rejected", e)
+		}
+	}
+}
+
+// Tests injection of request IDs.
+func TestRequestIDTransport(t *testing.T) {
+	var received []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("X-Request-ID"))
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &RequestIDTransport{Seq: NewSequence(3)}}
+	expected := NewSequence(3)
+	for i := 0; i < 2; i += 1 {
+		res, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	req.Header.Set("X-Request-ID", "preset")
+	if res, err := client.Do(req); err == nil {
+		res.Body.Close()
+	}
+
+	if len(received) != 3 ||
+		received[0] != expected.Next().String() ||
+		received[1] != expected.Next().String() ||
+		received[2] != "preset" {
+		t.Errorf("unexpected request IDs: %v", received)
+	}
+}
@@ -0,0 +1,112 @@
+// Assertion helpers for tests handling Uuid25 values
+//
+// This package provides helpers that reduce the boilerplate in test suites of
+// code producing or consuming UUIDs. On failure, the helpers report the values
+// in both the Uuid25 and hyphenated formats, marking the differing digits, and
+// let the test continue, returning false. The package also provides
+// deterministic ID fixtures and a fake ID service for HTTP integration tests.
+package uuid25test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// The subset of testing.TB used by the helpers.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// Asserts that `got` and `want` represent the same UUID.
+//
+// Both arguments are compared by their 128-bit values, so `want` may be given in
+// any format accepted by Parse(). If they differ, the failure message shows both
+// values in the hyphenated format with the differing digits marked.
+func AssertEqual(t TB, got uuid25.Uuid25, want string) bool {
+	t.Helper()
+	expected, err := uuid25.Parse(want)
+	if err != nil {
+		t.Errorf("invalid expected UUID %q: %v", want, err)
+		return false
+	}
+	if _, err := uuid25.ParseUuid25(string(got)); err != nil {
+		t.Errorf("invalid Uuid25 value %q: %v\nwant: %s (%s)", string(got), err,
+			expected, expected.ToHyphenated())
+		return false
+	}
+	if got == expected {
+		return true
+	}
+
+	a, b := got.ToHyphenated(), expected.ToHyphenated()
+	t.Errorf("UUID mismatch\ngot:  %s (%s)\nwant: %s (%s)\n      %s  %s",
+		got, a, expected, b,
+		strings.Repeat(" ", len(got)), markDiff(a, b))
+	return false
+}
+
+// Asserts that `u` is a valid Uuid25 value whose version field equals
+// `version` and whose variant field is the one defined by RFC 9562.
+func AssertVersion(t TB, u uuid25.Uuid25, version int) bool {
+	t.Helper()
+	if _, err := uuid25.ParseUuid25(string(u)); err != nil {
+		t.Errorf("invalid Uuid25 value %q: %v", string(u), err)
+		return false
+	}
+	if got := u.Version(); got != version {
+		t.Errorf("UUID version mismatch for %s (%s)\ngot:  %d\nwant: %d",
+			u, u.ToHyphenated(), got, version)
+		return false
+	}
+	if got := u.Variant(); got != uuid25.VariantRFC9562 {
+		t.Errorf("UUID variant mismatch for %s (%s)\ngot:  %v\nwant: %v",
+			u, u.ToHyphenated(), got, uuid25.VariantRFC9562)
+		return false
+	}
+	return true
+}
+
+// Asserts that `s` is a valid UUID string in any format accepted by Parse()
+// and returns the parsed value, or the zero value on failure.
+func AssertParses(t TB, s string) uuid25.Uuid25 {
+	t.Helper()
+	uuid25, err := uuid25.Parse(s)
+	if err != nil {
+		t.Errorf("failed to parse %q as %s: %v", s, formatHint(s), err)
+	}
+	return uuid25
+}
+
+// Returns a line that marks the positions where `a` and `b` differ with `^`.
+func markDiff(a, b string) string {
+	var sb strings.Builder
+	for i := 0; i < len(a) && i < len(b); i += 1 {
+		if a[i] != b[i] {
+			sb.WriteByte('^')
+		} else {
+			sb.WriteByte(' ')
+		}
+	}
+	return strings.TrimRight(sb.String(), " ")
+}
+
+// Returns a description of the format `s` appears to be written in.
+func formatHint(s string) string {
+	switch len(s) {
+	case 25:
+		return "Uuid25"
+	case 32:
+		return "hex"
+	case 36:
+		return "hyphenated"
+	case 38:
+		return "braced"
+	case 45:
+		return "URN"
+	default:
+		return fmt.Sprintf("unknown format (length %d)", len(s))
+	}
+}
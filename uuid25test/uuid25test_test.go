@@ -0,0 +1,83 @@
+package uuid25test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/uuid25/go-uuid25"
+)
+
+// A TB that records failure messages.
+type recorder struct {
+	messages []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+
+// Tests equality assertions and their failure messages.
+func TestAssertEqual(t *testing.T) {
+	x := uuid25.Uuid25("dpoadk8izg9y4tte7vy1xt94o")
+	r := &recorder{}
+	if !AssertEqual(r, x, "e7a1d63b-7117-4423-8988-afcf12161878") ||
+		!AssertEqual(r, x, "{E7A1D63B-7117-4423-8988-AFCF12161878}") ||
+		!AssertEqual(r, x, "dpoadk8izg9y4tte7vy1xt94o") || len(r.messages) != 0 {
+		t.Fail()
+	}
+
+	if AssertEqual(r, x, "e7a1d63b-7117-4423-8988-afcf12161870") || len(r.messages) != 1 {
+		t.Fatal("mismatch must be reported")
+	}
+	lines := strings.Split(r.messages[0], "\n")
+	if len(lines) != 4 ||
+		!strings.Contains(lines[1], "e7a1d63b-7117-4423-8988-afcf12161878") ||
+		!strings.Contains(lines[2], "e7a1d63b-7117-4423-8988-afcf12161870") ||
+		strings.Index(lines[3], "^") != strings.Index(lines[1], "(")+36 {
+		t.Errorf("unexpected message:\n%s", r.messages[0])
+	}
+
+	r = &recorder{}
+	if AssertEqual(r, x, "foo") || AssertEqual(r, "foo", x.String()) || len(r.messages) != 2 {
+		t.Fail()
+	}
+}
+
+// Tests version assertions.
+func TestAssertVersion(t *testing.T) {
+	r := &recorder{}
+	v4, _ := uuid25.Parse("e7a1d63b-7117-4423-8988-afcf12161878")
+	v7, _ := uuid25.Parse("01809424-3e59-7c05-9219-566f82fff672")
+	if !AssertVersion(r, v4, 4) || !AssertVersion(r, v7, 7) || len(r.messages) != 0 {
+		t.Fail()
+	}
+	if AssertVersion(r, v4, 7) || !strings.Contains(r.messages[0], "got:  4") {
+		t.Fail()
+	}
+	ncs, _ := uuid25.Parse("e7a1d63b-7117-4423-0988-afcf12161878")
+	if AssertVersion(r, ncs, 4) || AssertVersion(r, "foo", 4) || len(r.messages) != 3 {
+		t.Fail()
+	}
+}
+
+// Tests parse assertions.
+func TestAssertParses(t *testing.T) {
+	r := &recorder{}
+	if AssertParses(r, "urn:uuid:e7a1d63b-7117-4423-8988-afcf12161878") != "dpoadk8izg9y4tte7vy1xt94o" ||
+		len(r.messages) != 0 {
+		t.Fail()
+	}
+	if AssertParses(r, "e7a1d63b-7117-4423-8988-afcf1216187g") != "" ||
+		len(r.messages) != 1 || !strings.Contains(r.messages[0], "hyphenated") {
+		t.Fail()
+	}
+}
+
+// Tests that *testing.T satisfies TB.
+func TestTB(t *testing.T) {
+	var _ TB = t
+	AssertEqual(t, "dpoadk8izg9y4tte7vy1xt94o", "e7a1d63b711744238988afcf12161878")
+}
@@ -0,0 +1,93 @@
+package uuid25
+
+import "errors"
+
+// An error returned by ParseVersion() and ParseV7() when a valid UUID string has
+// an unexpected version or variant.
+var ErrWrongVersion = errors.New("unexpected UUID version")
+
+// Creates an instance from a UUID string in any format accepted by Parse(),
+// verifying that the UUID has the RFC 9562 variant and the version `version`.
+//
+// This function returns a parse error if `uuidString` is invalid and
+// ErrWrongVersion if it is valid but of another version or variant, so that APIs
+// accepting only specific kinds of IDs can validate input in one step.
+func ParseVersion(uuidString string, version int) (Uuid25, error) {
+	x, err := decodeAny(uuidString)
+	if err != nil {
+		return "", newParseError(err, uuidString, formatOfLength(len(uuidString)))
+	}
+	if int(x.Hi>>12&0xf) != version || x.Lo>>62 != 0b10 {
+		return "", ErrWrongVersion
+	}
+	buffer := encodeBase36(x)
+	return Uuid25(buffer[:]), nil
+}
+
+// Creates an instance from a UUID string in any format accepted by Parse(),
+// verifying that the UUID is a time-ordered UUIDv7.
+//
+// This function is a shorthand for `ParseVersion(uuidString, 7)`, intended for
+// APIs that accept only time-ordered IDs, for example as pagination cursors.
+func ParseV7(uuidString string) (Uuid25, error) {
+	return ParseVersion(uuidString, 7)
+}
+
+// The variant field of a UUID, which determines the layout of the other bits.
+type Variant int
+
+const (
+	// The reserved variant for backward compatibility with the NCS UUID
+	// (`0b0xx`), which includes the Nil UUID.
+	VariantNCS Variant = iota
+
+	// The variant specified by RFC 9562 and RFC 4122 (`0b10x`), which all
+	// versions 1 through 8 use.
+	VariantRFC9562
+
+	// The reserved variant for backward compatibility with Microsoft GUIDs
+	// (`0b110`).
+	VariantMicrosoft
+
+	// The variant reserved for future definition (`0b111`), which includes the
+	// Max UUID.
+	VariantFuture
+)
+
+var variantNames = [...]string{"NCS", "RFC9562", "Microsoft", "Future"}
+
+// Returns the name of the variant, which is one of "NCS", "RFC9562",
+// "Microsoft", and "Future".
+func (v Variant) String() string {
+	if v < 0 || int(v) >= len(variantNames) {
+		return "unknown"
+	}
+	return variantNames[v]
+}
+
+// Returns the variant field of the UUID.
+//
+// This method panics if the receiver is not constructed properly.
+func (uuid25 Uuid25) Variant() Variant {
+	switch b := uuid25.toUint128().Lo >> 61; {
+	case b < 0b100:
+		return VariantNCS
+	case b < 0b110:
+		return VariantRFC9562
+	case b == 0b110:
+		return VariantMicrosoft
+	default:
+		return VariantFuture
+	}
+}
+
+// Returns the version field of the UUID, the most significant four bits of
+// the seventh byte.
+//
+// The version field is meaningful only if Variant() returns VariantRFC9562, so
+// check both to validate that a value is, for example, a proper UUIDv7. This
+// method returns 0 for the Nil UUID and 15 for the Max UUID, and it panics if
+// the receiver is not constructed properly.
+func (uuid25 Uuid25) Version() int {
+	return int(uuid25.toUint128().Hi >> 12 & 0xf)
+}
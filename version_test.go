@@ -0,0 +1,74 @@
+package uuid25
+
+import (
+	"errors"
+	"testing"
+)
+
+// Tests version-constrained parsing.
+func TestParseVersion(t *testing.T) {
+	cases := []struct {
+		s       string
+		version int
+		err     error
+	}{
+		{"01809424-3e59-7c05-9219-566f82fff672", 7, nil},
+		{"{01809424-3E59-7C05-9219-566F82FFF672}", 7, nil},
+		{"e7a1d63b-7117-4423-8988-afcf12161878", 4, nil},
+		{"dpoadk8izg9y4tte7vy1xt94o", 4, nil},
+		{"e7a1d63b-7117-4423-8988-afcf12161878", 7, ErrWrongVersion},
+		{"01809424-3e59-7c05-1219-566f82fff672", 7, ErrWrongVersion},
+		{"01809424-3e59-7c05-d219-566f82fff672", 7, ErrWrongVersion},
+		{"00000000-0000-0000-0000-000000000000", 0, ErrWrongVersion},
+		{"01809424-3e59-7c05-9219-566f82fff67", 7, ErrInvalidLength},
+		{"01809424-3e59-7c05-9219-566f82fff67g", 7, ErrInvalidDigit},
+	}
+	for _, e := range cases {
+		x, err := ParseVersion(e.s, e.version)
+		if !errors.Is(err, e.err) {
+			t.Errorf("unexpected error for %s: %v", e.s, err)
+		}
+		if e.err == nil {
+			if y, _ := Parse(e.s); x != y {
+				t.Errorf("unexpected result for %s: %s", e.s, x)
+			}
+		} else if x != "" {
+			t.Fail()
+		}
+		if e.version == 7 {
+			if y, err := ParseV7(e.s); y != x || !errors.Is(err, e.err) {
+				t.Fail()
+			}
+		}
+	}
+}
+
+// Tests Version() and Variant() against the version and variant fields.
+func TestVersionAndVariant(t *testing.T) {
+	cases := []struct {
+		s       string
+		version int
+		variant Variant
+	}{
+		{"00000000-0000-0000-0000-000000000000", 0, VariantNCS},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", 15, VariantFuture},
+		{"01809424-3e59-7c05-9219-566f82fff672", 7, VariantRFC9562},
+		{"e7a1d63b-7117-4423-8988-afcf12161878", 4, VariantRFC9562},
+		{"2ed6657d-e927-568b-95e1-2665a8aea6a2", 5, VariantRFC9562},
+		{"01809424-3e59-7c05-b219-566f82fff672", 7, VariantRFC9562},
+		{"01809424-3e59-7c05-7219-566f82fff672", 7, VariantNCS},
+		{"01809424-3e59-1c05-c219-566f82fff672", 1, VariantMicrosoft},
+		{"01809424-3e59-1c05-d219-566f82fff672", 1, VariantMicrosoft},
+		{"01809424-3e59-1c05-e219-566f82fff672", 1, VariantFuture},
+	}
+	for _, e := range cases {
+		x, _ := Parse(e.s)
+		if x.Version() != e.version || x.Variant() != e.variant {
+			t.Errorf("unexpected fields for %s: %d %v", e.s, x.Version(), x.Variant())
+		}
+	}
+
+	if VariantRFC9562.String() != "RFC9562" || Variant(-1).String() != "unknown" {
+		t.Fail()
+	}
+}
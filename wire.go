@@ -0,0 +1,88 @@
+package uuid25
+
+import "errors"
+
+// Returns the fixed 16-byte binary representation for binary protocols.
+//
+// Unlike MarshalBinary(), which returns the 25-byte Uuid25 string for
+// compatibility, this method is guaranteed to always return exactly 16 bytes
+// in the big-endian byte order of RFC 9562, in this and all future versions of
+// this package. It returns an error if the receiver is not constructed
+// properly.
+func (uuid25 Uuid25) MarshalWire() ([]byte, error) {
+	if len(uuid25) != 25 {
+		return nil, errImproperValue
+	}
+	b := uuid25.ToBytes()
+	return b[:], nil
+}
+
+// Appends the fixed 16-byte binary representation to `dst` and returns the
+// extended buffer.
+//
+// This method panics if the receiver is not constructed properly.
+func (uuid25 Uuid25) AppendWire(dst []byte) []byte {
+	b := uuid25.ToBytes()
+	return append(dst, b[:]...)
+}
+
+// Restores a value from the fixed 16-byte binary representation.
+//
+// Unlike UnmarshalBinary(), this method accepts nothing but exactly 16 bytes
+// and returns ErrInvalidWireLength otherwise, so that a text representation
+// shipped by mistake is rejected rather than silently accepted.
+func (uuid25 *Uuid25) UnmarshalWire(data []byte) error {
+	if uuid25 == nil {
+		return errors.New("nil receiver")
+	} else if len(data) != 16 {
+		return ErrInvalidWireLength
+	}
+	*uuid25 = FromBytes(data)
+	return nil
+}
+
+// An error returned by UnmarshalWire() when the data is not 16 bytes long.
+var ErrInvalidWireLength = errors.New("wire data must be 16 bytes")
+
+// A Uuid25 variant whose MarshalBinary() emits the compact 16-byte
+// representation, for gob, CBOR, and other binary encoders.
+//
+// Declare fields with this type to opt into the compact binary form without
+// affecting other code in the program, and convert between this type and
+// Uuid25 with a plain type conversion:
+//
+//	type Record struct {
+//		ID uuid25.Compact
+//	}
+//	record := Record{ID: uuid25.Compact(id)}
+//
+// The text and JSON representations are the same as those of Uuid25, and
+// UnmarshalBinary() accepts both the 16-byte and the text representations, so
+// data written with Uuid25 fields remains readable.
+type Compact Uuid25
+
+// Returns the 25-digit Uuid25 representation.
+func (c Compact) String() string {
+	return Uuid25(c).String()
+}
+
+// Implements the encoding.BinaryMarshaler interface, returning the same 16
+// bytes as MarshalWire().
+func (c Compact) MarshalBinary() ([]byte, error) {
+	return Uuid25(c).MarshalWire()
+}
+
+// Implements the encoding.BinaryUnmarshaler interface.
+func (c *Compact) UnmarshalBinary(data []byte) error {
+	return (*Uuid25)(c).UnmarshalBinary(data)
+}
+
+// Implements the encoding.TextMarshaler interface.
+func (c Compact) MarshalText() ([]byte, error) {
+	return Uuid25(c).MarshalText()
+}
+
+// Implements the encoding.TextUnmarshaler interface.
+func (c *Compact) UnmarshalText(text []byte) error {
+	return (*Uuid25)(c).UnmarshalText(text)
+}
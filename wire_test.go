@@ -0,0 +1,107 @@
+package uuid25
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+)
+
+// Tests the fixed 16-byte wire format.
+func TestWire(t *testing.T) {
+	var buffer []byte
+	for _, e := range testCases {
+		x := Uuid25(e.uuid25)
+		if y, err := x.MarshalWire(); !bytes.Equal(y, e.bytes) || err != nil {
+			t.Fail()
+		}
+		buffer = x.AppendWire(buffer)
+
+		var unmarshaled Uuid25
+		if unmarshaled.UnmarshalWire(e.bytes) != nil || unmarshaled != x {
+			t.Fail()
+		}
+		if unmarshaled.UnmarshalWire([]byte(e.uuid25)) != ErrInvalidWireLength {
+			t.Fail()
+		}
+	}
+	for i, e := range testCases {
+		if !bytes.Equal(buffer[16*i:16*i+16], e.bytes) {
+			t.Fail()
+		}
+	}
+
+	if _, err := Uuid25("").MarshalWire(); err == nil {
+		t.Fail()
+	}
+	var nilPtr *Uuid25
+	if nilPtr.UnmarshalWire(make([]byte, 16)) == nil {
+		t.Fail()
+	}
+}
+
+// Tests the compact binary representation of Compact.
+func TestCompact(t *testing.T) {
+	for _, e := range testCases {
+		x := Uuid25(e.uuid25)
+		compact, err := Compact(x).MarshalBinary()
+		if !bytes.Equal(compact, e.bytes) || err != nil {
+			t.Fail()
+		}
+		text, err := x.MarshalBinary()
+		if string(text) != e.uuid25 || err != nil {
+			t.Fail()
+		}
+		if y, err := Compact(x).MarshalText(); string(y) != e.uuid25 || err != nil {
+			t.Fail()
+		}
+
+		var unmarshaled Compact
+		if unmarshaled.UnmarshalBinary(compact) != nil || Uuid25(unmarshaled) != x {
+			t.Fail()
+		}
+		if unmarshaled.UnmarshalBinary(text) != nil || unmarshaled.String() != e.uuid25 {
+			t.Fail()
+		}
+	}
+	if _, err := Compact("").MarshalBinary(); err == nil {
+		t.Fail()
+	}
+
+	type record struct {
+		ID Uuid25
+	}
+	type compactRecord struct {
+		ID Compact
+	}
+	// compare the second messages, which carry no type descriptors
+	var text, compact bytes.Buffer
+	textEncoder, compactEncoder := gob.NewEncoder(&text), gob.NewEncoder(&compact)
+	for i := 0; i < 2; i += 1 {
+		text.Reset()
+		compact.Reset()
+		if textEncoder.Encode(record{"3ud3gtvgolimgu9lah6aie99o"}) != nil ||
+			compactEncoder.Encode(compactRecord{"3ud3gtvgolimgu9lah6aie99o"}) != nil {
+			t.Fatal("gob encoding failed")
+		}
+	}
+	if text.Len()-compact.Len() != 9 {
+		t.Errorf("unexpected gob sizes: %d %d", text.Len(), compact.Len())
+	}
+
+	// Compact fields decode data written with both Compact and Uuid25 fields
+	for _, e := range []any{compactRecord{"3ud3gtvgolimgu9lah6aie99o"}, record{"3ud3gtvgolimgu9lah6aie99o"}} {
+		var encoded bytes.Buffer
+		var decoded compactRecord
+		if gob.NewEncoder(&encoded).Encode(e) != nil ||
+			gob.NewDecoder(&encoded).Decode(&decoded) != nil ||
+			decoded.ID != "3ud3gtvgolimgu9lah6aie99o" {
+			t.Fail()
+		}
+	}
+
+	data, err := json.Marshal(compactRecord{"3ud3gtvgolimgu9lah6aie99o"})
+	if err != nil || string(data) != `{"ID":"3ud3gtvgolimgu9lah6aie99o"}` {
+		t.Fail()
+	}
+}